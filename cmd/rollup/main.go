@@ -0,0 +1,10 @@
+package main
+
+import (
+	"ew/internal/app"
+	"os"
+)
+
+func main() {
+	os.Exit(app.RunRollup(os.Args[1:]))
+}
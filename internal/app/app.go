@@ -1,9 +1,17 @@
 package app
 
 import (
+	"context"
 	"ew/internal/database"
+	"ew/internal/gql"
+	"ew/internal/logging"
+	"ew/internal/models/events"
+	"ew/internal/rpc"
+	"ew/internal/storage/cache"
 	"ew/internal/storage/postgres"
+	"ew/internal/tracing"
 	"ew/internal/transport"
+	"net"
 	"os"
 	"os/signal"
 	"strconv"
@@ -12,42 +20,74 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 )
 
 func Run() {
+	logging.Init()
+
+	shutdownTracing, err := tracing.Init(context.Background())
+	if err != nil {
+		logrus.WithError(err).Fatal("error setting up tracing")
+	}
+
 	db := database.InitDB()
 	queryBuilder := database.InitQueryBuilder()
 
 	repo := postgres.NewRepo(db, queryBuilder)
-	validate := validator.New()
+	serviceRepo := postgres.NewServiceRepo(db, queryBuilder)
 
-	server := transport.NewServer(repo, validate)
+	eventLogSize := events.DefaultLogSize
+	if size := os.Getenv("EVENTS_LOG_SIZE"); size != "" {
+		eventLogSize, err = strconv.Atoi(size)
+		if err != nil || eventLogSize <= 0 {
+			logrus.Fatalf("invalid EVENTS_LOG_SIZE %q", size)
+		}
+	}
+	// the repositories record the events of their writes in the same transactions
+	eventRepo := postgres.NewEventRepo(db, queryBuilder, eventLogSize)
+	repo.Events = eventRepo
+	serviceRepo.Events = eventRepo
 
-	webApp := fiber.New(fiber.Config{
-		ReadTimeout:  3 * time.Second,
-		WriteTimeout: 3 * time.Second,
-	})
-	webApp.Use(logger.New(logger.Config{
-		Format: "${time} ${method} ${path} - ${status} - ${latency}\n",
-	}))
-	webApp.Use(recover.New())
+	validate := validator.New()
 
-	transport.RegisterHandlers(webApp, transport.NewStrictHandler(
-		server,
-		[]transport.StrictMiddlewareFunc{},
-	))
+	cachedRepo := cache.NewRepo(repo)
+	if ttl := os.Getenv("CACHE_TTL"); ttl != "" {
+		cachedRepo.TTL, err = time.ParseDuration(ttl)
+		if err != nil || cachedRepo.TTL <= 0 {
+			logrus.Fatalf("invalid CACHE_TTL %q", ttl)
+		}
+	}
+	server := transport.NewServer(cachedRepo, cache.NewServiceRepo(serviceRepo, cachedRepo), validate)
+	if locale := os.Getenv("DEFAULT_LOCALE"); locale != "" {
+		if _, found := server.Translations.GetTranslator(locale); !found {
+			logrus.Fatalf("unsupported DEFAULT_LOCALE %q", locale)
+		}
+		server.DefaultLocale = locale
+	}
 
-	loggingLevel, err := strconv.Atoi(os.Getenv("LOGGING_LEVEL"))
-	if err != nil {
-		logrus.WithError(err).Error("error parsing LOGGING_LEVEL, set to default = 2 (ErrorLevel)")
-		logrus.SetLevel(logrus.ErrorLevel)
-	} else {
-		logrus.SetLevel(logrus.Level(loggingLevel))
+	idempotencyRepo := postgres.NewIdempotencyRepo(db, queryBuilder)
+	server.Idempotency = idempotencyRepo
+	if ttl := os.Getenv("IDEMPOTENCY_TTL"); ttl != "" {
+		server.IdempotencyTTL, err = time.ParseDuration(ttl)
+		if err != nil || server.IdempotencyTTL <= 0 {
+			logrus.Fatalf("invalid IDEMPOTENCY_TTL %q", ttl)
+		}
 	}
 
+	server.Events = eventRepo
+
+	// the handlers take copies of the server, so it has to be complete by now
+	webApp := newWebApp(server)
+	grpcServer := newGRPCServer(server)
+
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	go refreshRollup(backgroundCtx, repo)
+	go purgeIdempotencyKeys(backgroundCtx, idempotencyRepo)
+	go eventRepo.Listen(backgroundCtx)
+
 	go func() {
 		logrus.Info("Listening on :" + os.Getenv("HTTP_BIND"))
 
@@ -56,19 +96,90 @@ func Run() {
 		}
 	}()
 
+	go func() {
+		logrus.Info("gRPC listening on :" + os.Getenv("GRPC_BIND"))
+
+		listener, err := net.Listen("tcp", ":"+os.Getenv("GRPC_BIND"))
+		if err != nil {
+			logrus.Panic(err)
+		}
+		if err := grpcServer.Serve(listener); err != nil {
+			logrus.Panic(err)
+		}
+	}()
+
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
 	<-c
 	logrus.Info("Gracefully shutting down...")
 
+	// ends the open event streams, the shutdown waits for them otherwise
+	eventRepo.Close()
+
 	if err := webApp.ShutdownWithTimeout(5 * time.Second); err != nil {
 		logrus.Fatalf("Fiber server shutdown error: %v", err)
 	}
+	stopGRPC(grpcServer, 5*time.Second)
 
 	logrus.Info("Running cleanup tasks...")
 
+	stopBackground()
+
 	db.Close()
 
+	if err := shutdownTracing(context.Background()); err != nil {
+		logrus.WithError(err).Error("error flushing traces")
+	}
+
 	logrus.Info("Fiber was successfully shut down.")
 }
+
+// newWebApp serves the REST and the GraphQL APIs of the server.
+func newWebApp(server transport.Server) *fiber.App {
+	webApp := fiber.New(fiber.Config{
+		ReadTimeout:  3 * time.Second,
+		WriteTimeout: 3 * time.Second,
+		ErrorHandler: transport.ErrorHandler,
+	})
+	webApp.Use(tracing.Middleware)
+	webApp.Use(logging.Middleware)
+	webApp.Use(recover.New())
+	webApp.Use(transport.ProblemContentType)
+	webApp.Use(transport.Locale)
+	webApp.Use(transport.CacheControl)
+
+	transport.RegisterHandlers(webApp, transport.NewStrictHandler(
+		server,
+		[]transport.StrictMiddlewareFunc{transport.Logging, transport.Tracing},
+	))
+	webApp.Post("/graphql", gql.Handler(server))
+
+	return webApp
+}
+
+func newGRPCServer(server transport.Server) *grpc.Server {
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(rpc.UnaryInterceptor),
+		grpc.StreamInterceptor(rpc.StreamInterceptor),
+	)
+	rpc.NewServer(server).Register(grpcServer)
+
+	return grpcServer
+}
+
+// stopGRPC waits for the running calls, such as open streams, up to the timeout and then cancels them.
+func stopGRPC(server *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		logrus.Warn("gRPC calls still running, stopping them")
+		server.Stop()
+	}
+}
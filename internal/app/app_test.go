@@ -0,0 +1,57 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"ew/internal/models/events"
+	"ew/internal/models/subscriptions"
+	"ew/internal/storage/inmemory"
+	"ew/internal/transport"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func TestWebAppPublishesEvents(t *testing.T) {
+	repo := inmemory.NewRepo([]*subscriptions.Subscription{})
+	server := transport.NewServer(repo, inmemory.NewServiceRepo(nil, repo), validator.New())
+	eventRepo := inmemory.NewEventRepo(events.DefaultLogSize)
+	repo.Events = eventRepo
+	server.Events = eventRepo
+
+	webApp := newWebApp(server)
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	body, _ := json.Marshal(transport.Subscription{Price: 105, StartDate: "11-2000", UserId: uuid.New(), ServiceName: "test"})
+	req := httptest.NewRequest("POST", "/subscriptions", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := webApp.Test(req)
+	if err != nil || resp.StatusCode != 200 {
+		t.Fatalf("create failed: %v %v", resp, err)
+	}
+	var created transport.CreateSubscription200JSONResponse
+	json.NewDecoder(resp.Body).Decode(&created)
+
+	// the GraphQL mutations publish as well
+	query, _ := json.Marshal(map[string]any{
+		"query":     `mutation($id: ID!) { deleteSubscription(id: $id) }`,
+		"variables": map[string]any{"id": created.SubscriptionId.String()},
+	})
+	req = httptest.NewRequest("POST", "/graphql", bytes.NewReader(query))
+	req.Header.Set("Content-Type", "application/json")
+	if resp, err = webApp.Test(req); err != nil || resp.StatusCode != 200 {
+		t.Fatalf("delete failed: %v %v", resp, err)
+	}
+
+	published, err := eventRepo.Since(context.Background(), 0)
+	if err != nil || len(published) != 2 {
+		t.Fatalf("expected two published events, got %v %v", published, err)
+	}
+	if published[0].Type != events.Created || published[1].Type != events.Deleted || published[1].SubscriptionID != *created.SubscriptionId {
+		t.Errorf("unexpected events %v", published)
+	}
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"context"
+	"ew/internal/models/idempotency"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const idempotencyPurgeInterval = time.Hour
+
+// purgeIdempotencyKeys deletes the expired Idempotency-Key records until the context is done.
+func purgeIdempotencyKeys(ctx context.Context, repo idempotency.Repo) {
+	ticker := time.NewTicker(idempotencyPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := repo.Purge(ctx)
+		if err != nil {
+			logrus.WithError(err).Error("error purging idempotency keys")
+		} else if purged > 0 {
+			logrus.WithField("purged", purged).Info("purged expired idempotency keys")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
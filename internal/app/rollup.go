@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"ew/internal/database"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+	"ew/internal/storage/postgres"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const rollupRefreshInterval = time.Hour
+
+// RunRollup maintains the monthly_spend table by hand:
+// "rebuild" recomputes it from scratch and "check" reports where it differs from the raw subscriptions.
+// It returns the exit code of the command.
+func RunRollup(args []string) int {
+	if len(args) != 1 || (args[0] != "rebuild" && args[0] != "check") {
+		fmt.Println("usage: rollup rebuild|check")
+		return 2
+	}
+
+	logging.Init()
+
+	db := database.InitDB()
+	defer db.Close()
+
+	repo := postgres.NewRepo(db, database.InitQueryBuilder())
+	ctx := context.Background()
+
+	if args[0] == "rebuild" {
+		if err := repo.RebuildMonthlySpend(ctx); err != nil {
+			logrus.WithError(err).Error("error rebuilding monthly spend")
+			return 1
+		}
+		logrus.Info("monthly spend rebuilt")
+		return 0
+	}
+
+	mismatches, err := repo.CheckMonthlySpend(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("error checking monthly spend")
+		return 1
+	}
+	for _, mismatch := range mismatches {
+		fmt.Printf("%s\t%s\texpected %d\tactual %d\n", mismatch.UserId, mismatch.ServiceName, mismatch.Expected, mismatch.Actual)
+	}
+	if len(mismatches) > 0 {
+		logrus.Errorf("monthly spend differs for %d user and service pairs", len(mismatches))
+		return 1
+	}
+	logrus.Info("monthly spend is consistent")
+	return 0
+}
+
+// refreshRollup appends the new month of the open subscriptions to monthly_spend until the context is done.
+// The previous month is refreshed as well, so a month boundary missed while the server was down is caught up.
+func refreshRollup(ctx context.Context, repo *postgres.SubscriptionRepository) {
+	ticker := time.NewTicker(rollupRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		from := subscriptions.MonthStart(time.Now()).AddDate(0, -1, 0)
+		if err := repo.RefreshMonthlySpend(ctx, from); err != nil {
+			logrus.WithError(err).Error("error refreshing monthly spend")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"database/sql/driver"
+	"strings"
+)
+
+// TextArray is a []string that goqu passes to postgres as a single text[] value
+// instead of expanding it into a list of placeholders.
+type TextArray []string
+
+var arrayEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func (a TextArray) Value() (driver.Value, error) {
+	b := strings.Builder{}
+	b.WriteByte('{')
+	for i, item := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('"')
+		b.WriteString(arrayEscaper.Replace(item))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+
+	return b.String(), nil
+}
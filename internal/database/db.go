@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"ew/internal/tracing"
 	"os"
 	"strconv"
 
@@ -18,6 +19,7 @@ func InitDB() *pgxpool.Pool {
 	cfg.ConnConfig.Password = os.Getenv("POSTGRES_PASSWORD")
 	cfg.ConnConfig.Database = os.Getenv("POSTGRES_DB")
 	cfg.MaxConns = 20
+	cfg.ConnConfig.Tracer = tracing.QueryTracer{}
 
 	dbPool, err := pgxpool.NewWithConfig(context.Background(), cfg)
 	if err != nil {
@@ -0,0 +1,65 @@
+package gql
+
+import (
+	"context"
+	"ew/internal/transport"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// problemCodes are the extension codes of the REST problem statuses, the rest are INTERNAL_SERVER_ERROR.
+var problemCodes = map[int]string{
+	http.StatusBadRequest:           "BAD_USER_INPUT",
+	http.StatusNotFound:             "NOT_FOUND",
+	http.StatusConflict:             "CONFLICT",
+	http.StatusUnsupportedMediaType: "BAD_USER_INPUT",
+	http.StatusUnprocessableEntity:  "BAD_USER_INPUT",
+}
+
+// problemError puts a problem of transport.Server into the extensions of a GraphQL error.
+type problemError transport.Problem
+
+func (e problemError) Error() string {
+	if e.Detail != nil {
+		return *e.Detail
+	}
+	return e.Title
+}
+
+func (e problemError) Extensions() map[string]any {
+	code, ok := problemCodes[e.Status]
+	if !ok {
+		code = "INTERNAL_SERVER_ERROR"
+	}
+
+	res := map[string]any{"code": code, "status": e.Status}
+	if e.Errors != nil {
+		res["errors"] = *e.Errors
+	}
+	return res
+}
+
+// parseID reports a malformed identifier like the REST API rejects it while binding the request.
+func (r *Resolver) parseID(ctx context.Context, field string, id graphql.ID) (transport.UUID, error) {
+	res, invalid := r.REST.ParseUUID(ctx, field, string(id))
+	if invalid != nil {
+		return res, problemError(*invalid)
+	}
+	return res, nil
+}
+
+func (r *Resolver) parseIDs(ctx context.Context, field string, ids *[]graphql.ID) (*[]transport.UUID, error) {
+	if ids == nil {
+		return nil, nil
+	}
+	res := make([]transport.UUID, 0, len(*ids))
+	for _, id := range *ids {
+		parsed, err := r.parseID(ctx, field, id)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, parsed)
+	}
+	return &res, nil
+}
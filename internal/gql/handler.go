@@ -0,0 +1,38 @@
+package gql
+
+import (
+	"ew/internal/logging"
+	"ew/internal/transport"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Handler serves the GraphQL requests posted as JSON. Every request gets its own loaders,
+// the errors of the resolvers are reported in the response body with the status 200.
+func Handler(rest transport.Server) fiber.Handler {
+	resolver := &Resolver{REST: rest}
+	schema := graphql.MustParseSchema(schemaSource, resolver)
+
+	return func(c *fiber.Ctx) error {
+		var req request
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		c.Locals(logging.OperationLocal, "graphql")
+		ctx := withLoaders(c.UserContext(), resolver.newLoaders())
+
+		res := schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+		if len(res.Errors) > 0 {
+			logging.FromContext(ctx).WithField("errors", res.Errors).Info("graphql request failed")
+		}
+		return c.JSON(res)
+	}
+}
@@ -0,0 +1,245 @@
+package gql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"ew/internal/models/subscriptions"
+	"ew/internal/storage/inmemory"
+	"ew/internal/transport"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	id1   = uuid.New()
+	id2   = uuid.New()
+	id3   = uuid.New()
+	user1 = uuid.New()
+	user2 = uuid.New()
+)
+
+// countingRepo counts the list and the summary queries to check that nested fields are batched.
+type countingRepo struct {
+	*inmemory.SubscriptionRepository
+	lists     int
+	summaries int
+}
+
+func (r *countingRepo) GetList(ctx context.Context, params subscriptions.SubscriptionListParams) ([]*subscriptions.Subscription, error) {
+	r.lists++
+	return r.SubscriptionRepository.GetList(ctx, params)
+}
+
+func (r *countingRepo) GetUserSummary(ctx context.Context, userId uuid.UUID) (*subscriptions.UserSummary, error) {
+	r.summaries++
+	return r.SubscriptionRepository.GetUserSummary(ctx, userId)
+}
+
+func (r *countingRepo) GetUserSummaries(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID]*subscriptions.UserSummary, error) {
+	r.summaries++
+	return r.SubscriptionRepository.GetUserSummaries(ctx, userIds)
+}
+
+func prepareServ() (*fiber.App, *countingRepo) {
+	repo := &countingRepo{SubscriptionRepository: inmemory.NewRepo([]*subscriptions.Subscription{
+		{ID: id1, ServiceName: "some item", Price: 125, StartDate: time.Now().AddDate(-1, -5, 0), UserId: user1},
+		{ID: id2, ServiceName: "item 2", Price: 250, StartDate: time.Now().AddDate(0, -2, 0), UserId: user1},
+		{ID: id3, ServiceName: "nothing", Price: 500, StartDate: time.Now().AddDate(0, -1, 0), UserId: user2},
+	})}
+	server := transport.NewServer(repo, inmemory.NewServiceRepo(nil, repo.SubscriptionRepository), validator.New())
+	server.Idempotency = inmemory.NewIdempotencyRepo()
+
+	webApp := fiber.New(fiber.Config{ErrorHandler: transport.ErrorHandler})
+	webApp.Use(transport.Locale)
+	webApp.Post("/graphql", Handler(server))
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	return webApp, repo
+}
+
+type response struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func exec(t *testing.T, webApp *fiber.App, query string, variables map[string]any) response {
+	t.Helper()
+	return execIn(t, webApp, "", query, variables)
+}
+
+// execIn posts the query with the Accept-Language header of the locale.
+func execIn(t *testing.T, webApp *fiber.App, locale, query string, variables map[string]any) response {
+	t.Helper()
+	body, _ := json.Marshal(request{Query: query, Variables: variables})
+	req := httptest.NewRequest("POST", "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", locale)
+
+	resp, err := webApp.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+
+	var res response
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestHandlerQuery(t *testing.T) {
+	webApp, _ := prepareServ()
+
+	res := exec(t, webApp, `query($id: ID!) { subscription(id: $id) { id serviceName price tags user { id } } }`,
+		map[string]any{"id": id2.String()})
+	if len(res.Errors) != 0 {
+		t.Fatal(res.Errors)
+	}
+	var data struct {
+		ID          string
+		ServiceName string
+		Price       int
+		Tags        []string
+		User        struct{ ID string }
+	}
+	json.Unmarshal(res.Data["subscription"], &data)
+	if data.ID != id2.String() || data.ServiceName != "item 2" || data.Price != 250 || data.Tags == nil || data.User.ID != user1.String() {
+		t.Errorf("unexpected subscription %s", res.Data["subscription"])
+	}
+
+	res = exec(t, webApp, `{ subscription(id: "`+uuid.NewString()+`") { id } }`, nil)
+	if len(res.Errors) != 0 || string(res.Data["subscription"]) != "null" {
+		t.Errorf("unexpected response for a missing subscription %v", res)
+	}
+
+	res = exec(t, webApp, `{ stats(filter: {serviceNames: ["item 2"]}) { totalPrice } }`, nil)
+	if string(res.Data["stats"]) != `{"totalPrice":750}` {
+		t.Errorf("unexpected stats %s", res.Data["stats"])
+	}
+}
+
+func TestHandlerBatching(t *testing.T) {
+	webApp, repo := prepareServ()
+
+	res := exec(t, webApp, `query($ids: [ID!]!) { users(ids: $ids) { id summary { activeCount } subscriptions { id user { id } } } }`,
+		map[string]any{"ids": []string{user1.String(), user2.String()}})
+	if len(res.Errors) != 0 {
+		t.Fatal(res.Errors)
+	}
+	var users []struct {
+		ID            string
+		Summary       struct{ ActiveCount int }
+		Subscriptions []struct{ ID string }
+	}
+	json.Unmarshal(res.Data["users"], &users)
+	if len(users) != 2 || len(users[0].Subscriptions) != 2 || len(users[1].Subscriptions) != 1 {
+		t.Errorf("unexpected users %s", res.Data["users"])
+	}
+	if users[0].Summary.ActiveCount != 2 || users[1].Summary.ActiveCount != 1 {
+		t.Errorf("unexpected summaries %s", res.Data["users"])
+	}
+	if repo.lists != 1 {
+		t.Errorf("subscriptions of the users listed %d times", repo.lists)
+	}
+	if repo.summaries != 1 {
+		t.Errorf("summaries of the users read %d times", repo.summaries)
+	}
+
+	repo.lists = 0
+	res = exec(t, webApp, `{ subscriptions { id user { subscriptions { id } } } }`, nil)
+	if len(res.Errors) != 0 {
+		t.Fatal(res.Errors)
+	}
+	if repo.lists != 2 {
+		t.Errorf("list and its users listed %d times, expected 2", repo.lists)
+	}
+}
+
+func TestHandlerMutation(t *testing.T) {
+	webApp, repo := prepareServ()
+
+	res := exec(t, webApp, `mutation($input: SubscriptionInput!) { createSubscription(input: $input) { subscription { id category } duplicates } }`,
+		map[string]any{"input": map[string]any{
+			"serviceName": "test", "price": 105, "userId": user2.String(), "startDate": "11-2000", "endDate": "12-2000", "category": "Music",
+		}})
+	if len(res.Errors) != 0 {
+		t.Fatal(res.Errors)
+	}
+	var created struct {
+		Subscription struct {
+			ID       string
+			Category *string
+		}
+	}
+	json.Unmarshal(res.Data["createSubscription"], &created)
+	id := uuid.MustParse(created.Subscription.ID)
+
+	// null clears the field, the omitted ones stay
+	res = exec(t, webApp, `mutation($id: ID!) { updateSubscription(id: $id, patch: {endDate: null, category: null, price: 110}) { price endDate category } }`,
+		map[string]any{"id": id.String()})
+	if len(res.Errors) != 0 {
+		t.Fatal(res.Errors)
+	}
+	stored, _ := repo.GetByID(context.Background(), id)
+	if stored.EndDate != nil || stored.Category != nil || stored.Price != 110 || stored.ServiceName != "test" {
+		t.Errorf("unexpected update %v", stored)
+	}
+
+	res = exec(t, webApp, `mutation($id: ID!) { deleteSubscription(id: $id) }`, map[string]any{"id": id.String()})
+	if string(res.Data["deleteSubscription"]) != "true" {
+		t.Errorf("not deleted %v", res)
+	}
+	res = exec(t, webApp, `mutation($id: ID!) { deleteSubscription(id: $id) }`, map[string]any{"id": id.String()})
+	if string(res.Data["deleteSubscription"]) != "false" {
+		t.Errorf("deleted again %v", res)
+	}
+}
+
+func TestHandlerValidation(t *testing.T) {
+	webApp, _ := prepareServ()
+
+	res := exec(t, webApp, `mutation { createSubscription(input: {serviceName: "test", price: -1, userId: "`+user1.String()+`", startDate: "2000-11"}) { duplicates } }`, nil)
+	if len(res.Errors) != 1 || res.Errors[0].Extensions["code"] != "BAD_USER_INPUT" {
+		t.Fatalf("unexpected errors %v", res.Errors)
+	}
+	fields := map[string]bool{}
+	for _, field := range res.Errors[0].Extensions["errors"].([]any) {
+		fields[field.(map[string]any)["field"].(string)] = true
+	}
+	if !fields["price"] || !fields["start_date"] {
+		t.Errorf("unexpected field errors %v", res.Errors[0].Extensions)
+	}
+
+	res = exec(t, webApp, `{ subscription(id: "not-an-id") { id } }`, nil)
+	if len(res.Errors) != 1 || res.Errors[0].Extensions["code"] != "BAD_USER_INPUT" {
+		t.Fatalf("malformed id accepted %v", res.Errors)
+	}
+	english := res.Errors[0].Extensions["errors"].([]any)[0].(map[string]any)["message"]
+	if english != "id must be a valid UUID" {
+		t.Errorf("unexpected message %v", english)
+	}
+
+	// the message follows accept-language like the REST API
+	res = execIn(t, webApp, "ru-RU", `{ subscription(id: "not-an-id") { id } }`, nil)
+	if len(res.Errors) != 1 || res.Errors[0].Extensions["errors"].([]any)[0].(map[string]any)["message"] == english {
+		t.Errorf("untranslated malformed id %v", res.Errors)
+	}
+
+	res = exec(t, webApp, `{ subscriptions(filter: {status: "paused"}) { id } }`, nil)
+	if len(res.Errors) != 1 || res.Errors[0].Extensions["code"] != "BAD_USER_INPUT" {
+		t.Errorf("invalid status accepted %v", res.Errors)
+	}
+}
@@ -0,0 +1,66 @@
+package gql
+
+import (
+	"context"
+	"sync"
+)
+
+type batch[K comparable, V any] struct {
+	done   chan struct{}
+	values map[K]V
+	err    error
+}
+
+// loader batches and caches the lookups of one request. The resolvers of a list register the keys of its items,
+// the first Load of any of them then fetches all registered keys at once, so the nested fields of N items
+// cost one fetch instead of N. Keys loaded without being registered are fetched on their own.
+type loader[K comparable, V any] struct {
+	fetch func(context.Context, []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	batches map[K]*batch[K, V]
+}
+
+func newLoader[K comparable, V any](fetch func(context.Context, []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{fetch: fetch, batches: make(map[K]*batch[K, V])}
+}
+
+// Register adds the keys to the next fetch.
+func (l *loader[K, V]) Register(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		if _, ok := l.batches[key]; !ok {
+			l.pending = append(l.pending, key)
+			l.batches[key] = nil
+		}
+	}
+}
+
+// Load returns the value of the key, the zero value if the fetch did not return one.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	b := l.batches[key]
+	if b != nil {
+		l.mu.Unlock()
+		<-b.done
+		return b.values[key], b.err
+	}
+
+	keys := l.pending
+	if _, registered := l.batches[key]; !registered {
+		keys = append(keys, key)
+	}
+	b = &batch[K, V]{done: make(chan struct{})}
+	for _, k := range keys {
+		l.batches[k] = b
+	}
+	l.pending = nil
+	l.mu.Unlock()
+
+	b.values, b.err = l.fetch(ctx, keys)
+	close(b.done)
+	return b.values[key], b.err
+}
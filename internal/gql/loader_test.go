@@ -0,0 +1,44 @@
+package gql
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestLoader(t *testing.T) {
+	var fetched [][]int
+	l := newLoader(func(_ context.Context, keys []int) (map[int]string, error) {
+		fetched = append(fetched, slices.Clone(keys))
+		res := map[int]string{}
+		for _, key := range keys {
+			if key != 3 {
+				res[key] = string(rune('a' + key))
+			}
+		}
+		return res, nil
+	})
+	ctx := context.Background()
+
+	l.Register(1, 2, 3, 1)
+	if v, _ := l.Load(ctx, 2); v != "c" {
+		t.Errorf("unexpected value %q", v)
+	}
+	if v, _ := l.Load(ctx, 1); v != "b" {
+		t.Errorf("unexpected value %q", v)
+	}
+	if v, _ := l.Load(ctx, 3); v != "" {
+		t.Errorf("unexpected value %q", v)
+	}
+	// unregistered keys are fetched on their own, the loaded ones are cached
+	if v, _ := l.Load(ctx, 4); v != "e" {
+		t.Errorf("unexpected value %q", v)
+	}
+	l.Register(4, 5)
+	l.Load(ctx, 5)
+
+	expected := [][]int{{1, 2, 3}, {4}, {5}}
+	if !slices.EqualFunc(fetched, expected, slices.Equal) {
+		t.Errorf("fetched %v, expected %v", fetched, expected)
+	}
+}
@@ -0,0 +1,422 @@
+package gql
+
+import (
+	"context"
+	_ "embed"
+	"ew/internal/transport"
+	"fmt"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/oapi-codegen/nullable"
+)
+
+//go:embed schema.graphql
+var schemaSource string
+
+// Resolver answers the queries and the mutations through transport.Server,
+// so they share the repository, the validation and the localized messages with the REST API.
+type Resolver struct {
+	REST transport.Server
+}
+
+// queryResolver and mutationResolver are the roots of the operations, Resolver itself cannot be the query root
+// because graphql-go reserves its Subscription method for the subscription operation.
+type queryResolver struct{ *Resolver }
+
+type mutationResolver struct{ *Resolver }
+
+func (r *Resolver) Query() *queryResolver {
+	return &queryResolver{r}
+}
+
+func (r *Resolver) Mutation() *mutationResolver {
+	return &mutationResolver{r}
+}
+
+type loaders struct {
+	subscriptions *loader[transport.UUID, []transport.Subscription]
+	summaries     *loader[transport.UUID, transport.UserSummary]
+}
+
+func (r *Resolver) newLoaders() *loaders {
+	return &loaders{
+		subscriptions: newLoader(r.subscriptionsByUser),
+		summaries:     newLoader(r.summaries),
+	}
+}
+
+func (l *loaders) register(userIds ...transport.UUID) {
+	l.subscriptions.Register(userIds...)
+	l.summaries.Register(userIds...)
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func unexpected(res any) error {
+	return fmt.Errorf("unexpected response %T", res)
+}
+
+func (r *Resolver) list(ctx context.Context, params transport.ListSubscriptionsParams) ([]transport.Subscription, error) {
+	res, err := r.REST.ListSubscriptions(ctx, transport.ListSubscriptionsRequestObject{Params: params})
+	if err != nil {
+		return nil, err
+	}
+
+	switch res := res.(type) {
+	case transport.ListSubscriptions200JSONResponse:
+		return res, nil
+	case transport.ListSubscriptionsdefaultApplicationProblemPlusJSONResponse:
+		return nil, problemError(res.Body)
+	}
+	return nil, unexpected(res)
+}
+
+// subscriptionsByUser lists the subscriptions of all the users at once.
+func (r *Resolver) subscriptionsByUser(ctx context.Context, userIds []transport.UUID) (map[transport.UUID][]transport.Subscription, error) {
+	items, err := r.list(ctx, transport.ListSubscriptionsParams{UserId: &userIds})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[transport.UUID][]transport.Subscription, len(userIds))
+	for _, item := range items {
+		res[item.UserId] = append(res[item.UserId], item)
+	}
+	return res, nil
+}
+
+// summaries reads the summaries of all the users at once.
+func (r *Resolver) summaries(ctx context.Context, userIds []transport.UUID) (map[transport.UUID]transport.UserSummary, error) {
+	return r.REST.UserSummaries(ctx, userIds)
+}
+
+type subscriptionFilter struct {
+	StartDate         *string
+	EndDate           *string
+	UserIds           *[]graphql.ID
+	ServiceNames      *[]string
+	Categories        *[]string
+	Tags              *[]string
+	ServiceNamePrefix *string
+	PriceMin          *int32
+	PriceMax          *int32
+	Status            *string
+}
+
+func optionalInt(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+	res := int(*value)
+	return &res
+}
+
+func (r *queryResolver) Subscriptions(ctx context.Context, args struct {
+	Filter *subscriptionFilter
+	Offset *int32
+	Limit  *int32
+}) ([]*subscriptionResolver, error) {
+	filter := args.Filter
+	if filter == nil {
+		filter = &subscriptionFilter{}
+	}
+
+	userIds, err := r.parseIDs(ctx, "userIds", filter.UserIds)
+	if err != nil {
+		return nil, err
+	}
+
+	params := transport.ListSubscriptionsParams{
+		Offset:            optionalInt(args.Offset),
+		Limit:             optionalInt(args.Limit),
+		StartDate:         filter.StartDate,
+		EndDate:           filter.EndDate,
+		UserId:            userIds,
+		ServiceName:       filter.ServiceNames,
+		Category:          filter.Categories,
+		Tag:               filter.Tags,
+		ServiceNamePrefix: filter.ServiceNamePrefix,
+		PriceMin:          optionalInt(filter.PriceMin),
+		PriceMax:          optionalInt(filter.PriceMax),
+	}
+	if filter.Status != nil {
+		status := transport.SubscriptionStatus(*filter.Status)
+		params.Status = &status
+	}
+
+	items, err := r.list(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return newSubscriptions(ctx, items), nil
+}
+
+// read returns nil for a missing subscription.
+func (r *Resolver) read(ctx context.Context, id transport.UUID) (*subscriptionResolver, error) {
+	res, err := r.REST.ReadSubscription(ctx, transport.ReadSubscriptionRequestObject{SubscriptionId: id})
+	if err != nil {
+		return nil, err
+	}
+
+	switch res := res.(type) {
+	case transport.ReadSubscription200JSONResponse:
+		return newSubscriptions(ctx, []transport.Subscription{transport.Subscription(res)})[0], nil
+	case transport.ReadSubscription404ApplicationProblemPlusJSONResponse:
+		return nil, nil
+	case transport.ReadSubscriptiondefaultApplicationProblemPlusJSONResponse:
+		return nil, problemError(res.Body)
+	}
+	return nil, unexpected(res)
+}
+
+func (r *queryResolver) Subscription(ctx context.Context, args struct{ ID graphql.ID }) (*subscriptionResolver, error) {
+	id, err := r.parseID(ctx, "id", args.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.read(ctx, id)
+}
+
+type statsFilter struct {
+	StartDate    *string
+	EndDate      *string
+	UserIds      *[]graphql.ID
+	ServiceNames *[]string
+	Categories   *[]string
+	Tags         *[]string
+	GroupBy      *string
+}
+
+func (r *queryResolver) Stats(ctx context.Context, args struct{ Filter *statsFilter }) (*statsResolver, error) {
+	filter := args.Filter
+	if filter == nil {
+		filter = &statsFilter{}
+	}
+
+	userIds, err := r.parseIDs(ctx, "userIds", filter.UserIds)
+	if err != nil {
+		return nil, err
+	}
+
+	params := transport.StatsSubscriptionsParams{
+		StartDate:   filter.StartDate,
+		EndDate:     filter.EndDate,
+		UserId:      userIds,
+		ServiceName: filter.ServiceNames,
+		Category:    filter.Categories,
+		Tag:         filter.Tags,
+	}
+	if filter.GroupBy != nil {
+		groupBy := transport.StatsGroupBy(*filter.GroupBy)
+		params.GroupBy = &groupBy
+	}
+
+	res, err := r.REST.StatsSubscriptions(ctx, transport.StatsSubscriptionsRequestObject{Params: params})
+	if err != nil {
+		return nil, err
+	}
+
+	switch res := res.(type) {
+	case transport.StatsSubscriptions200JSONResponse:
+		return &statsResolver{stats: res}, nil
+	case transport.StatsSubscriptionsdefaultApplicationProblemPlusJSONResponse:
+		return nil, problemError(res.Body)
+	}
+	return nil, unexpected(res)
+}
+
+func (r *queryResolver) Users(ctx context.Context, args struct{ IDs []graphql.ID }) ([]*userResolver, error) {
+	ids, err := r.parseIDs(ctx, "ids", &args.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	loadersFrom(ctx).register(*ids...)
+
+	res := make([]*userResolver, 0, len(*ids))
+	for _, id := range *ids {
+		res = append(res, &userResolver{id: id})
+	}
+	return res, nil
+}
+
+type subscriptionInput struct {
+	ServiceId    *graphql.ID
+	ServiceName  string
+	Price        int32
+	UserId       graphql.ID
+	StartDate    string
+	EndDate      *string
+	TrialEndDate *string
+	Category     *string
+	Tags         *[]string
+}
+
+func (r *mutationResolver) CreateSubscription(ctx context.Context, args struct {
+	Input            subscriptionInput
+	RejectDuplicates *bool
+	IdempotencyKey   *string
+}) (*createdResolver, error) {
+	userId, err := r.parseID(ctx, "userId", args.Input.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &transport.Subscription{
+		ServiceName:  args.Input.ServiceName,
+		Price:        int(args.Input.Price),
+		UserId:       userId,
+		StartDate:    args.Input.StartDate,
+		EndDate:      args.Input.EndDate,
+		TrialEndDate: args.Input.TrialEndDate,
+		Category:     args.Input.Category,
+		Tags:         args.Input.Tags,
+	}
+	if args.Input.ServiceId != nil {
+		serviceId, err := r.parseID(ctx, "serviceId", *args.Input.ServiceId)
+		if err != nil {
+			return nil, err
+		}
+		body.ServiceId = &serviceId
+	}
+
+	res, err := r.REST.CreateSubscription(ctx, transport.CreateSubscriptionRequestObject{
+		Params: transport.CreateSubscriptionParams{RejectDuplicates: args.RejectDuplicates, IdempotencyKey: args.IdempotencyKey},
+		Body:   body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch res := res.(type) {
+	case transport.CreateSubscription200JSONResponse:
+		created := &createdResolver{}
+		if res.Duplicates != nil {
+			created.duplicates = *res.Duplicates
+		}
+		created.subscription, err = r.read(ctx, *res.SubscriptionId)
+		if err == nil && created.subscription == nil {
+			err = fmt.Errorf("created subscription %s not found", res.SubscriptionId)
+		}
+		return created, err
+	case transport.CreateSubscription409ApplicationProblemPlusJSONResponse:
+		return nil, problemError(res)
+	case transport.CreateSubscription422ApplicationProblemPlusJSONResponse:
+		return nil, problemError(res)
+	case transport.CreateSubscriptiondefaultApplicationProblemPlusJSONResponse:
+		return nil, problemError(res.Body)
+	}
+	return nil, unexpected(res)
+}
+
+type subscriptionPatchInput struct {
+	ServiceId    *graphql.ID
+	ServiceName  *string
+	Price        *int32
+	UserId       *graphql.ID
+	StartDate    *string
+	EndDate      graphql.NullString
+	TrialEndDate graphql.NullString
+	Category     graphql.NullString
+	Tags         *[]string
+}
+
+// mergeValue maps an input field where null clears the value to a field of a merge patch.
+func mergeValue(value graphql.NullString) nullable.Nullable[string] {
+	switch {
+	case !value.Set:
+		return nil
+	case value.Value == nil:
+		return nullable.NewNullNullable[string]()
+	}
+	return nullable.NewNullableWithValue(*value.Value)
+}
+
+func (r *mutationResolver) UpdateSubscription(ctx context.Context, args struct {
+	ID    graphql.ID
+	Patch subscriptionPatchInput
+}) (*subscriptionResolver, error) {
+	id, err := r.parseID(ctx, "id", args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &transport.SubscriptionMergePatch{
+		EndDate:      mergeValue(args.Patch.EndDate),
+		TrialEndDate: mergeValue(args.Patch.TrialEndDate),
+		Category:     mergeValue(args.Patch.Category),
+	}
+	if args.Patch.ServiceName != nil {
+		body.ServiceName = nullable.NewNullableWithValue(*args.Patch.ServiceName)
+	}
+	if args.Patch.Price != nil {
+		body.Price = nullable.NewNullableWithValue(int(*args.Patch.Price))
+	}
+	if args.Patch.StartDate != nil {
+		body.StartDate = nullable.NewNullableWithValue(*args.Patch.StartDate)
+	}
+	if args.Patch.Tags != nil {
+		body.Tags = nullable.NewNullableWithValue(*args.Patch.Tags)
+	}
+	if args.Patch.ServiceId != nil {
+		serviceId, err := r.parseID(ctx, "serviceId", *args.Patch.ServiceId)
+		if err != nil {
+			return nil, err
+		}
+		body.ServiceId = &serviceId
+	}
+	if args.Patch.UserId != nil {
+		userId, err := r.parseID(ctx, "userId", *args.Patch.UserId)
+		if err != nil {
+			return nil, err
+		}
+		body.UserId = nullable.NewNullableWithValue(userId)
+	}
+
+	res, err := r.REST.UpdateSubscription(ctx, transport.UpdateSubscriptionRequestObject{SubscriptionId: id, ApplicationMergePatchPlusJSONBody: body})
+	if err != nil {
+		return nil, err
+	}
+
+	switch res := res.(type) {
+	case transport.UpdateSubscription204Response:
+		return r.read(ctx, id)
+	case transport.UpdateSubscription404ApplicationProblemPlusJSONResponse:
+		return nil, nil
+	case transport.UpdateSubscription422ApplicationProblemPlusJSONResponse:
+		return nil, problemError(res)
+	case transport.UpdateSubscriptiondefaultApplicationProblemPlusJSONResponse:
+		return nil, problemError(res.Body)
+	}
+	return nil, unexpected(res)
+}
+
+func (r *mutationResolver) DeleteSubscription(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	id, err := r.parseID(ctx, "id", args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := r.REST.DeleteSubscription(ctx, transport.DeleteSubscriptionRequestObject{SubscriptionId: id})
+	if err != nil {
+		return false, err
+	}
+
+	switch res := res.(type) {
+	case transport.DeleteSubscription204Response:
+		return true, nil
+	case transport.DeleteSubscription404ApplicationProblemPlusJSONResponse:
+		return false, nil
+	case transport.DeleteSubscriptiondefaultApplicationProblemPlusJSONResponse:
+		return false, problemError(res.Body)
+	}
+	return false, unexpected(res)
+}
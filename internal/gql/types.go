@@ -0,0 +1,219 @@
+package gql
+
+import (
+	"context"
+	"ew/internal/transport"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+type subscriptionResolver struct {
+	item transport.Subscription
+}
+
+func newSubscriptions(ctx context.Context, items []transport.Subscription) []*subscriptionResolver {
+	res := make([]*subscriptionResolver, 0, len(items))
+	userIds := make([]transport.UUID, 0, len(items))
+	for _, item := range items {
+		res = append(res, &subscriptionResolver{item: item})
+		userIds = append(userIds, item.UserId)
+	}
+	// the users of a list are loaded together if their fields are selected
+	loadersFrom(ctx).register(userIds...)
+	return res
+}
+
+func (r *subscriptionResolver) ID() graphql.ID {
+	return graphql.ID(r.item.SubscriptionId.String())
+}
+
+func (r *subscriptionResolver) ServiceID() *graphql.ID {
+	if r.item.ServiceId == nil {
+		return nil
+	}
+	id := graphql.ID(r.item.ServiceId.String())
+	return &id
+}
+
+func (r *subscriptionResolver) ServiceName() string {
+	return r.item.ServiceName
+}
+
+func (r *subscriptionResolver) Price() int32 {
+	return int32(r.item.Price)
+}
+
+func (r *subscriptionResolver) UserID() graphql.ID {
+	return graphql.ID(r.item.UserId.String())
+}
+
+func (r *subscriptionResolver) StartDate() string {
+	return r.item.StartDate
+}
+
+func (r *subscriptionResolver) EndDate() *string {
+	return r.item.EndDate
+}
+
+func (r *subscriptionResolver) TrialEndDate() *string {
+	return r.item.TrialEndDate
+}
+
+func (r *subscriptionResolver) Category() *string {
+	return r.item.Category
+}
+
+func (r *subscriptionResolver) Tags() []string {
+	if r.item.Tags == nil {
+		return []string{}
+	}
+	return *r.item.Tags
+}
+
+func (r *subscriptionResolver) Pauses() []*pauseResolver {
+	res := []*pauseResolver{}
+	if r.item.Pauses != nil {
+		for _, pause := range *r.item.Pauses {
+			res = append(res, &pauseResolver{pause: pause})
+		}
+	}
+	return res
+}
+
+func (r *subscriptionResolver) User() *userResolver {
+	return &userResolver{id: r.item.UserId}
+}
+
+type pauseResolver struct {
+	pause transport.Pause
+}
+
+func (r *pauseResolver) StartDate() string {
+	return r.pause.StartDate
+}
+
+func (r *pauseResolver) ResumeDate() *string {
+	return r.pause.ResumeDate
+}
+
+type userResolver struct {
+	id transport.UUID
+}
+
+func (r *userResolver) ID() graphql.ID {
+	return graphql.ID(r.id.String())
+}
+
+func (r *userResolver) Summary(ctx context.Context) (*summaryResolver, error) {
+	summary, err := loadersFrom(ctx).summaries.Load(ctx, r.id)
+	if err != nil {
+		return nil, err
+	}
+	return &summaryResolver{summary: summary}, nil
+}
+
+func (r *userResolver) Subscriptions(ctx context.Context) ([]*subscriptionResolver, error) {
+	items, err := loadersFrom(ctx).subscriptions.Load(ctx, r.id)
+	if err != nil {
+		return nil, err
+	}
+	return newSubscriptions(ctx, items), nil
+}
+
+type summaryResolver struct {
+	summary transport.UserSummary
+}
+
+func (r *summaryResolver) ActiveCount() int32 {
+	return int32(r.summary.ActiveCount)
+}
+
+func (r *summaryResolver) MonthlyRunRate() int32 {
+	return int32(r.summary.MonthlyRunRate)
+}
+
+func (r *summaryResolver) LifetimeSpend() int32 {
+	return int32(r.summary.LifetimeSpend)
+}
+
+func (r *summaryResolver) Last12MonthsSpend() int32 {
+	return int32(r.summary.Last12MonthsSpend)
+}
+
+func (r *summaryResolver) MostExpensiveService() *servicePriceResolver {
+	if r.summary.MostExpensiveService == nil {
+		return nil
+	}
+	return &servicePriceResolver{price: *r.summary.MostExpensiveService}
+}
+
+func (r *summaryResolver) NextEndDate() *string {
+	return r.summary.NextEndDate
+}
+
+type servicePriceResolver struct {
+	price transport.ServicePrice
+}
+
+func (r *servicePriceResolver) ServiceName() string {
+	return r.price.ServiceName
+}
+
+func (r *servicePriceResolver) Price() int32 {
+	return int32(r.price.Price)
+}
+
+type statsResolver struct {
+	stats transport.StatsSubscriptions200JSONResponse
+}
+
+func (r *statsResolver) TotalPrice() int32 {
+	if r.stats.TotalPrice == nil {
+		return 0
+	}
+	return int32(*r.stats.TotalPrice)
+}
+
+func (r *statsResolver) Groups() *[]*statsGroupResolver {
+	if r.stats.Groups == nil {
+		return nil
+	}
+	res := make([]*statsGroupResolver, 0, len(*r.stats.Groups))
+	for _, group := range *r.stats.Groups {
+		res = append(res, &statsGroupResolver{group: group})
+	}
+	return &res
+}
+
+type statsGroupResolver struct {
+	group transport.StatsGroup
+}
+
+func (r *statsGroupResolver) Key() string {
+	return r.group.Key
+}
+
+func (r *statsGroupResolver) Name() string {
+	return r.group.Name
+}
+
+func (r *statsGroupResolver) TotalPrice() int32 {
+	return int32(r.group.TotalPrice)
+}
+
+type createdResolver struct {
+	subscription *subscriptionResolver
+	duplicates   []transport.UUID
+}
+
+func (r *createdResolver) Subscription() *subscriptionResolver {
+	return r.subscription
+}
+
+func (r *createdResolver) Duplicates() []graphql.ID {
+	res := make([]graphql.ID, 0, len(r.duplicates))
+	for _, id := range r.duplicates {
+		res = append(res, graphql.ID(id.String()))
+	}
+	return res
+}
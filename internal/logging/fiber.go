@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	HeaderRequestID = "X-Request-ID"
+
+	// OperationLocal is the fiber local the strict handler middleware stores the operation id in.
+	OperationLocal = "operation"
+)
+
+// validRequestID keeps a client supplied X-Request-ID from breaking the log lines it ends up in.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID returns the request id supplied by the client, or a generated one if it is missing or unsafe to log.
+func RequestID(supplied string) string {
+	if !validRequestID.MatchString(supplied) {
+		return uuid.NewString()
+	}
+	return supplied
+}
+
+// Middleware takes the X-Request-ID of the request or generates one, echoes it in the response,
+// puts a logger with the request_id field into the user context and writes an access log line when the request is done.
+func Middleware(c *fiber.Ctx) error {
+	start := time.Now()
+
+	requestID := RequestID(c.Get(HeaderRequestID))
+	c.Set(HeaderRequestID, requestID)
+
+	entry := FromContext(c.UserContext()).WithField("request_id", requestID)
+	c.SetUserContext(WithLogger(c.UserContext(), entry))
+
+	err := c.Next()
+	if err != nil {
+		// let the error handler write the response now, so its status is logged
+		if handleErr := c.App().ErrorHandler(c, err); handleErr != nil {
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+		}
+	}
+
+	fields := logrus.Fields{
+		"method":  c.Method(),
+		"path":    c.Path(),
+		"status":  c.Response().StatusCode(),
+		"latency": float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if operation, ok := c.Locals(OperationLocal).(string); ok {
+		fields["operation"] = operation
+	}
+	if userId := c.Params("user_id", c.Query("user_id")); userId != "" {
+		fields["user_id"] = userId
+	}
+
+	entry = entry.WithFields(fields)
+	if err != nil {
+		entry = entry.WithError(err)
+	}
+	entry.Info("request handled")
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestMiddleware(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.InfoLevel)
+
+	webApp := fiber.New()
+	webApp.Use(func(c *fiber.Ctx) error {
+		c.SetUserContext(WithLogger(c.UserContext(), logrus.NewEntry(logger)))
+		return c.Next()
+	})
+	webApp.Use(Middleware)
+	webApp.Get("/users/:user_id/summary", func(c *fiber.Ctx) error {
+		c.Locals(OperationLocal, "userSummary")
+		FromContext(c.UserContext()).Info("handled")
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	userId := uuid.NewString()
+	req := httptest.NewRequest("GET", "/users/"+userId+"/summary", nil)
+	req.Header.Set(HeaderRequestID, "abc-123")
+
+	resp, _ := webApp.Test(req)
+	if resp.Header.Get(HeaderRequestID) != "abc-123" {
+		t.Errorf("request id is not propagated: %q", resp.Header.Get(HeaderRequestID))
+	}
+
+	entries := hook.AllEntries()
+	if len(entries) != 2 {
+		t.Fatalf("not equal %v", entries)
+	}
+	if entries[0].Data["request_id"] != "abc-123" {
+		t.Errorf("handler log has no request id: %v", entries[0].Data)
+	}
+
+	access := entries[1].Data
+	for key, value := range map[string]any{"request_id": "abc-123", "operation": "userSummary", "user_id": userId, "status": 204} {
+		if access[key] != value {
+			t.Errorf("%s: not equal %v", key, access[key])
+		}
+	}
+	if _, ok := access["latency"]; !ok {
+		t.Errorf("no latency in %v", access)
+	}
+
+	// an id that could break the log line is replaced
+	req = httptest.NewRequest("GET", "/users/"+userId+"/summary", nil)
+	req.Header.Set(HeaderRequestID, "bad\tid")
+
+	resp, _ = webApp.Test(req)
+	if _, err := uuid.Parse(resp.Header.Get(HeaderRequestID)); err != nil {
+		t.Errorf("request id is not generated: %q", resp.Header.Get(HeaderRequestID))
+	}
+}
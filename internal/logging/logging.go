@@ -0,0 +1,58 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type loggerKey struct{}
+
+// WithLogger returns a context carrying the request-scoped logger.
+func WithLogger(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, loggerKey{}, entry)
+}
+
+// FromContext returns the logger of the request, or the standard logger outside of one.
+func FromContext(ctx context.Context) *logrus.Entry {
+	if entry, ok := ctx.Value(loggerKey{}).(*logrus.Entry); ok {
+		return entry
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
+
+// QueryFields are the fields of a query log line. Argument values that could identify a user,
+// such as ids, names and tags, are replaced with their type, numbers and dates are kept for debugging.
+func QueryFields(query string, args []any) logrus.Fields {
+	redacted := make([]any, 0, len(args))
+	for _, arg := range args {
+		redacted = append(redacted, redact(arg))
+	}
+	return logrus.Fields{"query": query, "args": redacted}
+}
+
+func redact(arg any) any {
+	switch value := arg.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, time.Time, *time.Time:
+		return value
+	default:
+		return fmt.Sprintf("[redacted %T]", value)
+	}
+}
+
+// Init switches the standard logger to JSON and sets its level from LOGGING_LEVEL.
+func Init() {
+	logrus.SetFormatter(&logrus.JSONFormatter{TimestampFormat: time.RFC3339Nano})
+
+	loggingLevel, err := strconv.Atoi(os.Getenv("LOGGING_LEVEL"))
+	if err != nil {
+		logrus.WithError(err).Error("error parsing LOGGING_LEVEL, set to default = 2 (ErrorLevel)")
+		logrus.SetLevel(logrus.ErrorLevel)
+	} else {
+		logrus.SetLevel(logrus.Level(loggingLevel))
+	}
+}
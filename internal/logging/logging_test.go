@@ -0,0 +1,23 @@
+package logging
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestQueryFields(t *testing.T) {
+	month := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	fields := QueryFields("SELECT 1", []any{uuid.New(), "Yandex Plus", 125, month, nil})
+
+	expected := []any{"[redacted uuid.UUID]", "[redacted string]", 125, month, nil}
+	if !reflect.DeepEqual(fields["args"], expected) {
+		t.Errorf("not equal %v", fields["args"])
+	}
+	if fields["query"] != "SELECT 1" {
+		t.Errorf("not equal %v", fields["query"])
+	}
+}
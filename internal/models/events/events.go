@@ -0,0 +1,127 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"ew/internal/models/subscriptions"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// DefaultLogSize is the number of the latest events kept for resuming streams.
+const DefaultLogSize = 1000
+
+// subscriberBuffer is the number of events a subscriber may lag behind before it is dropped.
+const subscriberBuffer = 64
+
+// Expired is returned for an event that is no longer in the log, the events after it cannot be replayed.
+var Expired = errors.New("events after the id are no longer in the log")
+
+type Type string
+
+const (
+	Created Type = "created"
+	Updated Type = "updated"
+	Deleted Type = "deleted"
+)
+
+// Event is a change of a subscription. IDs grow in the order the events are committed.
+type Event struct {
+	ID             int64
+	Type           Type
+	SubscriptionID uuid.UUID
+	UserID         uuid.UUID
+	// Subscription is the subscription as the change wrote it, nil for Deleted
+	Subscription *subscriptions.Subscription
+}
+
+// New makes the event of a write of the subscription. The subscription is copied,
+// the event keeps it as it was when written.
+func New(typ Type, item *subscriptions.Subscription) Event {
+	event := Event{Type: typ, SubscriptionID: item.ID, UserID: item.UserId}
+	if typ != Deleted {
+		event.Subscription = item.Clone()
+	}
+	return event
+}
+
+// Repo is the log of the events the subscription repositories record in the transactions of their writes.
+type Repo interface {
+	// Since returns the logged events after the id, oldest first, and Expired if some of them were dropped from the log.
+	Since(ctx context.Context, id int64) ([]Event, error)
+	// Subscribe delivers the events published from now on until cancel is called. The channel is closed
+	// when the subscriber falls behind or the repository is closed, the log then covers the missed events.
+	Subscribe() (events <-chan Event, cancel func())
+}
+
+// Hub fans the events out to the subscribers of one instance.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+	closed      bool
+}
+
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[chan Event]struct{})}
+}
+
+func (h *Hub) Subscribe() (<-chan Event, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan Event, subscriberBuffer)
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	h.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.drop(ch)
+	}
+}
+
+// Broadcast never blocks, a subscriber with a full buffer is dropped instead.
+func (h *Hub) Broadcast(event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+			h.drop(ch)
+		}
+	}
+}
+
+// Reset drops every subscriber, e.g. after events were missed, so that they resume from the log.
+func (h *Hub) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers {
+		h.drop(ch)
+	}
+}
+
+// Close drops every subscriber and closes the channels of the later ones right away, which ends the open streams on shutdown.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for ch := range h.subscribers {
+		h.drop(ch)
+	}
+}
+
+func (h *Hub) drop(ch chan Event) {
+	if _, ok := h.subscribers[ch]; ok {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
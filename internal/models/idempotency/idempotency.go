@@ -0,0 +1,44 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	Mismatch   = errors.New("idempotency key was used with a different request")
+	InProgress = errors.New("request with the idempotency key is still in progress")
+)
+
+// Record is a key reserved for a request, Response stays nil until the request completes.
+type Record struct {
+	Key         string
+	RequestHash string
+	Response    []byte
+	ExpiresAt   time.Time
+}
+
+type Repo interface {
+	// Reserve claims the key for the request until the lease passes, a request running longer lets a retry through.
+	// It returns the completed record of an earlier request with the same hash, Mismatch if the hash differs,
+	// InProgress if the earlier request has not completed yet, and nil if the key was free.
+	Reserve(ctx context.Context, key, requestHash string, lease time.Duration) (*Record, error)
+	// Complete stores the response to replay for the reserved key and keeps it until ttl passes.
+	Complete(ctx context.Context, key string, response []byte, ttl time.Duration) error
+	// Release frees a reserved key whose request failed, so that it can be retried.
+	Release(ctx context.Context, key string) error
+	// Purge deletes the expired keys.
+	Purge(ctx context.Context) (int64, error)
+}
+
+// Check resolves a reservation that found the key taken by another request.
+func Check(record *Record, requestHash string) (*Record, error) {
+	switch {
+	case record.RequestHash != requestHash:
+		return nil, Mismatch
+	case record.Response == nil:
+		return nil, InProgress
+	}
+	return record, nil
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ServiceRepo interface {
+	GetList(context.Context, ServiceListParams) ([]*Service, error)
+	GetByID(context.Context, uuid.UUID) (*Service, error)
+	FindByName(context.Context, string) (*Service, error)
+	Add(context.Context, *Service) (uuid.UUID, error)
+	Update(context.Context, *ServicePatch) (int64, error)
+	Delete(context.Context, uuid.UUID) (int64, error)
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"errors"
+	"ew/internal/database"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	NotFound  = errors.New("not found")
+	NameTaken = errors.New("name already taken")
+)
+
+type Service struct {
+	ID           uuid.UUID          `db:"id" goqu:"skipinsert"`
+	Name         string             `db:"name"`
+	Aliases      database.TextArray `db:"aliases"`
+	Category     *string            `db:"category" goqu:"omitnil"`
+	DefaultPrice *uint              `db:"default_price" goqu:"omitnil"`
+	LogoUrl      *string            `db:"logo_url" goqu:"omitnil"`
+}
+
+type ServicePatch struct {
+	ID           uuid.UUID           `db:"id" goqu:"skipupdate"`
+	Name         *string             `db:"name" goqu:"omitnil"`
+	Aliases      *database.TextArray `db:"aliases" goqu:"omitnil"`
+	Category     *string             `db:"category" goqu:"omitnil"`
+	DefaultPrice *uint               `db:"default_price" goqu:"omitnil"`
+	LogoUrl      *string             `db:"logo_url" goqu:"omitnil"`
+}
+
+type ServiceListParams struct {
+	Category *string
+	Offset   *int
+	Limit    *int
+}
+
+// Names returns the canonical name and all aliases of the service.
+func (s *Service) Names() []string {
+	return append([]string{s.Name}, s.Aliases...)
+}
+
+// Key is the form the names and the aliases are compared in, without case and surrounding spaces.
+func Key(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Keys returns the distinct keys of all names of the service.
+func (s *Service) Keys() []string {
+	keys := make([]string, 0, len(s.Aliases)+1)
+	for _, name := range s.Names() {
+		if key := Key(name); !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
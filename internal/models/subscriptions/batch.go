@@ -0,0 +1,89 @@
+package subscriptions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// RolledBack marks the operations of an all-or-nothing batch that were undone or never run
+// because another operation of the batch failed.
+var RolledBack = errors.New("operation rolled back with the batch")
+
+// BatchOperation is a single write of a batch, exactly one of the fields is set.
+type BatchOperation struct {
+	Create *Subscription
+	Update *SubscriptionPatch
+	Delete *uuid.UUID
+}
+
+// BatchResult is the outcome of the operation with the same index, ID is the created, updated or deleted subscription.
+type BatchResult struct {
+	ID  uuid.UUID
+	Err error
+}
+
+// RunBatch implements SubscriptionRepo.Batch on top of WithTx. The writes of a repository inside a transaction
+// are expected to be atomic on their own, so that a failed one leaves the rest of a best-effort batch intact.
+func RunBatch(ctx context.Context, repo SubscriptionRepo, ops []BatchOperation, atomic bool) ([]BatchResult, error) {
+	var results []BatchResult
+
+	err := repo.WithTx(ctx, func(tx SubscriptionRepo) error {
+		results = make([]BatchResult, len(ops))
+		for i, op := range ops {
+			results[i] = apply(ctx, tx, op)
+			if atomic && results[i].Err != nil {
+				for j := range results {
+					if j != i {
+						results[j] = BatchResult{Err: RolledBack}
+					}
+				}
+				return RolledBack
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, RolledBack) {
+		return results, nil
+	}
+	return results, err
+}
+
+func apply(ctx context.Context, repo SubscriptionRepo, op BatchOperation) BatchResult {
+	var (
+		res      BatchResult
+		affected int64
+	)
+	switch {
+	case op.Create != nil:
+		res.ID, res.Err = repo.Add(ctx, op.Create)
+		return res
+	case op.Update != nil:
+		res.ID = op.Update.ID
+		affected, res.Err = update(ctx, repo, op.Update)
+	case op.Delete != nil:
+		res.ID = *op.Delete
+		affected, res.Err = repo.Delete(ctx, *op.Delete)
+	default:
+		res.Err = errors.New("empty batch operation")
+		return res
+	}
+
+	if res.Err == nil && affected == 0 {
+		res.Err = NotFound
+	}
+	return res
+}
+
+// update writes the patch if it passes CheckDates against the subscription read in the transaction of the batch.
+func update(ctx context.Context, repo SubscriptionRepo, patch *SubscriptionPatch) (int64, error) {
+	current, err := repo.GetByID(ctx, patch.ID)
+	if err != nil {
+		return 0, err
+	}
+	if err = patch.CheckDates(current); err != nil {
+		return 0, err
+	}
+	return repo.Update(ctx, patch)
+}
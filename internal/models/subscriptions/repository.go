@@ -2,6 +2,7 @@ package subscriptions
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -9,8 +10,50 @@ import (
 type SubscriptionRepo interface {
 	GetList(context.Context, SubscriptionListParams) ([]*Subscription, error)
 	GetByID(context.Context, uuid.UUID) (*Subscription, error)
+	// Add and Update link a subscription given a service name without an id to the catalog entry of the name,
+	// adding the entry in the same transaction if there is none.
 	Add(context.Context, *Subscription) (uuid.UUID, error)
 	Update(context.Context, *SubscriptionPatch) (int64, error)
 	Delete(context.Context, uuid.UUID) (int64, error)
+	Pause(ctx context.Context, id uuid.UUID, start time.Time) error
+	Resume(ctx context.Context, id uuid.UUID, resume time.Time) error
 	GetStats(context.Context, SubscriptionListParams) (int, error)
+	GetGroupedStats(context.Context, SubscriptionListParams, GroupBy) ([]*StatsGroup, error)
+	GetForecast(context.Context, ForecastParams) ([]*MonthTotal, error)
+	GetUserSummary(context.Context, uuid.UUID) (*UserSummary, error)
+	// GetUserSummaries reads the summaries of the users at once, every user is in the result.
+	GetUserSummaries(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID]*UserSummary, error)
+	GetTop(context.Context, TopParams) ([]*TopItem, error)
+	GetDuplicates(ctx context.Context, userIds []uuid.UUID) ([]*Duplicate, error)
+	FindOverlapping(context.Context, *Subscription) ([]*Subscription, error)
+	GetChurn(context.Context, ChurnParams) ([]*ServiceChurn, error)
+	GetCohorts(context.Context, CohortParams) ([]*Cohort, error)
+	// Batch runs the operations in order in one transaction. With atomic set the first failed operation
+	// rolls back the whole batch, otherwise the failed operations are skipped and the rest are committed.
+	// An update is checked with SubscriptionPatch.CheckDates against the subscription as read in the transaction.
+	// The error is only returned if the batch could not run at all.
+	Batch(ctx context.Context, ops []BatchOperation, atomic bool) ([]BatchResult, error)
+	// WithTx runs fn as a unit of work: the repository passed to it makes every read and write in one transaction,
+	// committed if fn returns nil and rolled back otherwise.
+	WithTx(ctx context.Context, fn func(SubscriptionRepo) error) error
+}
+
+// CacheStats counts the reads a caching SubscriptionRepo answered itself and the ones it passed through.
+type CacheStats struct {
+	Hits    int
+	Misses  int
+	Entries int
+}
+
+type noCacheKey struct{}
+
+// WithoutCache marks the reads made with the returned context to bypass any cache in front of the repository.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// CacheBypassed reports whether the context was made by WithoutCache.
+func CacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(noCacheKey{}).(bool)
+	return bypass
 }
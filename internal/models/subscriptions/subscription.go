@@ -2,36 +2,451 @@ package subscriptions
 
 import (
 	"errors"
+	"ew/internal/database"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-var NotFound = errors.New("not found")
+var (
+	NotFound          = errors.New("not found")
+	AlreadyPaused     = errors.New("subscription is already paused")
+	NotPaused         = errors.New("subscription is not paused")
+	InvalidPauseDates = errors.New("pause dates overlap the subscription history")
+	InvalidPeriod     = errors.New("subscription ends before it starts")
+	InvalidTrial      = errors.New("trial ends before the subscription starts")
+)
 
 type Subscription struct {
-	ID          uuid.UUID  `db:"id" goqu:"skipinsert"`
-	ServiceName string     `db:"service_name"`
-	Price       uint       `db:"price"`
-	UserId      uuid.UUID  `db:"user_id"`
-	StartDate   time.Time  `db:"start_date"`
-	EndDate     *time.Time `db:"end_date" goqu:"omitnil"`
+	ID           uuid.UUID          `db:"id" goqu:"skipinsert"`
+	ServiceId    *uuid.UUID         `db:"service_id" goqu:"omitnil"`
+	ServiceName  string             `db:"service_name"`
+	Price        uint               `db:"price"`
+	UserId       uuid.UUID          `db:"user_id"`
+	StartDate    time.Time          `db:"start_date"`
+	EndDate      *time.Time         `db:"end_date" goqu:"omitnil"`
+	TrialEndDate *time.Time         `db:"trial_end_date" goqu:"omitnil"`
+	Category     *string            `db:"category" goqu:"omitnil"`
+	Tags         database.TextArray `db:"tags"`
+	Pauses       []Pause            `db:"-"`
+}
+
+// Pause stops billing from StartDate up to, but not including, ResumeDate.
+// An open pause has no ResumeDate.
+type Pause struct {
+	ID             uuid.UUID  `db:"id" goqu:"skipinsert"`
+	SubscriptionId uuid.UUID  `db:"subscription_id"`
+	StartDate      time.Time  `db:"start_date"`
+	ResumeDate     *time.Time `db:"resume_date" goqu:"omitnil"`
 }
 
+// Covers reports whether billing is paused in the month of the given date.
+func (p *Pause) Covers(month time.Time) bool {
+	if MonthsBetween(p.StartDate, month) < 0 {
+		return false
+	}
+	return p.ResumeDate == nil || MonthsBetween(month, *p.ResumeDate) > 0
+}
+
+// Field names a nullable column of a subscription that a patch can set back to NULL.
+type Field string
+
+const (
+	FieldEndDate      Field = "end_date"
+	FieldTrialEndDate Field = "trial_end_date"
+	FieldCategory     Field = "category"
+)
+
 type SubscriptionPatch struct {
-	ID          uuid.UUID  `db:"id" goqu:"skipupdate"`
-	ServiceName *string    `db:"service_name" goqu:"omitnil"`
-	Price       *uint      `db:"price" goqu:"omitnil"`
-	UserId      *uuid.UUID `db:"user_id" goqu:"omitnil"`
-	StartDate   *time.Time `db:"start_date" goqu:"omitnil"`
-	EndDate     *time.Time `db:"end_date" goqu:"omitnil"`
+	ID           uuid.UUID           `db:"id" goqu:"skipupdate"`
+	ServiceId    *uuid.UUID          `db:"service_id" goqu:"omitnil"`
+	ServiceName  *string             `db:"service_name" goqu:"omitnil"`
+	Price        *uint               `db:"price" goqu:"omitnil"`
+	UserId       *uuid.UUID          `db:"user_id" goqu:"omitnil"`
+	StartDate    *time.Time          `db:"start_date" goqu:"omitnil"`
+	EndDate      *time.Time          `db:"end_date" goqu:"omitnil"`
+	TrialEndDate *time.Time          `db:"trial_end_date" goqu:"omitnil"`
+	Category     *string             `db:"category" goqu:"omitnil"`
+	Tags         *database.TextArray `db:"tags" goqu:"omitnil"`
+	// Clear lists the fields to set to NULL, their values in the patch are ignored
+	Clear []Field `db:"-"`
+}
+
+// Clears reports whether the patch sets the field to NULL.
+func (p *SubscriptionPatch) Clears(field Field) bool {
+	return slices.Contains(p.Clear, field)
+}
+
+// Period returns the start and the end date the subscription would have after the patch.
+func (p *SubscriptionPatch) Period(current *Subscription) (time.Time, *time.Time) {
+	start, end := current.StartDate, current.EndDate
+	if p.StartDate != nil {
+		start = *p.StartDate
+	}
+	if p.EndDate != nil {
+		end = p.EndDate
+	}
+	if p.Clears(FieldEndDate) {
+		end = nil
+	}
+	return start, end
 }
 
+// CheckDates returns InvalidPeriod or InvalidTrial if the subscription would break the date rules after the patch.
+func (p *SubscriptionPatch) CheckDates(current *Subscription) error {
+	start, end := p.Period(current)
+	if err := CheckPeriod(start, end); err != nil {
+		return err
+	}
+
+	trialEnd := current.TrialEndDate
+	if p.TrialEndDate != nil {
+		trialEnd = p.TrialEndDate
+	}
+	if p.Clears(FieldTrialEndDate) {
+		trialEnd = nil
+	}
+
+	if trialEnd != nil && trialEnd.Before(start) {
+		return InvalidTrial
+	}
+	return nil
+}
+
+// CheckPeriod returns InvalidPeriod if the subscription would end before it starts,
+// the storage rejects such subscriptions as well.
+func CheckPeriod(start time.Time, end *time.Time) error {
+	if end != nil && end.Before(start) {
+		return InvalidPeriod
+	}
+	return nil
+}
+
+type Status string
+
+const (
+	StatusActive Status = "active"
+	StatusEnded  Status = "ended"
+	StatusFuture Status = "future"
+	StatusTrial  Status = "trial"
+)
+
 type SubscriptionListParams struct {
-	ServiceName *string
-	StartDate   *time.Time
-	EndDate     *time.Time
-	UserId      *uuid.UUID
-	Offset      *int
-	Limit       *int
+	ServiceNames      []string
+	ServiceNamePrefix *string
+	StartDate         *time.Time
+	EndDate           *time.Time
+	UserIds           []uuid.UUID
+	Categories        []string
+	Tags              []string
+	PriceMin          *uint
+	PriceMax          *uint
+	Status            *Status
+	Offset            *int
+	Limit             *int
+}
+
+type GroupBy string
+
+const (
+	GroupByService  GroupBy = "service"
+	GroupByCategory GroupBy = "category"
+)
+
+type StatsGroup struct {
+	Key        string
+	Name       string
+	TotalPrice int
+}
+
+type Dimension string
+
+const (
+	DimensionServiceName Dimension = "service_name"
+	DimensionUserId      Dimension = "user_id"
+	DimensionCategory    Dimension = "category"
+)
+
+type Metric string
+
+const (
+	MetricTotal   Metric = "total"
+	MetricRunRate Metric = "run_rate"
+	MetricCount   Metric = "count"
+)
+
+// TopParams ranks the groups of subscriptions along Dimension by Metric within the Filter period.
+// The run rate is the spend in the last month of the period.
+type TopParams struct {
+	Filter    SubscriptionListParams
+	Dimension Dimension
+	Metric    Metric
+	Limit     int
+}
+
+type TopItem struct {
+	Rank  int
+	Key   string
+	Name  string
+	Value int
+}
+
+// RunRateMonth returns the month the run rate is measured in, which is the end of the period or the current month.
+func (p *TopParams) RunRateMonth() time.Time {
+	if p.Filter.EndDate != nil {
+		return MonthStart(*p.Filter.EndDate)
+	}
+	return MonthStart(time.Now())
+}
+
+// ForecastParams selects the subscriptions and the months to project spend for.
+// Cancel and PriceChangePercent describe a what-if scenario applied on top of the current subscriptions.
+type ForecastParams struct {
+	Filter             SubscriptionListParams
+	StartDate          time.Time
+	EndDate            time.Time
+	Cancel             []uuid.UUID
+	PriceChangePercent int
+}
+
+type MonthTotal struct {
+	Month      time.Time
+	TotalPrice int
+}
+
+// UserSummary is the spending overview of a single user relative to the current month.
+type UserSummary struct {
+	ActiveCount     int
+	MonthlyRunRate  int
+	LifetimeSpend   int
+	LastYearSpend   int
+	TopServiceName  *string
+	TopServicePrice *uint
+	NextEndDate     *time.Time
+}
+
+// Duplicate is a pair of subscriptions of one user to the same service running in common months.
+// WastedPrice is the price of the cheaper subscription for every common month paid so far.
+type Duplicate struct {
+	UserId       uuid.UUID
+	ServiceName  string
+	FirstId      uuid.UUID
+	SecondId     uuid.UUID
+	OverlapStart time.Time
+	OverlapEnd   *time.Time
+	WastedPrice  int
+}
+
+type ChurnParams struct {
+	Filter    SubscriptionListParams
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// ChurnMonth counts the subscriptions of a service that started or ended in Month,
+// and the ones that were active at its start.
+type ChurnMonth struct {
+	Month        time.Time
+	New          int
+	Cancelled    int
+	Active       int
+	NewMRR       int
+	CancelledMRR int
+}
+
+type ServiceChurn struct {
+	Key             string
+	Name            string
+	AverageLifetime float64
+	Months          []*ChurnMonth
+}
+
+// CohortParams selects the subscriptions started from StartDate to EndDate, grouped by the start month.
+type CohortParams struct {
+	Filter    SubscriptionListParams
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// Cohort holds the subscriptions started in Month. Active[i] is the number of them not ended i months later,
+// up to the current month.
+type Cohort struct {
+	Month  time.Time
+	Size   int
+	Active []int
+}
+
+// ChurnRate is the share of the subscriptions active at the start of the month that ended in it.
+func (m *ChurnMonth) ChurnRate() float64 {
+	if m.Active == 0 {
+		return 0
+	}
+	return float64(m.Cancelled) / float64(m.Active)
+}
+
+// NetMRR is the change of the monthly recurring revenue brought by the new and the ended subscriptions.
+func (m *ChurnMonth) NetMRR() int {
+	return m.NewMRR - m.CancelledMRR
+}
+
+// Lifetime returns the number of months the subscription runs, up to the given month if it has no end date.
+func (s *Subscription) Lifetime(now time.Time) int {
+	end := now
+	if s.EndDate != nil {
+		end = *s.EndDate
+	}
+	return max(MonthsBetween(s.StartDate, end)+1, 0)
+}
+
+// ApplyPriceChange scales a monthly total by the scenario price change, rounding half up.
+func (p *ForecastParams) ApplyPriceChange(total int) int {
+	return (total*(100+p.PriceChangePercent) + 50) / 100
+}
+
+// MonthStart returns the first day of the month t falls into.
+func MonthStart(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+}
+
+// MonthsBetween returns the number of calendar months from the month of from to the month of to.
+func MonthsBetween(from, to time.Time) int {
+	fromYear, fromMonth, _ := from.Date()
+	toYear, toMonth, _ := to.Date()
+
+	return (toYear-fromYear)*12 + int(toMonth-fromMonth)
+}
+
+// FirstChargeMonth returns the month of the first payment, which is the month after the trial if there is one.
+func (s *Subscription) FirstChargeMonth() time.Time {
+	if s.TrialEndDate != nil && MonthsBetween(s.StartDate, *s.TrialEndDate) >= 0 {
+		return MonthStart(*s.TrialEndDate).AddDate(0, 1, 0)
+	}
+	return MonthStart(s.StartDate)
+}
+
+// ChargedIn reports whether the subscription is paid for in the month of the given date.
+func (s *Subscription) ChargedIn(month time.Time) bool {
+	if MonthsBetween(s.FirstChargeMonth(), month) < 0 {
+		return false
+	}
+	if s.EndDate != nil && MonthsBetween(month, *s.EndDate) < 0 {
+		return false
+	}
+	for i := range s.Pauses {
+		if s.Pauses[i].Covers(month) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone copies the subscription along with the values its fields point to, including the pause history.
+func (s *Subscription) Clone() *Subscription {
+	clone := *s
+	clone.ServiceId = clonePtr(s.ServiceId)
+	clone.EndDate = clonePtr(s.EndDate)
+	clone.TrialEndDate = clonePtr(s.TrialEndDate)
+	clone.Category = clonePtr(s.Category)
+	clone.Tags = slices.Clone(s.Tags)
+	clone.Pauses = slices.Clone(s.Pauses)
+	for i := range clone.Pauses {
+		clone.Pauses[i].ResumeDate = clonePtr(s.Pauses[i].ResumeDate)
+	}
+	return &clone
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// ChargeReason tells the renewal reminders why a subscription is charged in a month.
+type ChargeReason string
+
+const (
+	// ChargeTrialEnd is the first charge after a trial, which the trial end is reminded of as
+	ChargeTrialEnd ChargeReason = "trial_end"
+	ChargeStart    ChargeReason = "start"
+	ChargeRenewal  ChargeReason = "renewal"
+)
+
+// ChargeIn returns the reason the subscription is charged in the month of the given date, false if it is not.
+func (s *Subscription) ChargeIn(month time.Time) (ChargeReason, bool) {
+	if !s.ChargedIn(month) {
+		return "", false
+	}
+	if MonthsBetween(s.FirstChargeMonth(), month) > 0 {
+		return ChargeRenewal, true
+	}
+	if s.TrialEndDate != nil && MonthsBetween(s.StartDate, *s.TrialEndDate) >= 0 {
+		return ChargeTrialEnd, true
+	}
+	return ChargeStart, true
+}
+
+// ServiceKey identifies the service of the subscription, by the catalog entry or by the normalized name.
+func (s *Subscription) ServiceKey() string {
+	if s.ServiceId != nil {
+		return s.ServiceId.String()
+	}
+	return strings.ToLower(strings.TrimSpace(s.ServiceName))
+}
+
+// Overlaps reports whether both subscriptions belong to the same user and service and share at least one month.
+// The periods are half-open, so a subscription starting in the month another one ends in does not overlap it.
+func (s *Subscription) Overlaps(other *Subscription) bool {
+	if s.UserId != other.UserId || s.ServiceKey() != other.ServiceKey() {
+		return false
+	}
+	if s.EndDate != nil && MonthsBetween(other.StartDate, *s.EndDate) <= 0 {
+		return false
+	}
+	if other.EndDate != nil && MonthsBetween(s.StartDate, *other.EndDate) <= 0 {
+		return false
+	}
+	return true
+}
+
+// OpenPause returns the pause that has not been resumed yet, if any.
+func (s *Subscription) OpenPause() *Pause {
+	for i := range s.Pauses {
+		if s.Pauses[i].ResumeDate == nil {
+			return &s.Pauses[i]
+		}
+	}
+	return nil
+}
+
+// CheckPause validates a pause starting in the given month against the subscription and its pause history.
+func (s *Subscription) CheckPause(start time.Time) error {
+	if s.OpenPause() != nil {
+		return AlreadyPaused
+	}
+	if MonthsBetween(s.StartDate, start) < 0 || (s.EndDate != nil && MonthsBetween(start, *s.EndDate) < 0) {
+		return InvalidPauseDates
+	}
+	for _, pause := range s.Pauses {
+		if MonthsBetween(*pause.ResumeDate, start) < 0 {
+			return InvalidPauseDates
+		}
+	}
+	return nil
+}
+
+// CheckResume validates resuming the open pause in the given month.
+func (s *Subscription) CheckResume(resume time.Time) error {
+	pause := s.OpenPause()
+	if pause == nil {
+		return NotPaused
+	}
+	if MonthsBetween(pause.StartDate, resume) < 0 {
+		return InvalidPauseDates
+	}
+	return nil
 }
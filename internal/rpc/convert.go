@@ -0,0 +1,201 @@
+package rpc
+
+import (
+	"context"
+	"ew/internal/rpc/pb"
+	"ew/internal/transport"
+
+	"github.com/google/uuid"
+)
+
+// parseUUID reports an identifier the REST API would have rejected while binding the request.
+func (s *Server) parseUUID(ctx context.Context, field, value string) (transport.UUID, error) {
+	id, invalid := s.REST.ParseUUID(ctx, field, value)
+	if invalid != nil {
+		return id, problemError(*invalid)
+	}
+	return id, nil
+}
+
+// parseOptionalUUID leaves an empty value to the required rules of transport.Server.
+func (s *Server) parseOptionalUUID(ctx context.Context, field, value string) (transport.UUID, error) {
+	if value == "" {
+		return uuid.Nil, nil
+	}
+	return s.parseUUID(ctx, field, value)
+}
+
+func (s *Server) parseUUIDs(ctx context.Context, field string, values []string) (*[]transport.UUID, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	res := make([]transport.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := s.parseUUID(ctx, field, value)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, id)
+	}
+	return &res, nil
+}
+
+// optionalList maps an empty repeated field to an absent query parameter.
+func optionalList(values []string) *[]string {
+	if len(values) == 0 {
+		return nil
+	}
+	return &values
+}
+
+func optionalInt(value *int64) *int {
+	if value == nil {
+		return nil
+	}
+	res := int(*value)
+	return &res
+}
+
+func (s *Server) subscriptionFromProto(ctx context.Context, item *pb.Subscription) (*transport.Subscription, error) {
+	if item == nil {
+		item = &pb.Subscription{}
+	}
+
+	userId, err := s.parseOptionalUUID(ctx, "user_id", item.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &transport.Subscription{
+		ServiceName:  item.GetServiceName(),
+		Price:        int(item.GetPrice()),
+		UserId:       userId,
+		StartDate:    item.GetStartDate(),
+		EndDate:      item.EndDate,
+		TrialEndDate: item.TrialEndDate,
+		Category:     item.Category,
+		Tags:         optionalList(item.GetTags()),
+	}
+
+	if item.ServiceId != nil {
+		serviceId, err := s.parseUUID(ctx, "service_id", item.GetServiceId())
+		if err != nil {
+			return nil, err
+		}
+		res.ServiceId = &serviceId
+	}
+	return res, nil
+}
+
+func (s *Server) patchFromProto(ctx context.Context, patch *pb.SubscriptionPatch) (*transport.SubscriptionPatch, error) {
+	if patch == nil {
+		patch = &pb.SubscriptionPatch{}
+	}
+
+	res := &transport.SubscriptionPatch{
+		ServiceName:  patch.ServiceName,
+		Price:        optionalInt(patch.Price),
+		StartDate:    patch.StartDate,
+		EndDate:      patch.EndDate,
+		TrialEndDate: patch.TrialEndDate,
+		Category:     patch.Category,
+	}
+
+	if patch.ServiceId != nil {
+		serviceId, err := s.parseUUID(ctx, "service_id", patch.GetServiceId())
+		if err != nil {
+			return nil, err
+		}
+		res.ServiceId = &serviceId
+	}
+	if patch.UserId != nil {
+		userId, err := s.parseUUID(ctx, "user_id", patch.GetUserId())
+		if err != nil {
+			return nil, err
+		}
+		res.UserId = &userId
+	}
+	if patch.Tags != nil {
+		tags := patch.Tags.GetValues()
+		if tags == nil {
+			tags = []string{}
+		}
+		res.Tags = &tags
+	}
+	return res, nil
+}
+
+func subscriptionToProto(item transport.Subscription) *pb.Subscription {
+	res := &pb.Subscription{
+		ServiceName:  item.ServiceName,
+		Price:        int64(item.Price),
+		UserId:       item.UserId.String(),
+		StartDate:    item.StartDate,
+		EndDate:      item.EndDate,
+		TrialEndDate: item.TrialEndDate,
+		Category:     item.Category,
+	}
+	if item.SubscriptionId != nil {
+		res.SubscriptionId = item.SubscriptionId.String()
+	}
+	if item.ServiceId != nil {
+		serviceId := item.ServiceId.String()
+		res.ServiceId = &serviceId
+	}
+	if item.Tags != nil {
+		res.Tags = *item.Tags
+	}
+	if item.Pauses != nil {
+		for _, pause := range *item.Pauses {
+			res.Pauses = append(res.Pauses, &pb.Pause{StartDate: pause.StartDate, ResumeDate: pause.ResumeDate})
+		}
+	}
+	return res
+}
+
+func (s *Server) listParams(ctx context.Context, req *pb.ListSubscriptionsRequest) (transport.ListSubscriptionsParams, error) {
+	userIds, err := s.parseUUIDs(ctx, "user_id", req.GetUserId())
+	if err != nil {
+		return transport.ListSubscriptionsParams{}, err
+	}
+
+	params := transport.ListSubscriptionsParams{
+		Offset:            optionalInt(req.Offset),
+		Limit:             optionalInt(req.Limit),
+		StartDate:         req.StartDate,
+		EndDate:           req.EndDate,
+		UserId:            userIds,
+		ServiceName:       optionalList(req.GetServiceName()),
+		Category:          optionalList(req.GetCategory()),
+		Tag:               optionalList(req.GetTag()),
+		ServiceNamePrefix: req.ServiceNamePrefix,
+		PriceMin:          optionalInt(req.PriceMin),
+		PriceMax:          optionalInt(req.PriceMax),
+	}
+	if req.Status != nil {
+		status := transport.SubscriptionStatus(req.GetStatus())
+		params.Status = &status
+	}
+	return params, nil
+}
+
+func (s *Server) statsParams(ctx context.Context, req *pb.StatsSubscriptionsRequest) (transport.StatsSubscriptionsParams, error) {
+	userIds, err := s.parseUUIDs(ctx, "user_id", req.GetUserId())
+	if err != nil {
+		return transport.StatsSubscriptionsParams{}, err
+	}
+
+	params := transport.StatsSubscriptionsParams{
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		UserId:      userIds,
+		ServiceName: optionalList(req.GetServiceName()),
+		Category:    optionalList(req.GetCategory()),
+		Tag:         optionalList(req.GetTag()),
+	}
+	if req.GroupBy != nil {
+		groupBy := transport.StatsGroupBy(req.GetGroupBy())
+		params.GroupBy = &groupBy
+	}
+	return params, nil
+}
@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"ew/internal/logging"
+	"ew/internal/transport"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// callContext does for a call what logging.Middleware and transport.Locale do for a REST request:
+// it puts a logger with the request_id and the operation into the context and picks the language of the messages.
+func callContext(ctx context.Context, fullMethod string) (context.Context, *logrus.Entry, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	requestID := logging.RequestID(first(strings.ToLower(logging.HeaderRequestID)))
+	_, operation, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+
+	entry := logging.FromContext(ctx).WithFields(logrus.Fields{"request_id": requestID, "operation": operation})
+	ctx = logging.WithLogger(ctx, entry)
+	ctx = transport.WithLocale(ctx, first("accept-language"))
+	return ctx, entry, requestID
+}
+
+// logCall writes the access log line of a finished call and turns a panic of the handler into an Internal error.
+func logCall(entry *logrus.Entry, start time.Time, err *error) {
+	if r := recover(); r != nil {
+		entry = entry.WithField("panic", r)
+		*err = status.Error(codes.Internal, transport.InternalError.Error())
+	}
+
+	entry = entry.WithFields(logrus.Fields{
+		"code":    status.Code(*err).String(),
+		"latency": float64(time.Since(start).Microseconds()) / 1000,
+	})
+	if *err != nil {
+		entry = entry.WithError(*err)
+	}
+	entry.Info("call handled")
+}
+
+func UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+	ctx, entry, requestID := callContext(ctx, info.FullMethod)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(logging.HeaderRequestID, requestID))
+
+	defer logCall(entry, time.Now(), &err)
+	return handler(ctx, req)
+}
+
+// contextStream replaces the context of a stream with the one of callContext.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func StreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	ctx, entry, requestID := callContext(stream.Context(), info.FullMethod)
+	_ = stream.SetHeader(metadata.Pairs(logging.HeaderRequestID, requestID))
+
+	defer logCall(entry, time.Now(), &err)
+	return handler(srv, contextStream{ServerStream: stream, ctx: ctx})
+}
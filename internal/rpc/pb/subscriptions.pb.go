@@ -0,0 +1,1209 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v5.29.3
+// source: subscriptions.proto
+
+// Подписки пользователей, те же операции, что и в REST API (api/openapi.yaml).
+// Даты передаются в формате MM-YYYY, идентификаторы - в виде строк UUID.
+// Ошибки валидации возвращаются с кодом INVALID_ARGUMENT и google.rpc.BadRequest в деталях.
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Pause struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	ResumeDate    *string                `protobuf:"bytes,2,opt,name=resume_date,json=resumeDate,proto3,oneof" json:"resume_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pause) Reset() {
+	*x = Pause{}
+	mi := &file_subscriptions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pause) ProtoMessage() {}
+
+func (x *Pause) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pause.ProtoReflect.Descriptor instead.
+func (*Pause) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Pause) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Pause) GetResumeDate() string {
+	if x != nil && x.ResumeDate != nil {
+		return *x.ResumeDate
+	}
+	return ""
+}
+
+type Subscription struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Заполняется сервером
+	SubscriptionId string   `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	ServiceId      *string  `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3,oneof" json:"service_id,omitempty"`
+	ServiceName    string   `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Price          int64    `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	UserId         string   `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate      string   `protobuf:"bytes,6,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate        *string  `protobuf:"bytes,7,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
+	TrialEndDate   *string  `protobuf:"bytes,8,opt,name=trial_end_date,json=trialEndDate,proto3,oneof" json:"trial_end_date,omitempty"`
+	Category       *string  `protobuf:"bytes,9,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	Tags           []string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Заполняется сервером
+	Pauses        []*Pause `protobuf:"bytes,11,rep,name=pauses,proto3" json:"pauses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	mi := &file_subscriptions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Subscription) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *Subscription) GetServiceId() string {
+	if x != nil && x.ServiceId != nil {
+		return *x.ServiceId
+	}
+	return ""
+}
+
+func (x *Subscription) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *Subscription) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Subscription) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Subscription) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Subscription) GetEndDate() string {
+	if x != nil && x.EndDate != nil {
+		return *x.EndDate
+	}
+	return ""
+}
+
+func (x *Subscription) GetTrialEndDate() string {
+	if x != nil && x.TrialEndDate != nil {
+		return *x.TrialEndDate
+	}
+	return ""
+}
+
+func (x *Subscription) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *Subscription) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Subscription) GetPauses() []*Pause {
+	if x != nil {
+		return x.Pauses
+	}
+	return nil
+}
+
+type SubscriptionPatch struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId    *string                `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3,oneof" json:"service_id,omitempty"`
+	ServiceName  *string                `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3,oneof" json:"service_name,omitempty"`
+	Price        *int64                 `protobuf:"varint,3,opt,name=price,proto3,oneof" json:"price,omitempty"`
+	UserId       *string                `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	StartDate    *string                `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"`
+	EndDate      *string                `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
+	TrialEndDate *string                `protobuf:"bytes,7,opt,name=trial_end_date,json=trialEndDate,proto3,oneof" json:"trial_end_date,omitempty"`
+	Category     *string                `protobuf:"bytes,8,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	// Заменяет теги, если задан
+	Tags          *Tags `protobuf:"bytes,9,opt,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscriptionPatch) Reset() {
+	*x = SubscriptionPatch{}
+	mi := &file_subscriptions_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscriptionPatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionPatch) ProtoMessage() {}
+
+func (x *SubscriptionPatch) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionPatch.ProtoReflect.Descriptor instead.
+func (*SubscriptionPatch) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubscriptionPatch) GetServiceId() string {
+	if x != nil && x.ServiceId != nil {
+		return *x.ServiceId
+	}
+	return ""
+}
+
+func (x *SubscriptionPatch) GetServiceName() string {
+	if x != nil && x.ServiceName != nil {
+		return *x.ServiceName
+	}
+	return ""
+}
+
+func (x *SubscriptionPatch) GetPrice() int64 {
+	if x != nil && x.Price != nil {
+		return *x.Price
+	}
+	return 0
+}
+
+func (x *SubscriptionPatch) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *SubscriptionPatch) GetStartDate() string {
+	if x != nil && x.StartDate != nil {
+		return *x.StartDate
+	}
+	return ""
+}
+
+func (x *SubscriptionPatch) GetEndDate() string {
+	if x != nil && x.EndDate != nil {
+		return *x.EndDate
+	}
+	return ""
+}
+
+func (x *SubscriptionPatch) GetTrialEndDate() string {
+	if x != nil && x.TrialEndDate != nil {
+		return *x.TrialEndDate
+	}
+	return ""
+}
+
+func (x *SubscriptionPatch) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *SubscriptionPatch) GetTags() *Tags {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type Tags struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tags) Reset() {
+	*x = Tags{}
+	mi := &file_subscriptions_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tags) ProtoMessage() {}
+
+func (x *Tags) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tags.ProtoReflect.Descriptor instead.
+func (*Tags) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Tags) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type ListSubscriptionsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Offset            *int64                 `protobuf:"varint,1,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	Limit             *int64                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	StartDate         *string                `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"`
+	EndDate           *string                `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
+	UserId            []string               `protobuf:"bytes,5,rep,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServiceName       []string               `protobuf:"bytes,6,rep,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Category          []string               `protobuf:"bytes,7,rep,name=category,proto3" json:"category,omitempty"`
+	Tag               []string               `protobuf:"bytes,8,rep,name=tag,proto3" json:"tag,omitempty"`
+	ServiceNamePrefix *string                `protobuf:"bytes,9,opt,name=service_name_prefix,json=serviceNamePrefix,proto3,oneof" json:"service_name_prefix,omitempty"`
+	PriceMin          *int64                 `protobuf:"varint,10,opt,name=price_min,json=priceMin,proto3,oneof" json:"price_min,omitempty"`
+	PriceMax          *int64                 `protobuf:"varint,11,opt,name=price_max,json=priceMax,proto3,oneof" json:"price_max,omitempty"`
+	// active, ended, future или trial
+	Status        *string `protobuf:"bytes,12,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionsRequest) Reset() {
+	*x = ListSubscriptionsRequest{}
+	mi := &file_subscriptions_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionsRequest) ProtoMessage() {}
+
+func (x *ListSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListSubscriptionsRequest) GetOffset() int64 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *ListSubscriptionsRequest) GetLimit() int64 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListSubscriptionsRequest) GetStartDate() string {
+	if x != nil && x.StartDate != nil {
+		return *x.StartDate
+	}
+	return ""
+}
+
+func (x *ListSubscriptionsRequest) GetEndDate() string {
+	if x != nil && x.EndDate != nil {
+		return *x.EndDate
+	}
+	return ""
+}
+
+func (x *ListSubscriptionsRequest) GetUserId() []string {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *ListSubscriptionsRequest) GetServiceName() []string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return nil
+}
+
+func (x *ListSubscriptionsRequest) GetCategory() []string {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *ListSubscriptionsRequest) GetTag() []string {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+func (x *ListSubscriptionsRequest) GetServiceNamePrefix() string {
+	if x != nil && x.ServiceNamePrefix != nil {
+		return *x.ServiceNamePrefix
+	}
+	return ""
+}
+
+func (x *ListSubscriptionsRequest) GetPriceMin() int64 {
+	if x != nil && x.PriceMin != nil {
+		return *x.PriceMin
+	}
+	return 0
+}
+
+func (x *ListSubscriptionsRequest) GetPriceMax() int64 {
+	if x != nil && x.PriceMax != nil {
+		return *x.PriceMax
+	}
+	return 0
+}
+
+func (x *ListSubscriptionsRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+type ListSubscriptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*Subscription        `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubscriptionsResponse) Reset() {
+	*x = ListSubscriptionsResponse{}
+	mi := &file_subscriptions_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionsResponse) ProtoMessage() {}
+
+func (x *ListSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListSubscriptionsResponse) GetSubscriptions() []*Subscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+type ReadSubscriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReadSubscriptionRequest) Reset() {
+	*x = ReadSubscriptionRequest{}
+	mi := &file_subscriptions_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadSubscriptionRequest) ProtoMessage() {}
+
+func (x *ReadSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*ReadSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReadSubscriptionRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+type CreateSubscriptionRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Subscription     *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	RejectDuplicates bool                   `protobuf:"varint,2,opt,name=reject_duplicates,json=rejectDuplicates,proto3" json:"reject_duplicates,omitempty"`
+	IdempotencyKey   *string                `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateSubscriptionRequest) Reset() {
+	*x = CreateSubscriptionRequest{}
+	mi := &file_subscriptions_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateSubscriptionRequest) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+func (x *CreateSubscriptionRequest) GetRejectDuplicates() bool {
+	if x != nil {
+		return x.RejectDuplicates
+	}
+	return false
+}
+
+func (x *CreateSubscriptionRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+type CreateSubscriptionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	// Пересекающиеся подписки пользователя на тот же сервис
+	Duplicates    []string `protobuf:"bytes,2,rep,name=duplicates,proto3" json:"duplicates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSubscriptionResponse) Reset() {
+	*x = CreateSubscriptionResponse{}
+	mi := &file_subscriptions_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubscriptionResponse) ProtoMessage() {}
+
+func (x *CreateSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*CreateSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateSubscriptionResponse) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionResponse) GetDuplicates() []string {
+	if x != nil {
+		return x.Duplicates
+	}
+	return nil
+}
+
+type UpdateSubscriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	Patch          *SubscriptionPatch     `protobuf:"bytes,2,opt,name=patch,proto3" json:"patch,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateSubscriptionRequest) Reset() {
+	*x = UpdateSubscriptionRequest{}
+	mi := &file_subscriptions_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSubscriptionRequest) ProtoMessage() {}
+
+func (x *UpdateSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateSubscriptionRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionRequest) GetPatch() *SubscriptionPatch {
+	if x != nil {
+		return x.Patch
+	}
+	return nil
+}
+
+type DeleteSubscriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteSubscriptionRequest) Reset() {
+	*x = DeleteSubscriptionRequest{}
+	mi := &file_subscriptions_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSubscriptionRequest) ProtoMessage() {}
+
+func (x *DeleteSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteSubscriptionRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+type StatsSubscriptionsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	StartDate   *string                `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"`
+	EndDate     *string                `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
+	UserId      []string               `protobuf:"bytes,3,rep,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServiceName []string               `protobuf:"bytes,4,rep,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Category    []string               `protobuf:"bytes,5,rep,name=category,proto3" json:"category,omitempty"`
+	Tag         []string               `protobuf:"bytes,6,rep,name=tag,proto3" json:"tag,omitempty"`
+	// service или category
+	GroupBy       *string `protobuf:"bytes,7,opt,name=group_by,json=groupBy,proto3,oneof" json:"group_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsSubscriptionsRequest) Reset() {
+	*x = StatsSubscriptionsRequest{}
+	mi := &file_subscriptions_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsSubscriptionsRequest) ProtoMessage() {}
+
+func (x *StatsSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*StatsSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StatsSubscriptionsRequest) GetStartDate() string {
+	if x != nil && x.StartDate != nil {
+		return *x.StartDate
+	}
+	return ""
+}
+
+func (x *StatsSubscriptionsRequest) GetEndDate() string {
+	if x != nil && x.EndDate != nil {
+		return *x.EndDate
+	}
+	return ""
+}
+
+func (x *StatsSubscriptionsRequest) GetUserId() []string {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *StatsSubscriptionsRequest) GetServiceName() []string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return nil
+}
+
+func (x *StatsSubscriptionsRequest) GetCategory() []string {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *StatsSubscriptionsRequest) GetTag() []string {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+func (x *StatsSubscriptionsRequest) GetGroupBy() string {
+	if x != nil && x.GroupBy != nil {
+		return *x.GroupBy
+	}
+	return ""
+}
+
+type StatsGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TotalPrice    int64                  `protobuf:"varint,3,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsGroup) Reset() {
+	*x = StatsGroup{}
+	mi := &file_subscriptions_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsGroup) ProtoMessage() {}
+
+func (x *StatsGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsGroup.ProtoReflect.Descriptor instead.
+func (*StatsGroup) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StatsGroup) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *StatsGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StatsGroup) GetTotalPrice() int64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+type StatsSubscriptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalPrice    int64                  `protobuf:"varint,1,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	Groups        []*StatsGroup          `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsSubscriptionsResponse) Reset() {
+	*x = StatsSubscriptionsResponse{}
+	mi := &file_subscriptions_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsSubscriptionsResponse) ProtoMessage() {}
+
+func (x *StatsSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_subscriptions_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*StatsSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_subscriptions_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StatsSubscriptionsResponse) GetTotalPrice() int64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *StatsSubscriptionsResponse) GetGroups() []*StatsGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+var File_subscriptions_proto protoreflect.FileDescriptor
+
+const file_subscriptions_proto_rawDesc = "" +
+	"\n" +
+	"\x13subscriptions.proto\x12\x13ew.subscriptions.v1\x1a\x1bgoogle/protobuf/empty.proto\"\\\n" +
+	"\x05Pause\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12$\n" +
+	"\vresume_date\x18\x02 \x01(\tH\x00R\n" +
+	"resumeDate\x88\x01\x01B\x0e\n" +
+	"\f_resume_date\"\xbc\x03\n" +
+	"\fSubscription\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\x12\"\n" +
+	"\n" +
+	"service_id\x18\x02 \x01(\tH\x00R\tserviceId\x88\x01\x01\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x06 \x01(\tR\tstartDate\x12\x1e\n" +
+	"\bend_date\x18\a \x01(\tH\x01R\aendDate\x88\x01\x01\x12)\n" +
+	"\x0etrial_end_date\x18\b \x01(\tH\x02R\ftrialEndDate\x88\x01\x01\x12\x1f\n" +
+	"\bcategory\x18\t \x01(\tH\x03R\bcategory\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x122\n" +
+	"\x06pauses\x18\v \x03(\v2\x1a.ew.subscriptions.v1.PauseR\x06pausesB\r\n" +
+	"\v_service_idB\v\n" +
+	"\t_end_dateB\x11\n" +
+	"\x0f_trial_end_dateB\v\n" +
+	"\t_category\"\xc9\x03\n" +
+	"\x11SubscriptionPatch\x12\"\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tH\x00R\tserviceId\x88\x01\x01\x12&\n" +
+	"\fservice_name\x18\x02 \x01(\tH\x01R\vserviceName\x88\x01\x01\x12\x19\n" +
+	"\x05price\x18\x03 \x01(\x03H\x02R\x05price\x88\x01\x01\x12\x1c\n" +
+	"\auser_id\x18\x04 \x01(\tH\x03R\x06userId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\tH\x04R\tstartDate\x88\x01\x01\x12\x1e\n" +
+	"\bend_date\x18\x06 \x01(\tH\x05R\aendDate\x88\x01\x01\x12)\n" +
+	"\x0etrial_end_date\x18\a \x01(\tH\x06R\ftrialEndDate\x88\x01\x01\x12\x1f\n" +
+	"\bcategory\x18\b \x01(\tH\aR\bcategory\x88\x01\x01\x12-\n" +
+	"\x04tags\x18\t \x01(\v2\x19.ew.subscriptions.v1.TagsR\x04tagsB\r\n" +
+	"\v_service_idB\x0f\n" +
+	"\r_service_nameB\b\n" +
+	"\x06_priceB\n" +
+	"\n" +
+	"\b_user_idB\r\n" +
+	"\v_start_dateB\v\n" +
+	"\t_end_dateB\x11\n" +
+	"\x0f_trial_end_dateB\v\n" +
+	"\t_category\"\x1e\n" +
+	"\x04Tags\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\x86\x04\n" +
+	"\x18ListSubscriptionsRequest\x12\x1b\n" +
+	"\x06offset\x18\x01 \x01(\x03H\x00R\x06offset\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x02 \x01(\x03H\x01R\x05limit\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\tH\x02R\tstartDate\x88\x01\x01\x12\x1e\n" +
+	"\bend_date\x18\x04 \x01(\tH\x03R\aendDate\x88\x01\x01\x12\x17\n" +
+	"\auser_id\x18\x05 \x03(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\x06 \x03(\tR\vserviceName\x12\x1a\n" +
+	"\bcategory\x18\a \x03(\tR\bcategory\x12\x10\n" +
+	"\x03tag\x18\b \x03(\tR\x03tag\x123\n" +
+	"\x13service_name_prefix\x18\t \x01(\tH\x04R\x11serviceNamePrefix\x88\x01\x01\x12 \n" +
+	"\tprice_min\x18\n" +
+	" \x01(\x03H\x05R\bpriceMin\x88\x01\x01\x12 \n" +
+	"\tprice_max\x18\v \x01(\x03H\x06R\bpriceMax\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\f \x01(\tH\aR\x06status\x88\x01\x01B\t\n" +
+	"\a_offsetB\b\n" +
+	"\x06_limitB\r\n" +
+	"\v_start_dateB\v\n" +
+	"\t_end_dateB\x16\n" +
+	"\x14_service_name_prefixB\f\n" +
+	"\n" +
+	"_price_minB\f\n" +
+	"\n" +
+	"_price_maxB\t\n" +
+	"\a_status\"d\n" +
+	"\x19ListSubscriptionsResponse\x12G\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2!.ew.subscriptions.v1.SubscriptionR\rsubscriptions\"B\n" +
+	"\x17ReadSubscriptionRequest\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\"\xd1\x01\n" +
+	"\x19CreateSubscriptionRequest\x12E\n" +
+	"\fsubscription\x18\x01 \x01(\v2!.ew.subscriptions.v1.SubscriptionR\fsubscription\x12+\n" +
+	"\x11reject_duplicates\x18\x02 \x01(\bR\x10rejectDuplicates\x12,\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01B\x12\n" +
+	"\x10_idempotency_key\"e\n" +
+	"\x1aCreateSubscriptionResponse\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x02 \x03(\tR\n" +
+	"duplicates\"\x82\x01\n" +
+	"\x19UpdateSubscriptionRequest\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\x12<\n" +
+	"\x05patch\x18\x02 \x01(\v2&.ew.subscriptions.v1.SubscriptionPatchR\x05patch\"D\n" +
+	"\x19DeleteSubscriptionRequest\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\"\x92\x02\n" +
+	"\x19StatsSubscriptionsRequest\x12\"\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tH\x00R\tstartDate\x88\x01\x01\x12\x1e\n" +
+	"\bend_date\x18\x02 \x01(\tH\x01R\aendDate\x88\x01\x01\x12\x17\n" +
+	"\auser_id\x18\x03 \x03(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\x04 \x03(\tR\vserviceName\x12\x1a\n" +
+	"\bcategory\x18\x05 \x03(\tR\bcategory\x12\x10\n" +
+	"\x03tag\x18\x06 \x03(\tR\x03tag\x12\x1e\n" +
+	"\bgroup_by\x18\a \x01(\tH\x02R\agroupBy\x88\x01\x01B\r\n" +
+	"\v_start_dateB\v\n" +
+	"\t_end_dateB\v\n" +
+	"\t_group_by\"S\n" +
+	"\n" +
+	"StatsGroup\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vtotal_price\x18\x03 \x01(\x03R\n" +
+	"totalPrice\"v\n" +
+	"\x1aStatsSubscriptionsResponse\x12\x1f\n" +
+	"\vtotal_price\x18\x01 \x01(\x03R\n" +
+	"totalPrice\x127\n" +
+	"\x06groups\x18\x02 \x03(\v2\x1f.ew.subscriptions.v1.StatsGroupR\x06groups2\x83\x06\n" +
+	"\x13SubscriptionService\x12r\n" +
+	"\x11ListSubscriptions\x12-.ew.subscriptions.v1.ListSubscriptionsRequest\x1a..ew.subscriptions.v1.ListSubscriptionsResponse\x12i\n" +
+	"\x13StreamSubscriptions\x12-.ew.subscriptions.v1.ListSubscriptionsRequest\x1a!.ew.subscriptions.v1.Subscription0\x01\x12c\n" +
+	"\x10ReadSubscription\x12,.ew.subscriptions.v1.ReadSubscriptionRequest\x1a!.ew.subscriptions.v1.Subscription\x12u\n" +
+	"\x12CreateSubscription\x12..ew.subscriptions.v1.CreateSubscriptionRequest\x1a/.ew.subscriptions.v1.CreateSubscriptionResponse\x12\\\n" +
+	"\x12UpdateSubscription\x12..ew.subscriptions.v1.UpdateSubscriptionRequest\x1a\x16.google.protobuf.Empty\x12\\\n" +
+	"\x12DeleteSubscription\x12..ew.subscriptions.v1.DeleteSubscriptionRequest\x1a\x16.google.protobuf.Empty\x12u\n" +
+	"\x12StatsSubscriptions\x12..ew.subscriptions.v1.StatsSubscriptionsRequest\x1a/.ew.subscriptions.v1.StatsSubscriptionsResponseB\x14Z\x12ew/internal/rpc/pbb\x06proto3"
+
+var (
+	file_subscriptions_proto_rawDescOnce sync.Once
+	file_subscriptions_proto_rawDescData []byte
+)
+
+func file_subscriptions_proto_rawDescGZIP() []byte {
+	file_subscriptions_proto_rawDescOnce.Do(func() {
+		file_subscriptions_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_subscriptions_proto_rawDesc), len(file_subscriptions_proto_rawDesc)))
+	})
+	return file_subscriptions_proto_rawDescData
+}
+
+var file_subscriptions_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_subscriptions_proto_goTypes = []any{
+	(*Pause)(nil),                      // 0: ew.subscriptions.v1.Pause
+	(*Subscription)(nil),               // 1: ew.subscriptions.v1.Subscription
+	(*SubscriptionPatch)(nil),          // 2: ew.subscriptions.v1.SubscriptionPatch
+	(*Tags)(nil),                       // 3: ew.subscriptions.v1.Tags
+	(*ListSubscriptionsRequest)(nil),   // 4: ew.subscriptions.v1.ListSubscriptionsRequest
+	(*ListSubscriptionsResponse)(nil),  // 5: ew.subscriptions.v1.ListSubscriptionsResponse
+	(*ReadSubscriptionRequest)(nil),    // 6: ew.subscriptions.v1.ReadSubscriptionRequest
+	(*CreateSubscriptionRequest)(nil),  // 7: ew.subscriptions.v1.CreateSubscriptionRequest
+	(*CreateSubscriptionResponse)(nil), // 8: ew.subscriptions.v1.CreateSubscriptionResponse
+	(*UpdateSubscriptionRequest)(nil),  // 9: ew.subscriptions.v1.UpdateSubscriptionRequest
+	(*DeleteSubscriptionRequest)(nil),  // 10: ew.subscriptions.v1.DeleteSubscriptionRequest
+	(*StatsSubscriptionsRequest)(nil),  // 11: ew.subscriptions.v1.StatsSubscriptionsRequest
+	(*StatsGroup)(nil),                 // 12: ew.subscriptions.v1.StatsGroup
+	(*StatsSubscriptionsResponse)(nil), // 13: ew.subscriptions.v1.StatsSubscriptionsResponse
+	(*emptypb.Empty)(nil),              // 14: google.protobuf.Empty
+}
+var file_subscriptions_proto_depIdxs = []int32{
+	0,  // 0: ew.subscriptions.v1.Subscription.pauses:type_name -> ew.subscriptions.v1.Pause
+	3,  // 1: ew.subscriptions.v1.SubscriptionPatch.tags:type_name -> ew.subscriptions.v1.Tags
+	1,  // 2: ew.subscriptions.v1.ListSubscriptionsResponse.subscriptions:type_name -> ew.subscriptions.v1.Subscription
+	1,  // 3: ew.subscriptions.v1.CreateSubscriptionRequest.subscription:type_name -> ew.subscriptions.v1.Subscription
+	2,  // 4: ew.subscriptions.v1.UpdateSubscriptionRequest.patch:type_name -> ew.subscriptions.v1.SubscriptionPatch
+	12, // 5: ew.subscriptions.v1.StatsSubscriptionsResponse.groups:type_name -> ew.subscriptions.v1.StatsGroup
+	4,  // 6: ew.subscriptions.v1.SubscriptionService.ListSubscriptions:input_type -> ew.subscriptions.v1.ListSubscriptionsRequest
+	4,  // 7: ew.subscriptions.v1.SubscriptionService.StreamSubscriptions:input_type -> ew.subscriptions.v1.ListSubscriptionsRequest
+	6,  // 8: ew.subscriptions.v1.SubscriptionService.ReadSubscription:input_type -> ew.subscriptions.v1.ReadSubscriptionRequest
+	7,  // 9: ew.subscriptions.v1.SubscriptionService.CreateSubscription:input_type -> ew.subscriptions.v1.CreateSubscriptionRequest
+	9,  // 10: ew.subscriptions.v1.SubscriptionService.UpdateSubscription:input_type -> ew.subscriptions.v1.UpdateSubscriptionRequest
+	10, // 11: ew.subscriptions.v1.SubscriptionService.DeleteSubscription:input_type -> ew.subscriptions.v1.DeleteSubscriptionRequest
+	11, // 12: ew.subscriptions.v1.SubscriptionService.StatsSubscriptions:input_type -> ew.subscriptions.v1.StatsSubscriptionsRequest
+	5,  // 13: ew.subscriptions.v1.SubscriptionService.ListSubscriptions:output_type -> ew.subscriptions.v1.ListSubscriptionsResponse
+	1,  // 14: ew.subscriptions.v1.SubscriptionService.StreamSubscriptions:output_type -> ew.subscriptions.v1.Subscription
+	1,  // 15: ew.subscriptions.v1.SubscriptionService.ReadSubscription:output_type -> ew.subscriptions.v1.Subscription
+	8,  // 16: ew.subscriptions.v1.SubscriptionService.CreateSubscription:output_type -> ew.subscriptions.v1.CreateSubscriptionResponse
+	14, // 17: ew.subscriptions.v1.SubscriptionService.UpdateSubscription:output_type -> google.protobuf.Empty
+	14, // 18: ew.subscriptions.v1.SubscriptionService.DeleteSubscription:output_type -> google.protobuf.Empty
+	13, // 19: ew.subscriptions.v1.SubscriptionService.StatsSubscriptions:output_type -> ew.subscriptions.v1.StatsSubscriptionsResponse
+	13, // [13:20] is the sub-list for method output_type
+	6,  // [6:13] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_subscriptions_proto_init() }
+func file_subscriptions_proto_init() {
+	if File_subscriptions_proto != nil {
+		return
+	}
+	file_subscriptions_proto_msgTypes[0].OneofWrappers = []any{}
+	file_subscriptions_proto_msgTypes[1].OneofWrappers = []any{}
+	file_subscriptions_proto_msgTypes[2].OneofWrappers = []any{}
+	file_subscriptions_proto_msgTypes[4].OneofWrappers = []any{}
+	file_subscriptions_proto_msgTypes[7].OneofWrappers = []any{}
+	file_subscriptions_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_subscriptions_proto_rawDesc), len(file_subscriptions_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_subscriptions_proto_goTypes,
+		DependencyIndexes: file_subscriptions_proto_depIdxs,
+		MessageInfos:      file_subscriptions_proto_msgTypes,
+	}.Build()
+	File_subscriptions_proto = out.File
+	file_subscriptions_proto_goTypes = nil
+	file_subscriptions_proto_depIdxs = nil
+}
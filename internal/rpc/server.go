@@ -0,0 +1,252 @@
+package rpc
+
+import (
+	"context"
+	"ew/internal/rpc/pb"
+	"ew/internal/transport"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// DefaultStreamPageSize is the number of subscriptions StreamSubscriptions reads at once.
+const DefaultStreamPageSize = 500
+
+// Server implements SubscriptionService on top of transport.Server,
+// so both APIs share the repository, the validation and the localized messages.
+type Server struct {
+	pb.UnimplementedSubscriptionServiceServer
+
+	REST           transport.Server
+	StreamPageSize int
+}
+
+func NewServer(rest transport.Server) *Server {
+	return &Server{REST: rest, StreamPageSize: DefaultStreamPageSize}
+}
+
+// Register adds the service to a gRPC server.
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	pb.RegisterSubscriptionServiceServer(registrar, s)
+}
+
+func unexpected(res any) error {
+	return status.Errorf(codes.Internal, "unexpected response %T", res)
+}
+
+func (s *Server) ListSubscriptions(ctx context.Context, req *pb.ListSubscriptionsRequest) (*pb.ListSubscriptionsResponse, error) {
+	params, err := s.listParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.list(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListSubscriptionsResponse{Subscriptions: make([]*pb.Subscription, 0, len(items))}
+	for _, item := range items {
+		res.Subscriptions = append(res.Subscriptions, subscriptionToProto(item))
+	}
+	return res, nil
+}
+
+func (s *Server) list(ctx context.Context, params transport.ListSubscriptionsParams) ([]transport.Subscription, error) {
+	res, err := s.REST.ListSubscriptions(ctx, transport.ListSubscriptionsRequestObject{Params: params})
+	if err != nil {
+		return nil, errInternal
+	}
+
+	switch res := res.(type) {
+	case transport.ListSubscriptions200JSONResponse:
+		return res, nil
+	case transport.ListSubscriptionsdefaultApplicationProblemPlusJSONResponse:
+		return nil, problemError(res.Body)
+	}
+	return nil, unexpected(res)
+}
+
+// StreamSubscriptions reads the listing page by page, the limit and the offset of the request apply to the whole stream.
+func (s *Server) StreamSubscriptions(req *pb.ListSubscriptionsRequest, stream grpc.ServerStreamingServer[pb.Subscription]) error {
+	params, err := s.listParams(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	remaining := params.Limit
+
+	for {
+		page := s.StreamPageSize
+		if remaining != nil && *remaining < page {
+			page = *remaining
+		}
+		params.Offset, params.Limit = &offset, &page
+
+		items, err := s.list(stream.Context(), params)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := stream.Send(subscriptionToProto(item)); err != nil {
+				return err
+			}
+		}
+
+		offset += len(items)
+		if remaining != nil {
+			left := *remaining - len(items)
+			remaining = &left
+			if left <= 0 {
+				return nil
+			}
+		}
+		if len(items) < page {
+			return nil
+		}
+	}
+}
+
+func (s *Server) ReadSubscription(ctx context.Context, req *pb.ReadSubscriptionRequest) (*pb.Subscription, error) {
+	id, err := s.parseUUID(ctx, "subscription_id", req.GetSubscriptionId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.REST.ReadSubscription(ctx, transport.ReadSubscriptionRequestObject{SubscriptionId: id})
+	if err != nil {
+		return nil, errInternal
+	}
+
+	switch res := res.(type) {
+	case transport.ReadSubscription200JSONResponse:
+		return subscriptionToProto(transport.Subscription(res)), nil
+	case transport.ReadSubscription404ApplicationProblemPlusJSONResponse:
+		return nil, problemError(transport.Problem(res))
+	case transport.ReadSubscriptiondefaultApplicationProblemPlusJSONResponse:
+		return nil, problemError(res.Body)
+	}
+	return nil, unexpected(res)
+}
+
+func (s *Server) CreateSubscription(ctx context.Context, req *pb.CreateSubscriptionRequest) (*pb.CreateSubscriptionResponse, error) {
+	body, err := s.subscriptionFromProto(ctx, req.GetSubscription())
+	if err != nil {
+		return nil, err
+	}
+
+	rejectDuplicates := req.GetRejectDuplicates()
+	res, err := s.REST.CreateSubscription(ctx, transport.CreateSubscriptionRequestObject{
+		Params: transport.CreateSubscriptionParams{RejectDuplicates: &rejectDuplicates, IdempotencyKey: req.IdempotencyKey},
+		Body:   body,
+	})
+	if err != nil {
+		return nil, errInternal
+	}
+
+	switch res := res.(type) {
+	case transport.CreateSubscription200JSONResponse:
+		created := &pb.CreateSubscriptionResponse{}
+		if res.SubscriptionId != nil {
+			created.SubscriptionId = res.SubscriptionId.String()
+		}
+		if res.Duplicates != nil {
+			for _, id := range *res.Duplicates {
+				created.Duplicates = append(created.Duplicates, id.String())
+			}
+		}
+		return created, nil
+	case transport.CreateSubscription409ApplicationProblemPlusJSONResponse:
+		return nil, problemError(transport.Problem(res))
+	case transport.CreateSubscription422ApplicationProblemPlusJSONResponse:
+		return nil, problemError(transport.Problem(res))
+	case transport.CreateSubscriptiondefaultApplicationProblemPlusJSONResponse:
+		return nil, problemError(res.Body)
+	}
+	return nil, unexpected(res)
+}
+
+func (s *Server) UpdateSubscription(ctx context.Context, req *pb.UpdateSubscriptionRequest) (*emptypb.Empty, error) {
+	id, err := s.parseUUID(ctx, "subscription_id", req.GetSubscriptionId())
+	if err != nil {
+		return nil, err
+	}
+	body, err := s.patchFromProto(ctx, req.GetPatch())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.REST.UpdateSubscription(ctx, transport.UpdateSubscriptionRequestObject{SubscriptionId: id, JSONBody: body})
+	if err != nil {
+		return nil, errInternal
+	}
+
+	switch res := res.(type) {
+	case transport.UpdateSubscription204Response:
+		return &emptypb.Empty{}, nil
+	case transport.UpdateSubscription404ApplicationProblemPlusJSONResponse:
+		return nil, problemError(transport.Problem(res))
+	case transport.UpdateSubscription422ApplicationProblemPlusJSONResponse:
+		return nil, problemError(transport.Problem(res))
+	case transport.UpdateSubscriptiondefaultApplicationProblemPlusJSONResponse:
+		return nil, problemError(res.Body)
+	}
+	return nil, unexpected(res)
+}
+
+func (s *Server) DeleteSubscription(ctx context.Context, req *pb.DeleteSubscriptionRequest) (*emptypb.Empty, error) {
+	id, err := s.parseUUID(ctx, "subscription_id", req.GetSubscriptionId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.REST.DeleteSubscription(ctx, transport.DeleteSubscriptionRequestObject{SubscriptionId: id})
+	if err != nil {
+		return nil, errInternal
+	}
+
+	switch res := res.(type) {
+	case transport.DeleteSubscription204Response:
+		return &emptypb.Empty{}, nil
+	case transport.DeleteSubscription404ApplicationProblemPlusJSONResponse:
+		return nil, problemError(transport.Problem(res))
+	case transport.DeleteSubscriptiondefaultApplicationProblemPlusJSONResponse:
+		return nil, problemError(res.Body)
+	}
+	return nil, unexpected(res)
+}
+
+func (s *Server) StatsSubscriptions(ctx context.Context, req *pb.StatsSubscriptionsRequest) (*pb.StatsSubscriptionsResponse, error) {
+	params, err := s.statsParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.REST.StatsSubscriptions(ctx, transport.StatsSubscriptionsRequestObject{Params: params})
+	if err != nil {
+		return nil, errInternal
+	}
+
+	switch res := res.(type) {
+	case transport.StatsSubscriptions200JSONResponse:
+		stats := &pb.StatsSubscriptionsResponse{}
+		if res.TotalPrice != nil {
+			stats.TotalPrice = int64(*res.TotalPrice)
+		}
+		if res.Groups != nil {
+			for _, group := range *res.Groups {
+				stats.Groups = append(stats.Groups, &pb.StatsGroup{Key: group.Key, Name: group.Name, TotalPrice: int64(group.TotalPrice)})
+			}
+		}
+		return stats, nil
+	case transport.StatsSubscriptionsdefaultApplicationProblemPlusJSONResponse:
+		return nil, problemError(res.Body)
+	}
+	return nil, unexpected(res)
+}
@@ -0,0 +1,246 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"ew/internal/models/subscriptions"
+	"ew/internal/rpc/pb"
+	"ew/internal/storage/inmemory"
+	"ew/internal/transport"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	id1 = uuid.New()
+	id2 = uuid.New()
+	id3 = uuid.New()
+)
+
+func prepareClient(t *testing.T) (pb.SubscriptionServiceClient, *inmemory.SubscriptionRepository) {
+	repo := inmemory.NewRepo([]*subscriptions.Subscription{
+		{ID: id1, ServiceName: "some item", Price: 125, StartDate: time.Now().AddDate(-1, -5, 0), UserId: uuid.New()},
+		{ID: id2, ServiceName: "item 2", Price: 250, StartDate: time.Now().AddDate(0, -2, 0), UserId: uuid.New()},
+		{ID: id3, ServiceName: "nothing", Price: 500, StartDate: time.Now().AddDate(0, -1, 0), UserId: uuid.New()},
+	})
+	rest := transport.NewServer(repo, inmemory.NewServiceRepo(nil, repo), validator.New())
+	rest.Idempotency = inmemory.NewIdempotencyRepo()
+
+	server := NewServer(rest)
+	server.StreamPageSize = 2
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(UnaryInterceptor), grpc.StreamInterceptor(StreamInterceptor))
+	server.Register(grpcServer)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	return pb.NewSubscriptionServiceClient(conn), repo
+}
+
+func fieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			return badRequest.GetFieldViolations()
+		}
+	}
+	return nil
+}
+
+func TestServerCRUD(t *testing.T) {
+	client, repo := prepareClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateSubscription(ctx, &pb.CreateSubscriptionRequest{Subscription: &pb.Subscription{
+		ServiceName: "test",
+		Price:       105,
+		UserId:      uuid.NewString(),
+		StartDate:   "11-2000",
+		Tags:        []string{"Work"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := client.ReadSubscription(ctx, &pb.ReadSubscriptionRequest{SubscriptionId: created.GetSubscriptionId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.GetServiceName() != "test" || item.GetPrice() != 105 || item.GetStartDate() != "11-2000" || !proto.Equal(item, &pb.Subscription{
+		SubscriptionId: created.GetSubscriptionId(), ServiceId: item.ServiceId, ServiceName: "test", Price: 105,
+		UserId: item.GetUserId(), StartDate: "11-2000", Tags: []string{"work"},
+	}) {
+		t.Errorf("unexpected subscription %v", item)
+	}
+
+	end := "12-2000"
+	_, err = client.UpdateSubscription(ctx, &pb.UpdateSubscriptionRequest{
+		SubscriptionId: created.GetSubscriptionId(),
+		Patch:          &pb.SubscriptionPatch{EndDate: &end, Tags: &pb.Tags{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, _ := repo.GetByID(ctx, uuid.MustParse(created.GetSubscriptionId()))
+	if stored.EndDate == nil || len(stored.Tags) != 0 {
+		t.Errorf("not updated %v", stored)
+	}
+
+	_, err = client.DeleteSubscription(ctx, &pb.DeleteSubscriptionRequest{SubscriptionId: created.GetSubscriptionId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.ReadSubscription(ctx, &pb.ReadSubscriptionRequest{SubscriptionId: created.GetSubscriptionId()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("deleted subscription read: %v", err)
+	}
+	_, err = client.DeleteSubscription(ctx, &pb.DeleteSubscriptionRequest{SubscriptionId: created.GetSubscriptionId()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("deleted subscription deleted again: %v", err)
+	}
+}
+
+func TestServerValidation(t *testing.T) {
+	client, _ := prepareClient(t)
+	ctx := context.Background()
+
+	_, err := client.CreateSubscription(ctx, &pb.CreateSubscriptionRequest{Subscription: &pb.Subscription{ServiceName: "test", StartDate: "2000-11"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("invalid subscription created: %v", err)
+	}
+	fields := map[string]bool{}
+	for _, violation := range fieldViolations(err) {
+		fields[violation.GetField()] = true
+	}
+	if !fields["price"] || !fields["user_id"] || !fields["start_date"] {
+		t.Errorf("unexpected violations %v", fieldViolations(err))
+	}
+
+	_, err = client.ReadSubscription(ctx, &pb.ReadSubscriptionRequest{SubscriptionId: "not-an-id"})
+	if status.Code(err) != codes.InvalidArgument || len(fieldViolations(err)) != 1 || fieldViolations(err)[0].GetField() != "subscription_id" ||
+		fieldViolations(err)[0].GetDescription() != "subscription_id must be a valid UUID" {
+		t.Errorf("malformed id accepted: %v", err)
+	}
+
+	// the messages follow accept-language like the REST API
+	ctx = metadata.AppendToOutgoingContext(ctx, "accept-language", "ru-RU")
+	start := time.Now().Format("01-2006")
+	_, err = client.UpdateSubscription(ctx, &pb.UpdateSubscriptionRequest{
+		SubscriptionId: id2.String(),
+		Patch:          &pb.SubscriptionPatch{EndDate: &start, StartDate: proto.String(time.Now().AddDate(1, 0, 0).Format("01-2006"))},
+	})
+	violations := fieldViolations(err)
+	if status.Code(err) != codes.InvalidArgument || len(violations) != 1 || violations[0].GetField() != "end_date" || violations[0].GetDescription() == "end_date must be greater than or equal to start_date" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	_, err = client.DeleteSubscription(ctx, &pb.DeleteSubscriptionRequest{SubscriptionId: "not-an-id"})
+	violations = fieldViolations(err)
+	if len(violations) != 1 || violations[0].GetField() != "subscription_id" || violations[0].GetDescription() == "subscription_id must be a valid UUID" {
+		t.Errorf("untranslated malformed id %v", err)
+	}
+}
+
+func TestServerListAndStats(t *testing.T) {
+	client, repo := prepareClient(t)
+	ctx := context.Background()
+
+	list, err := client.ListSubscriptions(ctx, &pb.ListSubscriptionsRequest{UserId: []string{repo.Items[1].UserId.String()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.GetSubscriptions()) != 1 || list.GetSubscriptions()[0].GetSubscriptionId() != id2.String() {
+		t.Errorf("unexpected list %v", list)
+	}
+
+	_, err = client.ListSubscriptions(ctx, &pb.ListSubscriptionsRequest{Status: proto.String("paused")})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid status accepted: %v", err)
+	}
+
+	stats, err := client.StatsSubscriptions(ctx, &pb.StatsSubscriptionsRequest{ServiceName: []string{"item 2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.GetTotalPrice() != 3*250 {
+		t.Errorf("unexpected stats %v", stats)
+	}
+}
+
+func TestServerStream(t *testing.T) {
+	client, _ := prepareClient(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		req      *pb.ListSubscriptionsRequest
+		expected []uuid.UUID
+	}{
+		// three items over pages of two
+		{&pb.ListSubscriptionsRequest{}, []uuid.UUID{id1, id2, id3}},
+		{&pb.ListSubscriptionsRequest{Offset: proto.Int64(1)}, []uuid.UUID{id2, id3}},
+		{&pb.ListSubscriptionsRequest{Limit: proto.Int64(2)}, []uuid.UUID{id1, id2}},
+		{&pb.ListSubscriptionsRequest{Offset: proto.Int64(1), Limit: proto.Int64(1)}, []uuid.UUID{id2}},
+	}
+
+	for _, c := range cases {
+		stream, err := client.StreamSubscriptions(ctx, c.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var received []uuid.UUID
+		for {
+			item, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			received = append(received, uuid.MustParse(item.GetSubscriptionId()))
+		}
+
+		if len(received) != len(c.expected) {
+			t.Errorf("%v: received %v, expected %v", c.req, received, c.expected)
+			continue
+		}
+		for i := range received {
+			if received[i] != c.expected[i] {
+				t.Errorf("%v: received %v, expected %v", c.req, received, c.expected)
+				break
+			}
+		}
+	}
+
+	stream, _ := client.StreamSubscriptions(ctx, &pb.ListSubscriptionsRequest{Limit: proto.Int64(0)})
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid limit accepted: %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"ew/internal/transport"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// problemCodes maps the statuses of the REST problems to gRPC codes, the rest become Internal.
+var problemCodes = map[int]codes.Code{
+	http.StatusBadRequest:           codes.InvalidArgument,
+	http.StatusNotFound:             codes.NotFound,
+	http.StatusConflict:             codes.FailedPrecondition,
+	http.StatusUnsupportedMediaType: codes.InvalidArgument,
+	http.StatusUnprocessableEntity:  codes.InvalidArgument,
+}
+
+// problemError converts a problem of transport.Server into a status, the field errors go into a BadRequest detail.
+func problemError(problem transport.Problem) error {
+	code, ok := problemCodes[problem.Status]
+	if !ok {
+		code = codes.Internal
+	}
+
+	message := problem.Title
+	if problem.Detail != nil {
+		message = *problem.Detail
+	}
+	st := status.New(code, message)
+
+	if problem.Errors == nil {
+		return st.Err()
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(*problem.Errors))
+	for _, field := range *problem.Errors {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field.Field, Description: field.Message})
+	}
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// errInternal replaces the errors transport.Server returns, it has already logged them.
+var errInternal = status.Error(codes.Internal, transport.InternalError.Error())
@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"ew/internal/models/subscriptions"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxEntries bounds the cache, it is emptied when a new entry would exceed it.
+	maxEntries = 10000
+	// DefaultTTL is how long an entry is served when TTL is not set.
+	DefaultTTL = 30 * time.Second
+)
+
+type entry struct {
+	params subscriptions.SubscriptionListParams
+	value  any
+	stored time.Time
+}
+
+// SubscriptionRepository wraps a SubscriptionRepo and caches GetStats and GetList results
+// until a write touches a matching user and service, the month changes or the entry expires.
+type SubscriptionRepository struct {
+	writer
+
+	// TTL bounds the age of the served entries, the writes made through other instances only reach the cache by expiry
+	TTL time.Duration
+
+	mu         sync.Mutex
+	entries    map[string]*entry
+	month      time.Time
+	generation int
+	hits       int
+	misses     int
+	now        func() time.Time
+}
+
+func NewRepo(repo subscriptions.SubscriptionRepo) *SubscriptionRepository {
+	res := &SubscriptionRepository{
+		TTL:     DefaultTTL,
+		entries: make(map[string]*entry),
+		now:     time.Now,
+	}
+	res.writer = writer{SubscriptionRepo: repo, stale: res}
+	return res
+}
+
+func (repo *SubscriptionRepository) GetStats(ctx context.Context, params subscriptions.SubscriptionListParams) (int, error) {
+	return cached(ctx, repo, "stats", params, repo.SubscriptionRepo.GetStats)
+}
+
+func (repo *SubscriptionRepository) GetList(ctx context.Context, params subscriptions.SubscriptionListParams) ([]*subscriptions.Subscription, error) {
+	items, err := cached(ctx, repo, "list", params, repo.SubscriptionRepo.GetList)
+	if err != nil {
+		return nil, err
+	}
+
+	// the callers get their own copies, the cached subscriptions are shared
+	res := make([]*subscriptions.Subscription, 0, len(items))
+	for _, item := range items {
+		res = append(res, item.Clone())
+	}
+	return res, nil
+}
+
+// Flush drops every entry, for the writes made around the repository such as linking a service.
+func (repo *SubscriptionRepository) Flush() {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.entries = make(map[string]*entry)
+	repo.generation++
+}
+
+func (repo *SubscriptionRepository) CacheStats() subscriptions.CacheStats {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return subscriptions.CacheStats{Hits: repo.hits, Misses: repo.misses, Entries: len(repo.entries)}
+}
+
+// invalidate drops the entries whose filters would select a subscription of the user and the service.
+func (repo *SubscriptionRepository) invalidate(userId uuid.UUID, serviceName string) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for key, entry := range repo.entries {
+		if matches(entry.params, userId, serviceName) {
+			delete(repo.entries, key)
+		}
+	}
+	repo.generation++
+}
+
+// matches only looks at the user and the service filters,
+// so entries filtered by other fields are invalidated even if the subscription is outside of them.
+func matches(params subscriptions.SubscriptionListParams, userId uuid.UUID, serviceName string) bool {
+	if len(params.UserIds) > 0 && !slices.Contains(params.UserIds, userId) {
+		return false
+	}
+	if len(params.ServiceNames) > 0 && !slices.Contains(params.ServiceNames, serviceName) {
+		return false
+	}
+	if params.ServiceNamePrefix != nil && !strings.HasPrefix(strings.ToLower(serviceName), strings.ToLower(*params.ServiceNamePrefix)) {
+		return false
+	}
+	return true
+}
+
+// cacheKey normalizes the filters, so the same set of values in another order shares the entry.
+func cacheKey(kind string, params subscriptions.SubscriptionListParams) string {
+	params.ServiceNames = slices.Sorted(slices.Values(params.ServiceNames))
+	params.UserIds = slices.SortedFunc(slices.Values(params.UserIds), func(a, b uuid.UUID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	params.Categories = slices.Sorted(slices.Values(params.Categories))
+	params.Tags = slices.Sorted(slices.Values(params.Tags))
+	if params.ServiceNamePrefix != nil {
+		prefix := strings.ToLower(*params.ServiceNamePrefix)
+		params.ServiceNamePrefix = &prefix
+	}
+
+	key, _ := json.Marshal(params)
+	return kind + string(key)
+}
+
+// lookup returns the entry under the key unless it has expired, emptying the cache first if the month has changed
+// since it was filled, as the current month bounds every period without an end date.
+func (repo *SubscriptionRepository) lookup(key string, bypass bool) (*entry, int) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := repo.now()
+	month := subscriptions.MonthStart(now)
+	if !month.Equal(repo.month) {
+		repo.entries = make(map[string]*entry)
+		repo.month = month
+		repo.generation++
+	}
+
+	entry, ok := repo.entries[key]
+	if ok && now.Sub(entry.stored) >= repo.TTL {
+		delete(repo.entries, key)
+		ok = false
+	}
+	if ok && !bypass {
+		repo.hits++
+		return entry, repo.generation
+	}
+	repo.misses++
+	return nil, repo.generation
+}
+
+// store keeps the value, also after a bypassed read to refresh the entry,
+// unless a write or a month change happened since the lookup, as it might be stale then.
+func (repo *SubscriptionRepository) store(key string, generation int, params subscriptions.SubscriptionListParams, value any) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if generation != repo.generation {
+		return
+	}
+	if len(repo.entries) >= maxEntries {
+		repo.entries = make(map[string]*entry)
+	}
+	repo.entries[key] = &entry{params: params, value: value, stored: repo.now()}
+}
+
+func cached[T any](ctx context.Context, repo *SubscriptionRepository, kind string, params subscriptions.SubscriptionListParams, load func(context.Context, subscriptions.SubscriptionListParams) (T, error)) (T, error) {
+	key := cacheKey(kind, params)
+
+	entry, generation := repo.lookup(key, subscriptions.CacheBypassed(ctx))
+	if entry != nil {
+		return entry.value.(T), nil
+	}
+
+	value, err := load(ctx, params)
+	if err != nil {
+		return value, err
+	}
+	repo.store(key, generation, params, value)
+	return value, nil
+}
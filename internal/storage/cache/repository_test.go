@@ -0,0 +1,199 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"ew/internal/models/subscriptions"
+	"ew/internal/storage/inmemory"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func prepareRepo() (*SubscriptionRepository, *inmemory.SubscriptionRepository) {
+	inner := inmemory.NewRepo([]*subscriptions.Subscription{
+		{ID: uuid.New(), ServiceName: "some item", Price: 100, StartDate: time.Now().AddDate(0, -2, 0), UserId: uuid.New()},
+		{ID: uuid.New(), ServiceName: "item 2", Price: 200, StartDate: time.Now().AddDate(0, -2, 0), UserId: uuid.New()},
+	})
+	return NewRepo(inner), inner
+}
+
+func TestCacheSubscriptionRepository_GetStats(t *testing.T) {
+	repo, inner := prepareRepo()
+	ctx := context.TODO()
+
+	first := subscriptions.SubscriptionListParams{UserIds: []uuid.UUID{inner.Items[0].UserId}}
+	second := subscriptions.SubscriptionListParams{UserIds: []uuid.UUID{inner.Items[1].UserId}}
+
+	for _, params := range []subscriptions.SubscriptionListParams{first, first, second} {
+		if _, err := repo.GetStats(ctx, params); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stats := repo.CacheStats(); stats != (subscriptions.CacheStats{Hits: 1, Misses: 2, Entries: 2}) {
+		t.Errorf("not equal %+v", stats)
+	}
+
+	// the first user's entry is dropped, the second one is still served from the cache
+	_, err := repo.Add(ctx, &subscriptions.Subscription{ServiceName: "new", Price: 50, StartDate: time.Now().AddDate(0, -2, 0), UserId: inner.Items[0].UserId})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total, _ := repo.GetStats(ctx, first)
+	if total != 450 {
+		t.Errorf("not equal %d", total)
+	}
+	if _, err = repo.GetStats(ctx, second); err != nil {
+		t.Fatal(err)
+	}
+	if stats := repo.CacheStats(); stats != (subscriptions.CacheStats{Hits: 2, Misses: 3, Entries: 2}) {
+		t.Errorf("not equal %+v", stats)
+	}
+
+	// moving a subscription to another user invalidates both of them
+	_, err = repo.Update(ctx, &subscriptions.SubscriptionPatch{ID: inner.Items[1].ID, UserId: &inner.Items[0].UserId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats := repo.CacheStats(); stats.Entries != 0 {
+		t.Errorf("not equal %+v", stats)
+	}
+
+	total, _ = repo.GetStats(ctx, second)
+	if total != 0 {
+		t.Errorf("not equal %d", total)
+	}
+}
+
+func TestCacheSubscriptionRepository_Key(t *testing.T) {
+	repo, inner := prepareRepo()
+	ctx := context.TODO()
+
+	ids := []uuid.UUID{inner.Items[0].UserId, inner.Items[1].UserId}
+	prefix, upper := "item", "ITEM"
+
+	_, _ = repo.GetList(ctx, subscriptions.SubscriptionListParams{UserIds: ids, ServiceNamePrefix: &prefix})
+	_, _ = repo.GetList(ctx, subscriptions.SubscriptionListParams{UserIds: []uuid.UUID{ids[1], ids[0]}, ServiceNamePrefix: &upper})
+	_, _ = repo.GetStats(ctx, subscriptions.SubscriptionListParams{UserIds: ids, ServiceNamePrefix: &prefix})
+
+	if stats := repo.CacheStats(); stats != (subscriptions.CacheStats{Hits: 1, Misses: 2, Entries: 2}) {
+		t.Errorf("not equal %+v", stats)
+	}
+
+	// a service outside of the prefix keeps the entries
+	_, err := repo.Delete(ctx, inner.Items[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats := repo.CacheStats(); stats.Entries != 2 {
+		t.Errorf("not equal %+v", stats)
+	}
+
+	_, _ = repo.GetStats(ctx, subscriptions.SubscriptionListParams{UserIds: ids, ServiceNamePrefix: &prefix})
+	_, _ = repo.GetStats(subscriptions.WithoutCache(ctx), subscriptions.SubscriptionListParams{UserIds: ids, ServiceNamePrefix: &prefix})
+
+	if stats := repo.CacheStats(); stats != (subscriptions.CacheStats{Hits: 2, Misses: 3, Entries: 2}) {
+		t.Errorf("not equal %+v", stats)
+	}
+}
+
+func TestCacheSubscriptionRepository_MonthBoundary(t *testing.T) {
+	repo, _ := prepareRepo()
+	ctx := context.TODO()
+
+	now := time.Date(2025, 3, 31, 23, 59, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+
+	_, _ = repo.GetStats(ctx, subscriptions.SubscriptionListParams{})
+	_, _ = repo.GetStats(ctx, subscriptions.SubscriptionListParams{})
+
+	now = now.Add(time.Minute)
+	_, _ = repo.GetStats(ctx, subscriptions.SubscriptionListParams{})
+
+	if stats := repo.CacheStats(); stats != (subscriptions.CacheStats{Hits: 1, Misses: 2, Entries: 1}) {
+		t.Errorf("not equal %+v", stats)
+	}
+}
+
+func TestCacheSubscriptionRepository_TTL(t *testing.T) {
+	repo, inner := prepareRepo()
+	ctx := context.TODO()
+
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+
+	// a write around the cache, as made by another instance, is only seen once the entry expires
+	_, _ = repo.GetStats(ctx, subscriptions.SubscriptionListParams{})
+	inner.Items[0].Price = 1000
+
+	now = now.Add(repo.TTL - time.Second)
+	if total, _ := repo.GetStats(ctx, subscriptions.SubscriptionListParams{}); total == 1200*3 {
+		t.Errorf("expected the cached total before expiry")
+	}
+	now = now.Add(time.Second)
+	if total, _ := repo.GetStats(ctx, subscriptions.SubscriptionListParams{}); total != 1200*3 {
+		t.Errorf("expected the stored total after expiry, got %d", total)
+	}
+
+	if stats := repo.CacheStats(); stats != (subscriptions.CacheStats{Hits: 1, Misses: 2, Entries: 1}) {
+		t.Errorf("not equal %+v", stats)
+	}
+}
+
+func TestCacheSubscriptionRepository_GetListCopies(t *testing.T) {
+	repo, _ := prepareRepo()
+	ctx := context.TODO()
+
+	items, _ := repo.GetList(ctx, subscriptions.SubscriptionListParams{})
+	items[0].Price = 1
+	items[0].Tags = append(items[0].Tags, "changed")
+
+	again, _ := repo.GetList(ctx, subscriptions.SubscriptionListParams{})
+	if again[0].Price == 1 || len(again[0].Tags) != 0 {
+		t.Errorf("cached subscription changed by a caller: %+v", again[0])
+	}
+}
+
+func TestCacheSubscriptionRepository_WithTx(t *testing.T) {
+	repo, inner := prepareRepo()
+	ctx := context.TODO()
+
+	params := subscriptions.SubscriptionListParams{UserIds: []uuid.UUID{inner.Items[0].UserId}}
+	price := uint(300)
+
+	_, _ = repo.GetStats(ctx, params)
+
+	err := repo.WithTx(ctx, func(tx subscriptions.SubscriptionRepo) error {
+		_, err := tx.Update(ctx, &subscriptions.SubscriptionPatch{ID: inner.Items[0].ID, Price: &price})
+		if err != nil {
+			return err
+		}
+		// the entry is kept until the transaction commits
+		if stats := repo.CacheStats(); stats.Entries != 1 {
+			t.Errorf("invalidated before commit %+v", stats)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats := repo.CacheStats(); stats.Entries != 0 {
+		t.Errorf("not invalidated after commit %+v", stats)
+	}
+
+	total, _ := repo.GetStats(ctx, params)
+	if total != 900 {
+		t.Errorf("not equal %d", total)
+	}
+
+	// a rolled back transaction leaves the cache as it is
+	_ = repo.WithTx(ctx, func(tx subscriptions.SubscriptionRepo) error {
+		_, _ = tx.Delete(ctx, inner.Items[0].ID)
+		return errors.New("failed")
+	})
+	if stats := repo.CacheStats(); stats.Entries != 1 {
+		t.Errorf("invalidated after rollback %+v", stats)
+	}
+}
@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"ew/internal/models/services"
+
+	"github.com/google/uuid"
+)
+
+// ServiceRepository wraps a ServiceRepo and flushes the subscription cache when a service is added or updated,
+// since linking it renames the subscriptions of any user, and when it is deleted, which unlinks them.
+type ServiceRepository struct {
+	services.ServiceRepo
+
+	Subscriptions *SubscriptionRepository
+}
+
+func NewServiceRepo(repo services.ServiceRepo, subs *SubscriptionRepository) *ServiceRepository {
+	return &ServiceRepository{ServiceRepo: repo, Subscriptions: subs}
+}
+
+func (repo *ServiceRepository) Add(ctx context.Context, elem *services.Service) (uuid.UUID, error) {
+	id, err := repo.ServiceRepo.Add(ctx, elem)
+	if err == nil {
+		repo.Subscriptions.Flush()
+	}
+	return id, err
+}
+
+func (repo *ServiceRepository) Update(ctx context.Context, elem *services.ServicePatch) (int64, error) {
+	affected, err := repo.ServiceRepo.Update(ctx, elem)
+	if err == nil && affected > 0 {
+		repo.Subscriptions.Flush()
+	}
+	return affected, err
+}
+
+func (repo *ServiceRepository) Delete(ctx context.Context, id uuid.UUID) (int64, error) {
+	affected, err := repo.ServiceRepo.Delete(ctx, id)
+	if err == nil && affected > 0 {
+		repo.Subscriptions.Flush()
+	}
+	return affected, err
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"ew/internal/models/services"
+	"ew/internal/models/subscriptions"
+	"ew/internal/storage/inmemory"
+	"testing"
+)
+
+func TestCacheServiceRepository_Delete(t *testing.T) {
+	subs, inner := prepareRepo()
+	repo := NewServiceRepo(inmemory.NewServiceRepo(nil, inner), subs)
+	ctx := context.TODO()
+
+	id, err := repo.Add(ctx, &services.Service{Name: "some item"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = subs.GetList(ctx, subscriptions.SubscriptionListParams{})
+	if stats := subs.CacheStats(); stats.Entries != 1 {
+		t.Fatalf("not cached %+v", stats)
+	}
+
+	// the deleted service is unlinked from the subscriptions of every user
+	affected, err := repo.Delete(ctx, id)
+	if err != nil || affected != 1 {
+		t.Fatalf("not deleted %d %v", affected, err)
+	}
+	if stats := subs.CacheStats(); stats.Entries != 0 {
+		t.Errorf("not flushed %+v", stats)
+	}
+
+	items, _ := subs.GetList(ctx, subscriptions.SubscriptionListParams{})
+	for _, item := range items {
+		if item.ServiceId != nil {
+			t.Errorf("still linked %v", item)
+		}
+	}
+
+	if _, err = repo.Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if stats := subs.CacheStats(); stats.Entries != 1 {
+		t.Errorf("flushed without a deletion %+v", stats)
+	}
+}
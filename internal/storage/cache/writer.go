@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"context"
+	"ew/internal/models/subscriptions"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// invalidator drops the cached entries a write has made stale.
+type invalidator interface {
+	invalidate(userId uuid.UUID, serviceName string)
+	Flush()
+}
+
+// writer passes the writes to the wrapped repository and reports the user and the service
+// of every subscription they touch.
+type writer struct {
+	subscriptions.SubscriptionRepo
+	stale invalidator
+}
+
+func (w *writer) Add(ctx context.Context, elem *subscriptions.Subscription) (uuid.UUID, error) {
+	id, err := w.SubscriptionRepo.Add(ctx, elem)
+	if err == nil {
+		w.stale.invalidate(elem.UserId, elem.ServiceName)
+	}
+	return id, err
+}
+
+func (w *writer) Update(ctx context.Context, elem *subscriptions.SubscriptionPatch) (int64, error) {
+	userId, serviceName, err := w.owner(ctx, elem.ID)
+	if err != nil {
+		return w.SubscriptionRepo.Update(ctx, elem)
+	}
+
+	affected, err := w.SubscriptionRepo.Update(ctx, elem)
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+
+	w.stale.invalidate(userId, serviceName)
+	if elem.UserId != nil {
+		userId = *elem.UserId
+	}
+	if elem.ServiceName != nil {
+		serviceName = *elem.ServiceName
+	}
+	w.stale.invalidate(userId, serviceName)
+
+	return affected, nil
+}
+
+func (w *writer) Delete(ctx context.Context, id uuid.UUID) (int64, error) {
+	userId, serviceName, err := w.owner(ctx, id)
+	if err != nil {
+		return w.SubscriptionRepo.Delete(ctx, id)
+	}
+
+	affected, err := w.SubscriptionRepo.Delete(ctx, id)
+	if err == nil && affected > 0 {
+		w.stale.invalidate(userId, serviceName)
+	}
+	return affected, err
+}
+
+func (w *writer) Pause(ctx context.Context, id uuid.UUID, start time.Time) error {
+	userId, serviceName, err := w.owner(ctx, id)
+	if err != nil {
+		return w.SubscriptionRepo.Pause(ctx, id, start)
+	}
+
+	err = w.SubscriptionRepo.Pause(ctx, id, start)
+	if err == nil {
+		w.stale.invalidate(userId, serviceName)
+	}
+	return err
+}
+
+func (w *writer) Resume(ctx context.Context, id uuid.UUID, resume time.Time) error {
+	userId, serviceName, err := w.owner(ctx, id)
+	if err != nil {
+		return w.SubscriptionRepo.Resume(ctx, id, resume)
+	}
+
+	err = w.SubscriptionRepo.Resume(ctx, id, resume)
+	if err == nil {
+		w.stale.invalidate(userId, serviceName)
+	}
+	return err
+}
+
+// Batch flushes the whole cache instead of looking up the owner of every operation,
+// a batch usually touches many users at once.
+func (w *writer) Batch(ctx context.Context, ops []subscriptions.BatchOperation, atomic bool) ([]subscriptions.BatchResult, error) {
+	results, err := w.SubscriptionRepo.Batch(ctx, ops, atomic)
+	if err == nil {
+		w.stale.Flush()
+	}
+	return results, err
+}
+
+// owner reads the user and the service of the subscription before a write,
+// the values are copied since the wrapped repository may update the subscription in place.
+func (w *writer) owner(ctx context.Context, id uuid.UUID) (uuid.UUID, string, error) {
+	subscription, err := w.SubscriptionRepo.GetByID(ctx, id)
+	if err != nil {
+		return uuid.UUID{}, "", err
+	}
+	return subscription.UserId, subscription.ServiceName, nil
+}
+
+// WithTx hands fn a writer that collects what the transaction touches,
+// the entries are only invalidated once it commits. Reads inside the transaction skip the cache.
+func (w *writer) WithTx(ctx context.Context, fn func(subscriptions.SubscriptionRepo) error) error {
+	touched := &pending{}
+	err := w.SubscriptionRepo.WithTx(ctx, func(tx subscriptions.SubscriptionRepo) error {
+		return fn(&writer{SubscriptionRepo: tx, stale: touched})
+	})
+	if err == nil {
+		touched.apply(w.stale)
+	}
+	return err
+}
+
+type ownerKey struct {
+	userId      uuid.UUID
+	serviceName string
+}
+
+// pending is the invalidator of a transaction.
+type pending struct {
+	owners []ownerKey
+	flush  bool
+}
+
+func (p *pending) invalidate(userId uuid.UUID, serviceName string) {
+	p.owners = append(p.owners, ownerKey{userId: userId, serviceName: serviceName})
+}
+
+func (p *pending) Flush() {
+	p.flush = true
+}
+
+func (p *pending) apply(stale invalidator) {
+	if p.flush {
+		stale.Flush()
+		return
+	}
+	for _, o := range p.owners {
+		stale.invalidate(o.userId, o.serviceName)
+	}
+}
@@ -0,0 +1,10 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/models/subscriptions"
+)
+
+func (repo *SubscriptionRepository) Batch(ctx context.Context, ops []subscriptions.BatchOperation, atomic bool) ([]subscriptions.BatchResult, error) {
+	return subscriptions.RunBatch(ctx, repo, ops, atomic)
+}
@@ -0,0 +1,51 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/database"
+	"ew/internal/models/subscriptions"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestInMemorySubscriptionRepository_Batch(t *testing.T) {
+	repo := prepareRepo()
+	count := len(repo.Items)
+	price := uint(999)
+	tags := database.TextArray{"changed"}
+	missing := uuid.New()
+
+	ops := []subscriptions.BatchOperation{
+		{Create: &subscriptions.Subscription{ServiceName: "batch", Price: 100, UserId: uuid.New(), StartDate: time.Now()}},
+		{Update: &subscriptions.SubscriptionPatch{ID: id1, Price: &price, Tags: &tags}},
+		{Delete: &missing},
+		{Delete: &id2},
+	}
+
+	results, err := repo.Batch(context.TODO(), ops, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, result := range results {
+		expected := subscriptions.RolledBack
+		if i == 2 {
+			expected = subscriptions.NotFound
+		}
+		if result.Err != expected {
+			t.Errorf("operation %d: expected %v, got %v", i, expected, result.Err)
+		}
+	}
+	if len(repo.Items) != count || repo.Items[0].Price == price || repo.Items[0].Tags != nil {
+		t.Errorf("atomic batch not rolled back: %d items, %v", len(repo.Items), repo.Items[0])
+	}
+
+	results, _ = repo.Batch(context.TODO(), ops, false)
+	if results[0].Err != nil || results[1].Err != nil || results[2].Err != subscriptions.NotFound || results[3].Err != nil {
+		t.Errorf("invalid best-effort results: %v", results)
+	}
+	if len(repo.Items) != count || repo.Items[0].Price != price {
+		t.Errorf("best-effort batch not applied: %d items, %v", len(repo.Items), repo.Items[0])
+	}
+}
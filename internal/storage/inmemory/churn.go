@@ -0,0 +1,71 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/models/subscriptions"
+	"slices"
+	"strings"
+	"time"
+)
+
+func (repo *SubscriptionRepository) GetChurn(_ context.Context, params subscriptions.ChurnParams) ([]*subscriptions.ServiceChurn, error) {
+	filters := params.Filter
+	afterEnd := params.EndDate.AddDate(0, 1, 0)
+	filters.StartDate, filters.EndDate = &params.StartDate, &afterEnd
+
+	now := subscriptions.MonthStart(time.Now())
+	res := make([]*subscriptions.ServiceChurn, 0)
+	byKey := make(map[string]*subscriptions.ServiceChurn)
+	lifetimes := make(map[string][]int)
+
+	for _, item := range repo.Items {
+		if !matches(item, filters, now) {
+			continue
+		}
+
+		key := item.ServiceKey()
+		churn, ok := byKey[key]
+		if !ok {
+			churn = &subscriptions.ServiceChurn{Key: key, Name: item.ServiceName}
+			for month := params.StartDate; !month.After(params.EndDate); month = month.AddDate(0, 1, 0) {
+				churn.Months = append(churn.Months, &subscriptions.ChurnMonth{Month: month})
+			}
+			byKey[key] = churn
+			res = append(res, churn)
+		}
+		churn.Name = min(churn.Name, item.ServiceName)
+		lifetimes[key] = append(lifetimes[key], item.Lifetime(now))
+
+		for _, month := range churn.Months {
+			started := subscriptions.MonthsBetween(item.StartDate, month.Month)
+			if started == 0 {
+				month.New++
+				month.NewMRR += int(item.Price)
+			}
+			if item.EndDate != nil && subscriptions.MonthsBetween(*item.EndDate, month.Month) == 0 {
+				month.Cancelled++
+				month.CancelledMRR += int(item.Price)
+			}
+			if started > 0 && (item.EndDate == nil || subscriptions.MonthsBetween(month.Month, *item.EndDate) >= 0) {
+				month.Active++
+			}
+		}
+	}
+
+	for _, churn := range res {
+		total := 0
+		for _, lifetime := range lifetimes[churn.Key] {
+			total += lifetime
+		}
+		churn.AverageLifetime = float64(total) / float64(len(lifetimes[churn.Key]))
+	}
+
+	slices.SortStableFunc(res, func(a, b *subscriptions.ServiceChurn) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return res, nil
+}
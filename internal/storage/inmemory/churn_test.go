@@ -0,0 +1,51 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/models/subscriptions"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInMemorySubscriptionRepository_GetChurn(t *testing.T) {
+	repo := prepareRepo()
+
+	end := subscriptions.MonthStart(time.Now())
+	start := end.AddDate(0, -2, 0)
+
+	churn, err := repo.GetChurn(context.TODO(), subscriptions.ChurnParams{StartDate: start, EndDate: end})
+	if err != nil {
+		t.Error(err)
+	}
+
+	names := make([]string, 0, len(churn))
+	for _, service := range churn {
+		names = append(names, service.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"item 2", "nothing", "some item", "variable"}) {
+		t.Errorf("not equal %v", names)
+	}
+
+	expected := &subscriptions.ServiceChurn{
+		Key:             "nothing",
+		Name:            "nothing",
+		AverageLifetime: 2,
+		Months: []*subscriptions.ChurnMonth{
+			{Month: start},
+			{Month: start.AddDate(0, 1, 0), New: 1, NewMRR: 500},
+			{Month: end, Cancelled: 1, Active: 1, CancelledMRR: 500},
+		},
+	}
+	if !reflect.DeepEqual(churn[1], expected) {
+		t.Errorf("not equal %+v", churn[1])
+	}
+
+	if churn[1].Months[2].ChurnRate() != 1 || churn[1].Months[2].NetMRR() != -500 {
+		t.Errorf("incorrect metrics %+v", churn[1].Months[2])
+	}
+
+	if churn[2].AverageLifetime != 18 || churn[2].Months[0].Active != 1 {
+		t.Errorf("not equal %+v", churn[2])
+	}
+}
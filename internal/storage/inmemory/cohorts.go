@@ -0,0 +1,50 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/models/subscriptions"
+	"slices"
+	"time"
+)
+
+func (repo *SubscriptionRepository) GetCohorts(_ context.Context, params subscriptions.CohortParams) ([]*subscriptions.Cohort, error) {
+	filters := params.Filter
+	afterEnd := params.EndDate.AddDate(0, 1, 0)
+	filters.StartDate, filters.EndDate = nil, &afterEnd
+
+	now := subscriptions.MonthStart(time.Now())
+	res := make([]*subscriptions.Cohort, 0)
+	byMonth := make(map[time.Time]*subscriptions.Cohort)
+
+	for _, item := range repo.Items {
+		if subscriptions.MonthsBetween(params.StartDate, item.StartDate) < 0 || !matches(item, filters, now) {
+			continue
+		}
+
+		age := subscriptions.MonthsBetween(item.StartDate, now)
+		if age < 0 {
+			continue
+		}
+
+		month := subscriptions.MonthStart(item.StartDate)
+		cohort, ok := byMonth[month]
+		if !ok {
+			cohort = &subscriptions.Cohort{Month: month, Active: make([]int, age+1)}
+			byMonth[month] = cohort
+			res = append(res, cohort)
+		}
+		cohort.Size++
+
+		for i := range cohort.Active {
+			if item.EndDate == nil || subscriptions.MonthsBetween(month.AddDate(0, i, 0), *item.EndDate) >= 0 {
+				cohort.Active[i]++
+			}
+		}
+	}
+
+	slices.SortFunc(res, func(a, b *subscriptions.Cohort) int {
+		return a.Month.Compare(b.Month)
+	})
+
+	return res, nil
+}
@@ -0,0 +1,45 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/models/subscriptions"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInMemorySubscriptionRepository_GetCohorts(t *testing.T) {
+	repo := prepareRepo()
+
+	month := subscriptions.MonthStart(time.Now())
+	repo.Items[1].StartDate = month.AddDate(0, -1, 0)
+	repo.Items[2].EndDate = &repo.Items[2].StartDate
+
+	cohorts, err := repo.GetCohorts(context.TODO(), subscriptions.CohortParams{StartDate: month.AddDate(0, -2, 0), EndDate: month})
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := []*subscriptions.Cohort{
+		{Month: month.AddDate(0, -1, 0), Size: 2, Active: []int{2, 1}},
+	}
+	if !reflect.DeepEqual(cohorts, expected) {
+		t.Errorf("not equal %v", cohorts)
+	}
+
+	cohorts, err = repo.GetCohorts(context.TODO(), subscriptions.CohortParams{
+		StartDate: month.AddDate(0, -3, 0),
+		EndDate:   month.AddDate(0, -3, 0),
+		Filter:    subscriptions.SubscriptionListParams{ServiceNames: []string{"variable"}},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected = []*subscriptions.Cohort{
+		{Month: month.AddDate(0, -3, 0), Size: 1, Active: []int{1, 1, 1, 1}},
+	}
+	if !reflect.DeepEqual(cohorts, expected) {
+		t.Errorf("not equal %v", cohorts)
+	}
+}
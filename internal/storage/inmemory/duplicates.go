@@ -0,0 +1,86 @@
+package inmemory
+
+import (
+	"bytes"
+	"context"
+	"ew/internal/models/subscriptions"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (repo *SubscriptionRepository) GetDuplicates(_ context.Context, userIds []uuid.UUID) ([]*subscriptions.Duplicate, error) {
+	month := subscriptions.MonthStart(time.Now())
+	res := make([]*subscriptions.Duplicate, 0)
+
+	for _, first := range repo.Items {
+		if len(userIds) > 0 && !slices.Contains(userIds, first.UserId) {
+			continue
+		}
+
+		for _, second := range repo.Items {
+			if bytes.Compare(first.ID[:], second.ID[:]) >= 0 || !first.Overlaps(second) {
+				continue
+			}
+
+			duplicate := &subscriptions.Duplicate{
+				UserId:       first.UserId,
+				ServiceName:  first.ServiceName,
+				FirstId:      first.ID,
+				SecondId:     second.ID,
+				OverlapStart: subscriptions.MonthStart(first.StartDate),
+			}
+			if first.StartDate.Before(second.StartDate) {
+				duplicate.OverlapStart = subscriptions.MonthStart(second.StartDate)
+			}
+
+			// the last common month is the one before the earlier end, which is not shared
+			for _, end := range []*time.Time{first.EndDate, second.EndDate} {
+				if end == nil {
+					continue
+				}
+				if last := subscriptions.MonthStart(*end).AddDate(0, -1, 0); duplicate.OverlapEnd == nil || last.Before(*duplicate.OverlapEnd) {
+					duplicate.OverlapEnd = &last
+				}
+			}
+
+			last := month
+			if duplicate.OverlapEnd != nil && duplicate.OverlapEnd.Before(last) {
+				last = *duplicate.OverlapEnd
+			}
+			for m := duplicate.OverlapStart; !m.After(last); m = m.AddDate(0, 1, 0) {
+				if first.ChargedIn(m) && second.ChargedIn(m) {
+					duplicate.WastedPrice += int(min(first.Price, second.Price))
+				}
+			}
+
+			res = append(res, duplicate)
+		}
+	}
+
+	slices.SortStableFunc(res, func(a, b *subscriptions.Duplicate) int {
+		if c := bytes.Compare(a.UserId[:], b.UserId[:]); c != 0 {
+			return c
+		}
+		if c := a.OverlapStart.Compare(b.OverlapStart); c != 0 {
+			return c
+		}
+		if c := bytes.Compare(a.FirstId[:], b.FirstId[:]); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.SecondId[:], b.SecondId[:])
+	})
+
+	return res, nil
+}
+
+func (repo *SubscriptionRepository) FindOverlapping(_ context.Context, elem *subscriptions.Subscription) ([]*subscriptions.Subscription, error) {
+	res := make([]*subscriptions.Subscription, 0)
+	for _, item := range repo.Items {
+		if item.ID != elem.ID && elem.Overlaps(item) {
+			res = append(res, item)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,94 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/models/subscriptions"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestInMemorySubscriptionRepository_GetDuplicates(t *testing.T) {
+	repo := prepareRepo()
+
+	repo.Items[1].UserId = repo.Items[0].UserId
+	repo.Items[1].ServiceName = " Some Item"
+
+	duplicates, err := repo.GetDuplicates(context.TODO(), []uuid.UUID{repo.Items[0].UserId})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(duplicates) != 1 {
+		t.Fatalf("not equal %v", duplicates)
+	}
+
+	first, second := id1, id2
+	if first.String() > second.String() {
+		first, second = second, first
+	}
+	expected := &subscriptions.Duplicate{
+		UserId:       repo.Items[0].UserId,
+		ServiceName:  duplicates[0].ServiceName,
+		FirstId:      first,
+		SecondId:     second,
+		OverlapStart: subscriptions.MonthStart(repo.Items[1].StartDate),
+		WastedPrice:  3 * 125,
+	}
+	if !reflect.DeepEqual(duplicates[0], expected) {
+		t.Errorf("not equal %+v", duplicates[0])
+	}
+
+	duplicates, err = repo.GetDuplicates(context.TODO(), []uuid.UUID{repo.Items[3].UserId})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(duplicates) != 0 {
+		t.Errorf("not equal %v", duplicates)
+	}
+
+	end := time.Now().AddDate(0, -3, 0)
+	overlapping, err := repo.FindOverlapping(context.TODO(), &subscriptions.Subscription{
+		ServiceName: "some item",
+		UserId:      repo.Items[0].UserId,
+		StartDate:   time.Now().AddDate(0, -5, 0),
+		EndDate:     &end,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(overlapping, []*subscriptions.Subscription{repo.Items[0]}) {
+		t.Errorf("not equal %v", overlapping)
+	}
+}
+
+func TestInMemorySubscriptionRepository_GetDuplicatesUpgrade(t *testing.T) {
+	userId := uuid.New()
+	upgrade := subscriptions.MonthStart(time.Now()).AddDate(0, -2, 0)
+	later := upgrade.AddDate(0, 1, 0)
+	repo := NewRepo([]*subscriptions.Subscription{
+		{ID: id1, ServiceName: "music", Price: 100, StartDate: upgrade.AddDate(0, -6, 0), EndDate: &upgrade, UserId: userId},
+		{ID: id2, ServiceName: "music", Price: 200, StartDate: upgrade, UserId: userId},
+	})
+
+	// the old plan ends in the month the new one starts in, they run back to back
+	duplicates, err := repo.GetDuplicates(context.TODO(), nil)
+	if err != nil || len(duplicates) != 0 {
+		t.Errorf("expected no duplicates of an upgrade, got %v %v", duplicates, err)
+	}
+	overlapping, err := repo.FindOverlapping(context.TODO(), repo.Items[1])
+	if err != nil || len(overlapping) != 0 {
+		t.Errorf("expected no overlapping subscriptions of an upgrade, got %v %v", overlapping, err)
+	}
+
+	// ending a month later shares the month of the upgrade
+	repo.Items[0].EndDate = &later
+	duplicates, err = repo.GetDuplicates(context.TODO(), nil)
+	if err != nil || len(duplicates) != 1 || !duplicates[0].OverlapEnd.Equal(upgrade) || duplicates[0].WastedPrice != 100 {
+		t.Errorf("expected a single common month, got %v %v", duplicates, err)
+	}
+}
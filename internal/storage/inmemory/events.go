@@ -0,0 +1,51 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/models/events"
+	"slices"
+	"sync"
+)
+
+type EventRepository struct {
+	*events.Hub
+
+	mu   sync.Mutex
+	size int
+	log  []events.Event
+	last int64
+}
+
+// NewEventRepo keeps the latest size events for Since.
+func NewEventRepo(size int) *EventRepository {
+	return &EventRepository{Hub: events.NewHub(), size: size}
+}
+
+func (repo *EventRepository) Publish(_ context.Context, event *events.Event) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.last++
+	event.ID = repo.last
+	repo.log = append(repo.log, *event)
+	if len(repo.log) > repo.size {
+		repo.log = slices.Delete(repo.log, 0, len(repo.log)-repo.size)
+	}
+
+	// broadcasting under the lock keeps the order of the ids
+	repo.Broadcast(*event)
+	return nil
+}
+
+func (repo *EventRepository) Since(_ context.Context, id int64) ([]events.Event, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if id >= repo.last {
+		return nil, nil
+	}
+	if len(repo.log) == 0 || repo.log[0].ID > id+1 {
+		return nil, events.Expired
+	}
+	return slices.Clone(repo.log[id+1-repo.log[0].ID:]), nil
+}
@@ -0,0 +1,66 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/models/events"
+	"testing"
+)
+
+func TestInMemoryEventRepository(t *testing.T) {
+	repo := NewEventRepo(3)
+	live, cancel := repo.Subscribe()
+	defer cancel()
+
+	for range 5 {
+		if err := repo.Publish(context.TODO(), &events.Event{Type: events.Created}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for id := int64(1); id <= 5; id++ {
+		if event := <-live; event.ID != id {
+			t.Errorf("expected event %d, got %d", id, event.ID)
+		}
+	}
+
+	// the log keeps the last three events
+	items, err := repo.Since(context.TODO(), 3)
+	if err != nil || len(items) != 2 || items[0].ID != 4 || items[1].ID != 5 {
+		t.Errorf("unexpected events after 3: %v %v", items, err)
+	}
+	items, err = repo.Since(context.TODO(), 2)
+	if err != nil || len(items) != 3 {
+		t.Errorf("unexpected events after 2: %v %v", items, err)
+	}
+	if _, err = repo.Since(context.TODO(), 1); err != events.Expired {
+		t.Errorf("expected expired events after 1, got %v", err)
+	}
+	if items, err = repo.Since(context.TODO(), 5); err != nil || len(items) != 0 {
+		t.Errorf("unexpected events after the last one: %v %v", items, err)
+	}
+}
+
+func TestInMemoryEventRepository_SlowSubscriber(t *testing.T) {
+	repo := NewEventRepo(events.DefaultLogSize)
+	live, cancel := repo.Subscribe()
+	defer cancel()
+
+	// the publisher does not wait for a subscriber that stopped reading
+	for range 100 {
+		_ = repo.Publish(context.TODO(), &events.Event{Type: events.Updated})
+	}
+
+	received := 0
+	for range live {
+		received++
+	}
+	if received == 0 || received == 100 {
+		t.Errorf("expected the subscriber dropped after a part of the events, received %d", received)
+	}
+
+	repo.Close()
+	closed, _ := repo.Subscribe()
+	if _, ok := <-closed; ok {
+		t.Error("expected a closed channel after Close")
+	}
+}
@@ -0,0 +1,68 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/models/idempotency"
+	"sync"
+	"time"
+)
+
+type IdempotencyRepository struct {
+	mu      sync.Mutex
+	records map[string]*idempotency.Record
+	now     func() time.Time
+}
+
+func NewIdempotencyRepo() *IdempotencyRepository {
+	return &IdempotencyRepository{records: make(map[string]*idempotency.Record), now: time.Now}
+}
+
+func (repo *IdempotencyRepository) Reserve(_ context.Context, key, requestHash string, lease time.Duration) (*idempotency.Record, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := repo.now()
+	if record, found := repo.records[key]; found && record.ExpiresAt.After(now) {
+		res := *record
+		return idempotency.Check(&res, requestHash)
+	}
+
+	repo.records[key] = &idempotency.Record{Key: key, RequestHash: requestHash, ExpiresAt: now.Add(lease)}
+	return nil, nil
+}
+
+func (repo *IdempotencyRepository) Complete(_ context.Context, key string, response []byte, ttl time.Duration) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if record, found := repo.records[key]; found {
+		record.Response = response
+		record.ExpiresAt = repo.now().Add(ttl)
+	}
+	return nil
+}
+
+func (repo *IdempotencyRepository) Release(_ context.Context, key string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if record, found := repo.records[key]; found && record.Response == nil {
+		delete(repo.records, key)
+	}
+	return nil
+}
+
+func (repo *IdempotencyRepository) Purge(_ context.Context) (int64, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := repo.now()
+	var purged int64
+	for key, record := range repo.records {
+		if !record.ExpiresAt.After(now) {
+			delete(repo.records, key)
+			purged++
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,65 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/models/idempotency"
+	"testing"
+	"time"
+)
+
+func TestInMemoryIdempotencyRepository(t *testing.T) {
+	repo := NewIdempotencyRepo()
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+
+	record, err := repo.Reserve(context.TODO(), "key", "hash", time.Minute)
+	if record != nil || err != nil {
+		t.Fatalf("expected a free key, got %v %v", record, err)
+	}
+
+	if _, err = repo.Reserve(context.TODO(), "key", "hash", time.Minute); err != idempotency.InProgress {
+		t.Errorf("expected in progress, got %v", err)
+	}
+
+	// the response is kept for the replay TTL rather than the lease of the reservation
+	_ = repo.Complete(context.TODO(), "key", []byte(`{"subscription_id":"id"}`), time.Hour)
+	now = now.Add(time.Minute)
+
+	record, err = repo.Reserve(context.TODO(), "key", "hash", time.Minute)
+	if err != nil || record == nil || string(record.Response) != `{"subscription_id":"id"}` {
+		t.Errorf("expected the stored response, got %v %v", record, err)
+	}
+	if _, err = repo.Reserve(context.TODO(), "key", "other", time.Minute); err != idempotency.Mismatch {
+		t.Errorf("expected mismatch, got %v", err)
+	}
+
+	// a completed key is not released
+	_ = repo.Release(context.TODO(), "key")
+	if _, err = repo.Reserve(context.TODO(), "key", "other", time.Minute); err != idempotency.Mismatch {
+		t.Errorf("expected mismatch after release, got %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	record, err = repo.Reserve(context.TODO(), "key", "other", time.Minute)
+	if record != nil || err != nil {
+		t.Errorf("expected the expired key to be reserved again, got %v %v", record, err)
+	}
+
+	_, _ = repo.Reserve(context.TODO(), "failed", "hash", time.Minute)
+	_ = repo.Release(context.TODO(), "failed")
+	if record, err = repo.Reserve(context.TODO(), "failed", "other", time.Minute); record != nil || err != nil {
+		t.Errorf("expected the released key to be free, got %v %v", record, err)
+	}
+
+	// a request outliving its lease lets a retry take the key over
+	_, _ = repo.Reserve(context.TODO(), "slow", "hash", time.Minute)
+	now = now.Add(time.Minute)
+	if record, err = repo.Reserve(context.TODO(), "slow", "hash", time.Minute); record != nil || err != nil {
+		t.Errorf("expected the key of the expired lease to be free, got %v %v", record, err)
+	}
+
+	now = now.Add(time.Hour)
+	if purged, _ := repo.Purge(context.TODO()); purged != 3 {
+		t.Errorf("expected 3 purged keys, got %d", purged)
+	}
+}
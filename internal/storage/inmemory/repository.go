@@ -2,9 +2,11 @@ package inmemory
 
 import (
 	"context"
+	"ew/internal/models/events"
 	"ew/internal/models/subscriptions"
-	"math"
+	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,57 +14,259 @@ import (
 
 type SubscriptionRepository struct {
 	Items []*subscriptions.Subscription
+	// Services is the catalog the written subscriptions are linked to, set by NewServiceRepo
+	Services *ServiceRepository
+	// Events receives the events of the writes, none are recorded without it
+	Events *EventRepository
+
+	// outbox collects the events of the transaction WithTx runs, they are published once it succeeds
+	outbox *[]events.Event
+}
+
+// record publishes the event of a write, or keeps it until the transaction the write runs in succeeds.
+func (repo *SubscriptionRepository) record(typ events.Type, item *subscriptions.Subscription) {
+	if repo.Events == nil {
+		return
+	}
+
+	event := events.New(typ, item)
+	if repo.outbox != nil {
+		*repo.outbox = append(*repo.outbox, event)
+		return
+	}
+	_ = repo.Events.Publish(context.Background(), &event)
 }
 
 func (repo *SubscriptionRepository) GetStats(ctx context.Context, params subscriptions.SubscriptionListParams) (int, error) {
-	items, err := repo.GetList(ctx, subscriptions.SubscriptionListParams{UserId: params.UserId, ServiceName: params.ServiceName})
+	items, err := repo.statsItems(ctx, params)
 	if err != nil {
 		return 0, err
 	}
 
 	total := 0
+	for _, item := range items {
+		total += cost(item, params)
+	}
+	return total, nil
+}
+
+func (repo *SubscriptionRepository) GetGroupedStats(ctx context.Context, params subscriptions.SubscriptionListParams, groupBy subscriptions.GroupBy) ([]*subscriptions.StatsGroup, error) {
+	if groupBy != subscriptions.GroupByService && groupBy != subscriptions.GroupByCategory {
+		return nil, fmt.Errorf("unsupported stats grouping %q", groupBy)
+	}
+
+	items, err := repo.statsItems(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*subscriptions.StatsGroup, 0)
+	byKey := make(map[string]*subscriptions.StatsGroup)
+
+	for _, item := range items {
+		var key, name string
+
+		switch groupBy {
+		case subscriptions.GroupByService:
+			key, name = item.ServiceKey(), item.ServiceName
+		case subscriptions.GroupByCategory:
+			if item.Category != nil {
+				key, name = *item.Category, *item.Category
+			}
+		}
+
+		group, ok := byKey[key]
+		if !ok {
+			group = &subscriptions.StatsGroup{Key: key, Name: name}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.Name = min(group.Name, name)
+		group.TotalPrice += cost(item, params)
+	}
+
+	slices.SortStableFunc(groups, func(a, b *subscriptions.StatsGroup) int {
+		if a.TotalPrice != b.TotalPrice {
+			return b.TotalPrice - a.TotalPrice
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return groups, nil
+}
+
+func (repo *SubscriptionRepository) GetTop(ctx context.Context, params subscriptions.TopParams) ([]*subscriptions.TopItem, error) {
+	items, err := repo.GetList(ctx, params.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	runRateMonth := params.RunRateMonth()
+	res := make([]*subscriptions.TopItem, 0)
+	byKey := make(map[string]*subscriptions.TopItem)
 
 	for _, item := range items {
-		var (
-			startYear, endYear   int
-			startMonth, endMonth time.Month
-			start, end           time.Time
-		)
+		var key, name string
+
+		switch params.Dimension {
+		case subscriptions.DimensionServiceName:
+			key, name = item.ServiceKey(), item.ServiceName
+		case subscriptions.DimensionUserId:
+			key, name = item.UserId.String(), item.UserId.String()
+		case subscriptions.DimensionCategory:
+			if item.Category != nil {
+				key, name = *item.Category, *item.Category
+			}
+		default:
+			return nil, fmt.Errorf("unsupported top dimension %q", params.Dimension)
+		}
 
-		if params.StartDate != nil {
-			minUnix := math.Max(float64(params.StartDate.Unix()), float64(item.StartDate.Unix()))
-			start = time.Unix(int64(minUnix), 0)
-		} else {
-			start = item.StartDate
+		top, ok := byKey[key]
+		if !ok {
+			top = &subscriptions.TopItem{Key: key, Name: name}
+			byKey[key] = top
+			res = append(res, top)
 		}
+		top.Name = min(top.Name, name)
 
-		if item.EndDate != nil {
-			end = *item.EndDate
-		} else {
-			end = time.Now()
+		switch params.Metric {
+		case subscriptions.MetricTotal:
+			top.Value += cost(item, params.Filter)
+		case subscriptions.MetricRunRate:
+			if item.ChargedIn(runRateMonth) {
+				top.Value += int(item.Price)
+			}
+		case subscriptions.MetricCount:
+			top.Value++
+		default:
+			return nil, fmt.Errorf("unsupported top metric %q", params.Metric)
 		}
+	}
 
-		if params.EndDate != nil {
-			minUnix := math.Min(float64(params.EndDate.Unix()), float64(end.Unix()))
-			end = time.Unix(int64(minUnix), 0)
+	res = slices.DeleteFunc(res, func(top *subscriptions.TopItem) bool {
+		return top.Value <= 0
+	})
+
+	slices.SortStableFunc(res, func(a, b *subscriptions.TopItem) int {
+		if a.Value != b.Value {
+			return b.Value - a.Value
 		}
+		return strings.Compare(a.Name, b.Name)
+	})
 
-		if start.After(end) {
-			start = end.AddDate(0, 1, 0)
+	for i, top := range res {
+		top.Rank = i + 1
+		if i > 0 && top.Value == res[i-1].Value {
+			top.Rank = res[i-1].Rank
 		}
+	}
 
-		startYear, startMonth, _ = start.Date()
-		endYear, endMonth, _ = end.Date()
+	return res[:min(params.Limit, len(res))], nil
+}
+
+func (repo *SubscriptionRepository) GetForecast(_ context.Context, params subscriptions.ForecastParams) ([]*subscriptions.MonthTotal, error) {
+	filters := params.Filter
+	afterEnd := params.EndDate.AddDate(0, 1, 0)
+	filters.StartDate, filters.EndDate = &params.StartDate, &afterEnd
 
-		diff := int(endMonth-startMonth+1) + 12*(endYear-startYear)
+	now := subscriptions.MonthStart(time.Now())
+	items := slices.DeleteFunc(slices.Clone(repo.Items), func(item *subscriptions.Subscription) bool {
+		return slices.Contains(params.Cancel, item.ID) || !matches(item, filters, now)
+	})
 
-		total += int(item.Price) * diff
+	res := make([]*subscriptions.MonthTotal, 0)
+	for month := params.StartDate; !month.After(params.EndDate); month = month.AddDate(0, 1, 0) {
+		total := 0
+		for _, item := range items {
+			if item.ChargedIn(month) {
+				total += int(item.Price)
+			}
+		}
+		res = append(res, &subscriptions.MonthTotal{Month: month, TotalPrice: params.ApplyPriceChange(total)})
 	}
-	return total, nil
+	return res, nil
+}
+
+func (repo *SubscriptionRepository) GetUserSummary(_ context.Context, userId uuid.UUID) (*subscriptions.UserSummary, error) {
+	month := subscriptions.MonthStart(time.Now())
+	summary := &subscriptions.UserSummary{}
+
+	for _, item := range repo.Items {
+		if item.UserId != userId {
+			continue
+		}
+
+		for i := subscriptions.MonthsBetween(item.StartDate, month); i >= 0; i-- {
+			if !item.ChargedIn(month.AddDate(0, -i, 0)) {
+				continue
+			}
+			summary.LifetimeSpend += int(item.Price)
+			if i < 12 {
+				summary.LastYearSpend += int(item.Price)
+			}
+			if i == 0 {
+				summary.MonthlyRunRate += int(item.Price)
+			}
+		}
+
+		if item.EndDate != nil && !item.EndDate.Before(month) &&
+			(summary.NextEndDate == nil || item.EndDate.Before(*summary.NextEndDate)) {
+			summary.NextEndDate = item.EndDate
+		}
+
+		if item.StartDate.After(month) || (item.EndDate != nil && item.EndDate.Before(month)) {
+			continue
+		}
+		summary.ActiveCount++
+
+		if summary.TopServicePrice == nil || item.Price > *summary.TopServicePrice ||
+			(item.Price == *summary.TopServicePrice && item.ServiceName < *summary.TopServiceName) {
+			summary.TopServiceName, summary.TopServicePrice = &item.ServiceName, &item.Price
+		}
+	}
+	return summary, nil
+}
+
+func (repo *SubscriptionRepository) GetUserSummaries(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID]*subscriptions.UserSummary, error) {
+	res := make(map[uuid.UUID]*subscriptions.UserSummary, len(userIds))
+	for _, userId := range userIds {
+		res[userId], _ = repo.GetUserSummary(ctx, userId)
+	}
+	return res, nil
+}
+
+func (repo *SubscriptionRepository) statsItems(ctx context.Context, params subscriptions.SubscriptionListParams) ([]*subscriptions.Subscription, error) {
+	filters := params
+	filters.StartDate, filters.EndDate = nil, nil
+
+	return repo.GetList(ctx, filters)
+}
+
+func cost(item *subscriptions.Subscription, params subscriptions.SubscriptionListParams) int {
+	from := subscriptions.MonthStart(item.StartDate)
+	if params.StartDate != nil && subscriptions.MonthsBetween(from, *params.StartDate) > 0 {
+		from = subscriptions.MonthStart(*params.StartDate)
+	}
+
+	to := time.Now()
+	if item.EndDate != nil {
+		to = *item.EndDate
+	}
+	if params.EndDate != nil && subscriptions.MonthsBetween(*params.EndDate, to) > 0 {
+		to = *params.EndDate
+	}
+
+	total := 0
+	for i := 0; i <= subscriptions.MonthsBetween(from, to); i++ {
+		if item.ChargedIn(from.AddDate(0, i, 0)) {
+			total += int(item.Price)
+		}
+	}
+	return total
 }
 
 func NewRepo(items []*subscriptions.Subscription) *SubscriptionRepository {
-	return &SubscriptionRepository{items}
+	return &SubscriptionRepository{Items: items}
 }
 
 func (repo *SubscriptionRepository) GetList(_ context.Context, params subscriptions.SubscriptionListParams) ([]*subscriptions.Subscription, error) {
@@ -74,46 +278,90 @@ func (repo *SubscriptionRepository) GetList(_ context.Context, params subscripti
 
 	copy(items, repo.Items)
 
+	month := subscriptions.MonthStart(time.Now())
+
+	// status is already relative to the current month, so it replaces the default period bound
+	if params.EndDate == nil && params.Status == nil {
+		params.EndDate = &month
+	}
+
+	items = slices.DeleteFunc(items, func(item *subscriptions.Subscription) bool {
+		return !matches(item, params, month)
+	})
+
 	if params.Offset != nil {
-		items = items[*params.Offset:]
+		items = items[min(*params.Offset, len(items)):]
 	}
 
 	if params.Limit != nil {
-		items = items[:*params.Limit]
+		items = items[:min(*params.Limit, len(items))]
 	}
 
-	if params.UserId != nil {
-		items = slices.DeleteFunc(items, func(item *subscriptions.Subscription) bool {
-			return item.UserId != *params.UserId
-		})
+	return items, nil
+}
+
+func matches(item *subscriptions.Subscription, params subscriptions.SubscriptionListParams, month time.Time) bool {
+	if len(params.UserIds) > 0 && !slices.Contains(params.UserIds, item.UserId) {
+		return false
 	}
 
-	if params.ServiceName != nil {
-		items = slices.DeleteFunc(items, func(item *subscriptions.Subscription) bool {
-			return item.ServiceName != *params.ServiceName
-		})
+	if len(params.ServiceNames) > 0 && !slices.Contains(params.ServiceNames, item.ServiceName) {
+		return false
 	}
 
-	if params.EndDate == nil {
-		now := time.Now()
-		y, m, _ := now.Date()
-		end := time.Date(y, m, 1, 0, 0, 0, 0, now.Location())
-		params.EndDate = &end
+	if len(params.Categories) > 0 && (item.Category == nil || !slices.Contains(params.Categories, *item.Category)) {
+		return false
 	}
 
-	if params.StartDate != nil {
-		items = slices.DeleteFunc(items, func(item *subscriptions.Subscription) bool {
-			startBefore := item.StartDate.Before(*params.EndDate)
-			endAfter := item.EndDate == nil || item.EndDate.After(*params.StartDate)
-			return !startBefore || !endAfter
-		})
-	} else {
-		items = slices.DeleteFunc(items, func(item *subscriptions.Subscription) bool {
-			return !item.StartDate.Before(*params.EndDate)
-		})
+	for _, tag := range params.Tags {
+		if !slices.Contains(item.Tags, tag) {
+			return false
+		}
 	}
 
-	return items, nil
+	if params.ServiceNamePrefix != nil && !strings.HasPrefix(strings.ToLower(item.ServiceName), strings.ToLower(*params.ServiceNamePrefix)) {
+		return false
+	}
+
+	if params.PriceMin != nil && item.Price < *params.PriceMin {
+		return false
+	}
+
+	if params.PriceMax != nil && item.Price > *params.PriceMax {
+		return false
+	}
+
+	if params.Status != nil {
+		switch *params.Status {
+		case subscriptions.StatusActive:
+			if item.StartDate.After(month) || (item.EndDate != nil && item.EndDate.Before(month)) {
+				return false
+			}
+		case subscriptions.StatusEnded:
+			if item.EndDate == nil || !item.EndDate.Before(month) {
+				return false
+			}
+		case subscriptions.StatusFuture:
+			if !item.StartDate.After(month) {
+				return false
+			}
+		case subscriptions.StatusTrial:
+			if item.StartDate.After(month) || item.TrialEndDate == nil || item.TrialEndDate.Before(month) ||
+				(item.EndDate != nil && item.EndDate.Before(month)) {
+				return false
+			}
+		}
+	}
+
+	if params.EndDate != nil && !item.StartDate.Before(*params.EndDate) {
+		return false
+	}
+
+	if params.StartDate != nil && item.EndDate != nil && item.EndDate.Before(*params.StartDate) {
+		return false
+	}
+
+	return true
 }
 
 func (repo *SubscriptionRepository) GetByID(_ context.Context, id uuid.UUID) (*subscriptions.Subscription, error) {
@@ -126,14 +374,35 @@ func (repo *SubscriptionRepository) GetByID(_ context.Context, id uuid.UUID) (*s
 }
 
 func (repo *SubscriptionRepository) Add(_ context.Context, elem *subscriptions.Subscription) (uuid.UUID, error) {
+	if err := subscriptions.CheckPeriod(elem.StartDate, elem.EndDate); err != nil {
+		return uuid.UUID{}, err
+	}
+	if elem.ServiceId == nil && repo.Services != nil {
+		price := elem.Price
+		service := repo.Services.ensureService(elem.ServiceName, &price)
+		elem.ServiceId = &service.ID
+		elem.ServiceName = service.Name
+	}
 	elem.ID = uuid.New()
 	repo.Items = append(repo.Items, elem)
+	repo.record(events.Created, elem)
 	return elem.ID, nil
 }
 
 func (repo *SubscriptionRepository) Update(_ context.Context, elem *subscriptions.SubscriptionPatch) (int64, error) {
 	for _, item := range repo.Items {
 		if item.ID == elem.ID {
+			if err := subscriptions.CheckPeriod(elem.Period(item)); err != nil {
+				return 0, err
+			}
+			if elem.ServiceName != nil && elem.ServiceId == nil && repo.Services != nil {
+				service := repo.Services.ensureService(*elem.ServiceName, elem.Price)
+				elem.ServiceId = &service.ID
+				elem.ServiceName = &service.Name
+			}
+			if elem.ServiceId != nil {
+				item.ServiceId = elem.ServiceId
+			}
 			if elem.ServiceName != nil {
 				item.ServiceName = *elem.ServiceName
 			}
@@ -149,6 +418,25 @@ func (repo *SubscriptionRepository) Update(_ context.Context, elem *subscription
 			if elem.EndDate != nil {
 				item.EndDate = elem.EndDate
 			}
+			if elem.TrialEndDate != nil {
+				item.TrialEndDate = elem.TrialEndDate
+			}
+			if elem.Category != nil {
+				item.Category = elem.Category
+			}
+			if elem.Tags != nil {
+				item.Tags = *elem.Tags
+			}
+			if elem.Clears(subscriptions.FieldEndDate) {
+				item.EndDate = nil
+			}
+			if elem.Clears(subscriptions.FieldTrialEndDate) {
+				item.TrialEndDate = nil
+			}
+			if elem.Clears(subscriptions.FieldCategory) {
+				item.Category = nil
+			}
+			repo.record(events.Updated, item)
 
 			return 1, nil
 		}
@@ -160,8 +448,35 @@ func (repo *SubscriptionRepository) Delete(_ context.Context, id uuid.UUID) (int
 	for i, item := range repo.Items {
 		if item.ID == id {
 			repo.Items = slices.Delete(repo.Items, i, i+1)
+			repo.record(events.Deleted, item)
 			return 1, nil
 		}
 	}
 	return 0, nil
 }
+
+func (repo *SubscriptionRepository) Pause(ctx context.Context, id uuid.UUID, start time.Time) error {
+	item, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = item.CheckPause(start); err != nil {
+		return err
+	}
+	item.Pauses = append(item.Pauses, subscriptions.Pause{ID: uuid.New(), SubscriptionId: id, StartDate: start})
+	repo.record(events.Updated, item)
+	return nil
+}
+
+func (repo *SubscriptionRepository) Resume(ctx context.Context, id uuid.UUID, resume time.Time) error {
+	item, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = item.CheckResume(resume); err != nil {
+		return err
+	}
+	item.OpenPause().ResumeDate = &resume
+	repo.record(events.Updated, item)
+	return nil
+}
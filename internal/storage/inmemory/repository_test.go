@@ -2,6 +2,7 @@ package inmemory
 
 import (
 	"context"
+	"errors"
 	"ew/internal/models/subscriptions"
 	"reflect"
 	"slices"
@@ -67,7 +68,7 @@ func TestInMemorySubscriptionRepository_GetByID(t *testing.T) {
 func TestInMemorySubscriptionRepository_GetList(t *testing.T) {
 	repo := prepareRepo()
 
-	items, err := repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{UserId: &repo.Items[0].UserId})
+	items, err := repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{UserIds: []uuid.UUID{repo.Items[0].UserId}})
 	if err != nil {
 		t.Error(err)
 	}
@@ -76,7 +77,7 @@ func TestInMemorySubscriptionRepository_GetList(t *testing.T) {
 		t.Errorf("not equal %v", items)
 	}
 
-	items, err = repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{ServiceName: &repo.Items[1].ServiceName})
+	items, err = repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{ServiceNames: []string{repo.Items[1].ServiceName}})
 	if err != nil {
 		t.Error(err)
 	}
@@ -116,10 +117,125 @@ func TestInMemorySubscriptionRepository_GetList(t *testing.T) {
 	}
 }
 
+func TestInMemorySubscriptionRepository_GetListFilters(t *testing.T) {
+	repo := prepareRepo()
+
+	items, err := repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{
+		UserIds: []uuid.UUID{repo.Items[0].UserId, repo.Items[3].UserId},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(items, []*subscriptions.Subscription{repo.Items[0], repo.Items[3]}) {
+		t.Errorf("not equal %v", items)
+	}
+
+	items, err = repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{
+		ServiceNames: []string{repo.Items[1].ServiceName, repo.Items[2].ServiceName},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(items, []*subscriptions.Subscription{repo.Items[1], repo.Items[2]}) {
+		t.Errorf("not equal %v", items)
+	}
+
+	prefix := "ITEM"
+	items, err = repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{ServiceNamePrefix: &prefix})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(items, []*subscriptions.Subscription{repo.Items[1]}) {
+		t.Errorf("not equal %v", items)
+	}
+
+	var priceMin, priceMax uint = 200, 500
+	items, err = repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{PriceMin: &priceMin, PriceMax: &priceMax})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(items, []*subscriptions.Subscription{repo.Items[1], repo.Items[2]}) {
+		t.Errorf("not equal %v", items)
+	}
+
+	music := "music"
+	repo.Items[0].Category = &music
+	repo.Items[0].Tags = []string{"family", "work"}
+	repo.Items[1].Tags = []string{"family"}
+
+	items, err = repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{Categories: []string{music}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(items, []*subscriptions.Subscription{repo.Items[0]}) {
+		t.Errorf("not equal %v", items)
+	}
+
+	items, err = repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{Tags: []string{"family"}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(items, []*subscriptions.Subscription{repo.Items[0], repo.Items[1]}) {
+		t.Errorf("not equal %v", items)
+	}
+
+	items, err = repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{Tags: []string{"family", "work"}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(items, []*subscriptions.Subscription{repo.Items[0]}) {
+		t.Errorf("not equal %v", items)
+	}
+
+	lastMonth := subscriptions.MonthStart(time.Now()).AddDate(0, -1, 0)
+	nextMonth := subscriptions.MonthStart(time.Now()).AddDate(0, 1, 0)
+	repo.Items[2].EndDate = &lastMonth
+	repo.Items = append(repo.Items, &subscriptions.Subscription{
+		ID: uuid.New(), ServiceName: "later", Price: 10, StartDate: nextMonth, UserId: uuid.New(),
+	})
+
+	status := subscriptions.StatusActive
+	items, err = repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{Status: &status})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(items, []*subscriptions.Subscription{repo.Items[0], repo.Items[1], repo.Items[3]}) {
+		t.Errorf("not equal %v", items)
+	}
+
+	status = subscriptions.StatusEnded
+	items, err = repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{Status: &status})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(items, []*subscriptions.Subscription{repo.Items[2]}) {
+		t.Errorf("not equal %v", items)
+	}
+
+	status = subscriptions.StatusFuture
+	items, err = repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{Status: &status})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(items, []*subscriptions.Subscription{repo.Items[4]}) {
+		t.Errorf("not equal %v", items)
+	}
+}
+
 func TestInMemorySubscriptionRepository_Stats(t *testing.T) {
 	repo := prepareRepo()
 
-	total, err := repo.GetStats(context.TODO(), subscriptions.SubscriptionListParams{UserId: &repo.Items[0].UserId})
+	total, err := repo.GetStats(context.TODO(), subscriptions.SubscriptionListParams{UserIds: []uuid.UUID{repo.Items[0].UserId}})
 	if err != nil {
 		t.Error(err)
 	}
@@ -128,7 +244,7 @@ func TestInMemorySubscriptionRepository_Stats(t *testing.T) {
 		t.Errorf("not equal %v", total)
 	}
 
-	total, err = repo.GetStats(context.TODO(), subscriptions.SubscriptionListParams{ServiceName: &repo.Items[3].ServiceName})
+	total, err = repo.GetStats(context.TODO(), subscriptions.SubscriptionListParams{ServiceNames: []string{repo.Items[3].ServiceName}})
 	if err != nil {
 		t.Error(err)
 	}
@@ -138,6 +254,278 @@ func TestInMemorySubscriptionRepository_Stats(t *testing.T) {
 	}
 }
 
+func TestInMemorySubscriptionRepository_StatsPeriod(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	repo := NewRepo([]*subscriptions.Subscription{
+		{ID: id1, ServiceName: "video", Price: 100, StartDate: start, EndDate: &end, UserId: uuid.New()},
+	})
+
+	// only the months of the subscription inside the period are counted
+	for _, tt := range []struct {
+		from, to time.Time
+		total    int
+	}{
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), 6 * 100},
+		{time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), 4 * 100},
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), 2 * 100},
+		{time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), 100},
+	} {
+		total, err := repo.GetStats(context.TODO(), subscriptions.SubscriptionListParams{StartDate: &tt.from, EndDate: &tt.to})
+		if err != nil || total != tt.total {
+			t.Errorf("%s - %s: expected %d, got %d %v", tt.from.Format("01-2006"), tt.to.Format("01-2006"), tt.total, total, err)
+		}
+	}
+}
+
+func TestInMemorySubscriptionRepository_StatsTrial(t *testing.T) {
+	repo := prepareRepo()
+
+	trialEnd := time.Now().AddDate(0, -2, 0)
+	repo.Items[3].TrialEndDate = &trialEnd
+
+	total, err := repo.GetStats(context.TODO(), subscriptions.SubscriptionListParams{ServiceNames: []string{repo.Items[3].ServiceName}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if total != 2*1500 {
+		t.Errorf("not equal %v", total)
+	}
+
+	currentTrialEnd := time.Now()
+	repo.Items[1].TrialEndDate = &currentTrialEnd
+
+	total, err = repo.GetStats(context.TODO(), subscriptions.SubscriptionListParams{UserIds: []uuid.UUID{repo.Items[1].UserId}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if total != 0 {
+		t.Errorf("not equal %v", total)
+	}
+
+	status := subscriptions.StatusTrial
+	items, err := repo.GetList(context.TODO(), subscriptions.SubscriptionListParams{Status: &status})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(items, []*subscriptions.Subscription{repo.Items[1]}) {
+		t.Errorf("not equal %v", items)
+	}
+}
+
+func TestInMemorySubscriptionRepository_StatsPause(t *testing.T) {
+	repo := prepareRepo()
+	params := subscriptions.SubscriptionListParams{ServiceNames: []string{repo.Items[3].ServiceName}}
+
+	pauseStart := subscriptions.MonthStart(time.Now().AddDate(0, -2, 0))
+	err := repo.Pause(context.TODO(), id4, pauseStart)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if err = repo.Pause(context.TODO(), id4, pauseStart); !errors.Is(err, subscriptions.AlreadyPaused) {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	total, err := repo.GetStats(context.TODO(), params)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if total != 1500 {
+		t.Errorf("not equal %v", total)
+	}
+
+	if err = repo.Resume(context.TODO(), id4, pauseStart.AddDate(0, -1, 0)); !errors.Is(err, subscriptions.InvalidPauseDates) {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	err = repo.Resume(context.TODO(), id4, pauseStart.AddDate(0, 1, 0))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if err = repo.Resume(context.TODO(), id4, time.Now()); !errors.Is(err, subscriptions.NotPaused) {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if err = repo.Pause(context.TODO(), id4, pauseStart); !errors.Is(err, subscriptions.InvalidPauseDates) {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	total, err = repo.GetStats(context.TODO(), params)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if total != 3*1500 {
+		t.Errorf("not equal %v", total)
+	}
+}
+
+func TestInMemorySubscriptionRepository_Forecast(t *testing.T) {
+	repo := prepareRepo()
+
+	start := subscriptions.MonthStart(time.Now()).AddDate(0, 1, 0)
+	params := subscriptions.ForecastParams{StartDate: start, EndDate: start.AddDate(0, 2, 0)}
+
+	end := start.AddDate(0, 1, 0)
+	repo.Items[1].EndDate = &end
+
+	err := repo.Pause(context.TODO(), id1, start.AddDate(0, 2, 0))
+	if err != nil {
+		t.Error(err)
+	}
+
+	forecast, err := repo.GetForecast(context.TODO(), params)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := []*subscriptions.MonthTotal{
+		{Month: start, TotalPrice: 125 + 250 + 1500},
+		{Month: start.AddDate(0, 1, 0), TotalPrice: 125 + 250 + 1500},
+		{Month: start.AddDate(0, 2, 0), TotalPrice: 1500},
+	}
+	if !reflect.DeepEqual(forecast, expected) {
+		t.Errorf("not equal %v", forecast)
+	}
+
+	params.Cancel = []uuid.UUID{id4}
+	params.PriceChangePercent = 10
+
+	forecast, err = repo.GetForecast(context.TODO(), params)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if forecast[0].TotalPrice != 413 || forecast[2].TotalPrice != 0 {
+		t.Errorf("incorrect scenario %v %v", forecast[0], forecast[2])
+	}
+}
+
+func TestInMemorySubscriptionRepository_UserSummary(t *testing.T) {
+	repo := prepareRepo()
+
+	userId := repo.Items[0].UserId
+	repo.Items[1].UserId = userId
+	end := subscriptions.MonthStart(time.Now()).AddDate(0, 2, 0)
+	repo.Items[1].EndDate = &end
+
+	err := repo.Pause(context.TODO(), id1, subscriptions.MonthStart(time.Now()))
+	if err != nil {
+		t.Error(err)
+	}
+
+	summary, err := repo.GetUserSummary(context.TODO(), userId)
+	if err != nil {
+		t.Error(err)
+	}
+
+	topName, topPrice := "item 2", uint(250)
+	expected := &subscriptions.UserSummary{
+		ActiveCount:     2,
+		MonthlyRunRate:  250,
+		LifetimeSpend:   17*125 + 3*250,
+		LastYearSpend:   11*125 + 3*250,
+		TopServiceName:  &topName,
+		TopServicePrice: &topPrice,
+		NextEndDate:     &end,
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("not equal %+v", summary)
+	}
+
+	summary, err = repo.GetUserSummary(context.TODO(), uuid.New())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(summary, &subscriptions.UserSummary{}) {
+		t.Errorf("not equal %+v", summary)
+	}
+
+	other := uuid.New()
+	summaries, err := repo.GetUserSummaries(context.TODO(), []uuid.UUID{userId, other})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(summaries) != 2 || !reflect.DeepEqual(summaries[userId], expected) || !reflect.DeepEqual(summaries[other], &subscriptions.UserSummary{}) {
+		t.Errorf("not equal %+v", summaries)
+	}
+}
+
+func TestInMemorySubscriptionRepository_Top(t *testing.T) {
+	repo := prepareRepo()
+	repo.Items[1].Price = 125
+
+	top, err := repo.GetTop(context.TODO(), subscriptions.TopParams{
+		Dimension: subscriptions.DimensionServiceName,
+		Metric:    subscriptions.MetricRunRate,
+		Limit:     10,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := []*subscriptions.TopItem{
+		{Rank: 1, Key: "variable", Name: "variable", Value: 1500},
+		{Rank: 2, Key: "nothing", Name: "nothing", Value: 500},
+		{Rank: 3, Key: "item 2", Name: "item 2", Value: 125},
+		{Rank: 3, Key: "some item", Name: "some item", Value: 125},
+	}
+	if !reflect.DeepEqual(top, expected) {
+		t.Errorf("not equal %v", top)
+	}
+
+	top, err = repo.GetTop(context.TODO(), subscriptions.TopParams{
+		Dimension: subscriptions.DimensionUserId,
+		Metric:    subscriptions.MetricTotal,
+		Limit:     1,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected = []*subscriptions.TopItem{
+		{Rank: 1, Key: repo.Items[3].UserId.String(), Name: repo.Items[3].UserId.String(), Value: 4 * 1500},
+	}
+	if !reflect.DeepEqual(top, expected) {
+		t.Errorf("not equal %v", top)
+	}
+
+	_, err = repo.GetTop(context.TODO(), subscriptions.TopParams{Dimension: "price", Metric: subscriptions.MetricCount, Limit: 1})
+	if err == nil {
+		t.Error("expected unsupported dimension error")
+	}
+}
+
+func TestInMemorySubscriptionRepository_GroupedStats(t *testing.T) {
+	repo := prepareRepo()
+
+	serviceId := uuid.New()
+	repo.Items[1].ServiceId = &serviceId
+	repo.Items[2].ServiceId = &serviceId
+	repo.Items[2].ServiceName = repo.Items[1].ServiceName
+	repo.Items[3].ServiceName = " Some Item"
+
+	groups, err := repo.GetGroupedStats(context.TODO(), subscriptions.SubscriptionListParams{}, subscriptions.GroupByService)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := []*subscriptions.StatsGroup{
+		{Key: "some item", Name: " Some Item", TotalPrice: 18*125 + 4*1500},
+		{Key: serviceId.String(), Name: "item 2", TotalPrice: 3*250 + 2*500},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("not equal %v", groups)
+	}
+}
+
 func TestInMemorySubscriptionRepository_Update(t *testing.T) {
 	repo := prepareRepo()
 
@@ -165,6 +553,55 @@ func TestInMemorySubscriptionRepository_Update(t *testing.T) {
 	}
 }
 
+func TestInMemorySubscriptionRepository_UpdateClear(t *testing.T) {
+	repo := prepareRepo()
+
+	price := uint(100)
+	affected, err := repo.Update(context.TODO(), &subscriptions.SubscriptionPatch{
+		ID:    id3,
+		Price: &price,
+		Clear: []subscriptions.Field{subscriptions.FieldEndDate},
+	})
+
+	if err != nil {
+		t.Error(err)
+	}
+	if affected != 1 {
+		t.Errorf("affected %v", affected)
+	}
+	if repo.Items[2].EndDate != nil || repo.Items[2].Price != price {
+		t.Errorf("not cleared %v", repo.Items[2])
+	}
+}
+
+func TestInMemorySubscriptionRepository_InvalidPeriod(t *testing.T) {
+	repo := prepareRepo()
+
+	end := time.Now().AddDate(-2, 0, 0)
+	_, err := repo.Add(context.TODO(), &subscriptions.Subscription{ServiceName: "test", Price: 1, StartDate: time.Now(), UserId: uuid.New(), EndDate: &end})
+	if !errors.Is(err, subscriptions.InvalidPeriod) {
+		t.Errorf("added subscription ending before it starts: %v", err)
+	}
+
+	start := time.Now().AddDate(1, 0, 0)
+	_, err = repo.Update(context.TODO(), &subscriptions.SubscriptionPatch{ID: id3, StartDate: &start})
+	if !errors.Is(err, subscriptions.InvalidPeriod) {
+		t.Errorf("moved start after the end: %v", err)
+	}
+	if repo.Items[2].StartDate.Equal(start) {
+		t.Errorf("rejected patch applied %v", repo.Items[2])
+	}
+
+	// clearing the end date makes the same start valid
+	_, err = repo.Update(context.TODO(), &subscriptions.SubscriptionPatch{ID: id3, StartDate: &start, Clear: []subscriptions.Field{subscriptions.FieldEndDate}})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(repo.Items) != 4 {
+		t.Errorf("unexpected items %v", repo.Items)
+	}
+}
+
 func TestInMemorySubscriptionRepository_Delete(t *testing.T) {
 	repo := prepareRepo()
 
@@ -0,0 +1,182 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/database"
+	"ew/internal/models/events"
+	"ew/internal/models/services"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ServiceRepository struct {
+	Items         []*services.Service
+	Subscriptions *SubscriptionRepository
+}
+
+func NewServiceRepo(items []*services.Service, subs *SubscriptionRepository) *ServiceRepository {
+	repo := &ServiceRepository{Items: items, Subscriptions: subs}
+	if subs != nil {
+		subs.Services = repo
+	}
+	return repo
+}
+
+func (repo *ServiceRepository) GetList(_ context.Context, params services.ServiceListParams) ([]*services.Service, error) {
+	items := make([]*services.Service, len(repo.Items))
+	copy(items, repo.Items)
+
+	if params.Category != nil {
+		items = slices.DeleteFunc(items, func(item *services.Service) bool {
+			return item.Category == nil || *item.Category != *params.Category
+		})
+	}
+
+	slices.SortStableFunc(items, func(a, b *services.Service) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if params.Offset != nil {
+		items = items[min(*params.Offset, len(items)):]
+	}
+
+	if params.Limit != nil {
+		items = items[:min(*params.Limit, len(items))]
+	}
+
+	return items, nil
+}
+
+func (repo *ServiceRepository) GetByID(_ context.Context, id uuid.UUID) (*services.Service, error) {
+	for _, item := range repo.Items {
+		if item.ID == id {
+			return item, nil
+		}
+	}
+	return nil, services.NotFound
+}
+
+func (repo *ServiceRepository) FindByName(_ context.Context, name string) (*services.Service, error) {
+	for _, item := range repo.Items {
+		if slices.ContainsFunc(item.Names(), func(n string) bool { return sameName(n, name) }) {
+			return item, nil
+		}
+	}
+	return nil, services.NotFound
+}
+
+func (repo *ServiceRepository) Add(_ context.Context, elem *services.Service) (uuid.UUID, error) {
+	if repo.nameTaken(elem) {
+		return uuid.UUID{}, services.NameTaken
+	}
+	if elem.Aliases == nil {
+		elem.Aliases = database.TextArray{}
+	}
+
+	elem.ID = uuid.New()
+	repo.Items = append(repo.Items, elem)
+	repo.linkSubscriptions(elem)
+
+	return elem.ID, nil
+}
+
+func (repo *ServiceRepository) Update(_ context.Context, elem *services.ServicePatch) (int64, error) {
+	for _, item := range repo.Items {
+		if item.ID == elem.ID {
+			updated := *item
+			if elem.Name != nil {
+				updated.Name = *elem.Name
+			}
+			if elem.Aliases != nil {
+				updated.Aliases = *elem.Aliases
+			}
+			if elem.Category != nil {
+				updated.Category = elem.Category
+			}
+			if elem.DefaultPrice != nil {
+				updated.DefaultPrice = elem.DefaultPrice
+			}
+			if elem.LogoUrl != nil {
+				updated.LogoUrl = elem.LogoUrl
+			}
+			if repo.nameTaken(&updated) {
+				return 0, services.NameTaken
+			}
+			*item = updated
+			repo.linkSubscriptions(item)
+
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (repo *ServiceRepository) Delete(_ context.Context, id uuid.UUID) (int64, error) {
+	for i, item := range repo.Items {
+		if item.ID == id {
+			repo.Items = slices.Delete(repo.Items, i, i+1)
+
+			if repo.Subscriptions != nil {
+				for _, subscription := range repo.Subscriptions.Items {
+					if subscription.ServiceId != nil && *subscription.ServiceId == id {
+						subscription.ServiceId = nil
+						repo.Subscriptions.record(events.Updated, subscription)
+					}
+				}
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// nameTaken reports whether a name or an alias of the service is used by another service.
+func (repo *ServiceRepository) nameTaken(service *services.Service) bool {
+	keys := service.Keys()
+	return slices.ContainsFunc(repo.Items, func(item *services.Service) bool {
+		return item.ID != service.ID && slices.ContainsFunc(item.Names(), func(n string) bool {
+			return slices.Contains(keys, services.Key(n))
+		})
+	})
+}
+
+// ensureService returns the catalog entry of the name, adding it for names seen for the first time.
+func (repo *ServiceRepository) ensureService(name string, price *uint) *services.Service {
+	service, err := repo.FindByName(context.Background(), name)
+	if err == nil {
+		return service
+	}
+
+	service = &services.Service{ID: uuid.New(), Name: strings.TrimSpace(name), Aliases: database.TextArray{}, DefaultPrice: price}
+	repo.Items = append(repo.Items, service)
+	repo.linkSubscriptions(service)
+	return service
+}
+
+// linkSubscriptions links the free-text subscriptions matching a name of the service and renames the linked ones,
+// recording the subscriptions it changes.
+func (repo *ServiceRepository) linkSubscriptions(service *services.Service) {
+	if repo.Subscriptions == nil {
+		return
+	}
+
+	for _, subscription := range repo.Subscriptions.Items {
+		linked := subscription.ServiceId != nil && *subscription.ServiceId == service.ID && subscription.ServiceName != service.Name
+		matches := subscription.ServiceId == nil && slices.ContainsFunc(service.Names(), func(n string) bool {
+			return sameName(n, subscription.ServiceName)
+		})
+
+		if linked || matches {
+			id := service.ID
+			subscription.ServiceId = &id
+			subscription.ServiceName = service.Name
+			repo.Subscriptions.record(events.Updated, subscription)
+		}
+	}
+}
+
+func sameName(a, b string) bool {
+	return services.Key(a) == services.Key(b)
+}
@@ -0,0 +1,161 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"ew/internal/database"
+	"ew/internal/models/events"
+	"ew/internal/models/services"
+	"ew/internal/models/subscriptions"
+	"testing"
+	"time"
+)
+
+func TestInMemoryServiceRepository_Add(t *testing.T) {
+	subs := prepareRepo()
+	repo := NewServiceRepo(nil, subs)
+
+	id, err := repo.Add(context.TODO(), &services.Service{Name: "Variable", Aliases: database.TextArray{"Nothing"}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, item := range []int{2, 3} {
+		if subs.Items[item].ServiceId == nil || *subs.Items[item].ServiceId != id {
+			t.Errorf("not linked %v", subs.Items[item])
+		}
+		if subs.Items[item].ServiceName != "Variable" {
+			t.Errorf("not normalized %v", subs.Items[item])
+		}
+	}
+	if subs.Items[0].ServiceId != nil {
+		t.Errorf("linked but shouldn`t %v", subs.Items[0])
+	}
+
+	_, err = repo.Add(context.TODO(), &services.Service{Name: " variable"})
+	if err != services.NameTaken {
+		t.Errorf("expected name taken, got %v", err)
+	}
+}
+
+func TestInMemoryServiceRepository_FindByName(t *testing.T) {
+	repo := NewServiceRepo(nil, nil)
+
+	id, _ := repo.Add(context.TODO(), &services.Service{Name: "Yandex Plus", Aliases: database.TextArray{"Яндекс Плюс"}})
+
+	for _, name := range []string{"yandex plus", "ЯНДЕКС ПЛЮС", " Yandex Plus "} {
+		item, err := repo.FindByName(context.TODO(), name)
+		if err != nil {
+			t.Error(err)
+		}
+		if item == nil || item.ID != id {
+			t.Errorf("not found by %q", name)
+		}
+	}
+
+	_, err := repo.FindByName(context.TODO(), "Netflix")
+	if err != services.NotFound {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestInMemoryServiceRepository_UpdateDelete(t *testing.T) {
+	subs := prepareRepo()
+	subs.Events = NewEventRepo(events.DefaultLogSize)
+	repo := NewServiceRepo(nil, subs)
+
+	id, _ := repo.Add(context.TODO(), &services.Service{Name: "nothing"})
+
+	// the subscriptions the catalog links, renames or unlinks are recorded, the unchanged ones are not
+	category := "video"
+	if _, err := repo.Update(context.TODO(), &services.ServicePatch{ID: id, Category: &category}); err != nil {
+		t.Fatal(err)
+	}
+	if recorded, _ := subs.Events.Since(context.TODO(), 0); len(recorded) != 1 || *recorded[0].Subscription.ServiceId != id {
+		t.Errorf("expected the link recorded, got %v", recorded)
+	}
+
+	name := "Nothing at all"
+	affected, err := repo.Update(context.TODO(), &services.ServicePatch{ID: id, Name: &name})
+	if err != nil {
+		t.Error(err)
+	}
+	if affected != 1 {
+		t.Errorf("affected %v", affected)
+	}
+	if subs.Items[2].ServiceName != name {
+		t.Errorf("not renamed %v", subs.Items[2])
+	}
+
+	affected, err = repo.Delete(context.TODO(), id)
+	if err != nil {
+		t.Error(err)
+	}
+	if affected != 1 {
+		t.Errorf("affected %v", affected)
+	}
+	if subs.Items[2].ServiceId != nil {
+		t.Errorf("not unlinked %v", subs.Items[2])
+	}
+
+	recorded, _ := subs.Events.Since(context.TODO(), 1)
+	if len(recorded) != 2 || recorded[0].Subscription.ServiceName != name || recorded[1].Subscription.ServiceId != nil {
+		t.Errorf("expected the rename and the unlink recorded, got %v", recorded)
+	}
+}
+
+func TestInMemoryServiceRepository_Aliases(t *testing.T) {
+	repo := NewServiceRepo(nil, nil)
+
+	_, err := repo.Add(context.TODO(), &services.Service{Name: "Yandex Plus", Aliases: database.TextArray{"yandex plus", "Кинопоиск"}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, service := range []*services.Service{
+		{Name: "кинопоиск "},
+		{Name: "Kinopoisk", Aliases: database.TextArray{" YANDEX PLUS"}},
+	} {
+		if _, err = repo.Add(context.TODO(), service); err != services.NameTaken {
+			t.Errorf("expected name taken for %v, got %v", service.Names(), err)
+		}
+	}
+
+	id, _ := repo.Add(context.TODO(), &services.Service{Name: "Kinopoisk"})
+	aliases := database.TextArray{"Кинопоиск"}
+	if _, err = repo.Update(context.TODO(), &services.ServicePatch{ID: id, Aliases: &aliases}); err != services.NameTaken {
+		t.Errorf("expected name taken, got %v", err)
+	}
+	if item, _ := repo.GetByID(context.TODO(), id); len(item.Aliases) != 0 {
+		t.Errorf("rejected update applied %v", item)
+	}
+}
+
+func TestInMemoryServiceRepository_Subscriptions(t *testing.T) {
+	subs := prepareRepo()
+	repo := NewServiceRepo(nil, subs)
+
+	// a rolled back write leaves no service behind
+	failed := errors.New("failed")
+	err := subs.WithTx(context.TODO(), func(tx subscriptions.SubscriptionRepo) error {
+		if _, err := tx.Add(context.TODO(), &subscriptions.Subscription{ServiceName: "Kinopoisk", Price: 300, StartDate: time.Now()}); err != nil {
+			return err
+		}
+		return failed
+	})
+	if err != failed || len(repo.Items) != 0 {
+		t.Errorf("service kept after rollback: %v %v", err, repo.Items)
+	}
+
+	// the name of a patch has no price to default to
+	name := " Kinopoisk"
+	if _, err = subs.Update(context.TODO(), &subscriptions.SubscriptionPatch{ID: subs.Items[0].ID, ServiceName: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.Items) != 1 || repo.Items[0].Name != "Kinopoisk" || repo.Items[0].DefaultPrice != nil {
+		t.Fatalf("unexpected catalog %v", repo.Items)
+	}
+	if subs.Items[0].ServiceId == nil || *subs.Items[0].ServiceId != repo.Items[0].ID || subs.Items[0].ServiceName != "Kinopoisk" {
+		t.Errorf("not linked %v", subs.Items[0])
+	}
+}
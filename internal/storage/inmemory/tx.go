@@ -0,0 +1,58 @@
+package inmemory
+
+import (
+	"context"
+	"ew/internal/models/events"
+	"ew/internal/models/services"
+	"ew/internal/models/subscriptions"
+	"slices"
+)
+
+// snapshot copies the items deeply enough to undo Update and the pause changes made in place.
+func (repo *SubscriptionRepository) snapshot() []*subscriptions.Subscription {
+	items := make([]*subscriptions.Subscription, 0, len(repo.Items))
+	for _, item := range repo.Items {
+		items = append(items, item.Clone())
+	}
+	return items
+}
+
+// WithTx restores the snapshot taken before fn unless it succeeds, along with the services the writes added to the catalog.
+// The events of the writes are only published once fn succeeds. There is no isolation from other callers.
+func (repo *SubscriptionRepository) WithTx(_ context.Context, fn func(subscriptions.SubscriptionRepo) error) error {
+	saved := repo.snapshot()
+	var catalog []*services.Service
+	if repo.Services != nil {
+		catalog = slices.Clone(repo.Services.Items)
+	}
+
+	outer := repo.outbox
+	outbox := []events.Event{}
+	repo.outbox = &outbox
+
+	committed := false
+	defer func() {
+		repo.outbox = outer
+		if !committed {
+			repo.Items = saved
+			if repo.Services != nil {
+				repo.Services.Items = catalog
+			}
+		}
+	}()
+
+	if err := fn(repo); err != nil {
+		return err
+	}
+	committed = true
+	repo.outbox = outer
+
+	for _, event := range outbox {
+		if outer != nil {
+			*outer = append(*outer, event)
+			continue
+		}
+		_ = repo.Events.Publish(context.Background(), &event)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"ew/internal/models/events"
+	"ew/internal/models/subscriptions"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestInMemorySubscriptionRepository_WithTx(t *testing.T) {
+	repo := prepareRepo()
+	repo.Events = NewEventRepo(events.DefaultLogSize)
+	count := len(repo.Items)
+	price := uint(999)
+	failed := errors.New("failed")
+
+	err := repo.WithTx(context.TODO(), func(tx subscriptions.SubscriptionRepo) error {
+		if _, err := tx.Update(context.TODO(), &subscriptions.SubscriptionPatch{ID: id1, Price: &price}); err != nil {
+			return err
+		}
+		if err := tx.Pause(context.TODO(), id2, subscriptions.MonthStart(time.Now())); err != nil {
+			return err
+		}
+		if _, err := tx.Add(context.TODO(), &subscriptions.Subscription{ServiceName: "tx", Price: 1, UserId: uuid.New(), StartDate: time.Now()}); err != nil {
+			return err
+		}
+		return failed
+	})
+	if err != failed {
+		t.Errorf("expected the error of fn, got %v", err)
+	}
+
+	first, _ := repo.GetByID(context.TODO(), id1)
+	second, _ := repo.GetByID(context.TODO(), id2)
+	if len(repo.Items) != count || first.Price == price || len(second.Pauses) != 0 {
+		t.Errorf("not rolled back: %d items, %v, %v", len(repo.Items), first, second)
+	}
+	if recorded, _ := repo.Events.Since(context.TODO(), 0); len(recorded) != 0 {
+		t.Errorf("expected no events of the rolled back writes, got %v", recorded)
+	}
+
+	err = repo.WithTx(context.TODO(), func(tx subscriptions.SubscriptionRepo) error {
+		_, err := tx.Update(context.TODO(), &subscriptions.SubscriptionPatch{ID: id1, Price: &price})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first, _ = repo.GetByID(context.TODO(), id1); first.Price != price {
+		t.Errorf("not committed: %v", first)
+	}
+	recorded, _ := repo.Events.Since(context.TODO(), 0)
+	if len(recorded) != 1 || recorded[0].Type != events.Updated || recorded[0].Subscription.Price != price {
+		t.Errorf("expected the update recorded on commit, got %v", recorded)
+	}
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"ew/internal/models/subscriptions"
+
+	"github.com/google/uuid"
+)
+
+// spendRefresh holds the users a batch has written so far.
+type spendRefresh struct {
+	userIds []uuid.UUID
+}
+
+// Batch runs the operations on a repository that leaves the monthly spend of the written users
+// to a single refresh before the transaction commits, along with the events of the operations.
+func (repo *SubscriptionRepository) Batch(ctx context.Context, ops []subscriptions.BatchOperation, atomic bool) ([]subscriptions.BatchResult, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.Batch")
+	defer span.End()
+
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	batch := &SubscriptionRepository{DB: tx, QB: repo.QB, tx: tx, batch: &spendRefresh{}, Events: repo.Events, outbox: &outbox{}}
+	results, err := subscriptions.RunBatch(ctx, batch, ops, atomic)
+	if err != nil {
+		return nil, err
+	}
+
+	err = refreshSpend(ctx, repo.QB, tx, batch.batch.userIds...)
+	if err != nil {
+		return nil, err
+	}
+	err = repo.flush(ctx, tx, batch.outbox)
+	if err != nil {
+		return nil, err
+	}
+	return results, tx.Commit(ctx)
+}
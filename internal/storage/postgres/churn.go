@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+
+	"github.com/doug-martin/goqu/v9"
+)
+
+func (repo *SubscriptionRepository) GetChurn(ctx context.Context, params subscriptions.ChurnParams) ([]*subscriptions.ServiceChurn, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.GetChurn")
+	defer span.End()
+
+	filters := params.Filter
+	afterEnd := params.EndDate.AddDate(0, 1, 0)
+	filters.StartDate, filters.EndDate = &params.StartDate, &afterEnd
+
+	started := goqu.L("subscriptions.start_date >= months.month AND subscriptions.start_date < months.month + INTERVAL '1 month'")
+	ended := goqu.L("subscriptions.end_date >= months.month AND subscriptions.end_date < months.month + INTERVAL '1 month'")
+
+	// every service gets a row for every month, so the lifetime average per row covers all of its subscriptions
+	query := repo.QB.From("subscriptions").
+		CrossJoin(goqu.L("generate_series(?::date, ?::date, INTERVAL '1 month') AS months(month)", params.StartDate, params.EndDate)).
+		CrossJoin(goqu.L("(SELECT ?::date) AS current_month(month)", currentMonth())).
+		Select(
+			serviceKey("subscriptions").As("key"),
+			goqu.MIN(col("service_name")).As("name"),
+			goqu.L("months.month::date"),
+			goqu.L("COUNT(*) FILTER (WHERE ?)", started),
+			goqu.L("COUNT(*) FILTER (WHERE ?)", ended),
+			goqu.L("COUNT(*) FILTER (WHERE subscriptions.start_date < months.month AND (subscriptions.end_date IS NULL OR subscriptions.end_date >= months.month))"),
+			goqu.L("COALESCE(SUM(subscriptions.price) FILTER (WHERE ?), 0)", started),
+			goqu.L("COALESCE(SUM(subscriptions.price) FILTER (WHERE ?), 0)", ended),
+			goqu.L("AVG("+monthSpan("subscriptions.start_date", "COALESCE(subscriptions.end_date, current_month.month)")+")"),
+		).
+		GroupBy(goqu.C("key"), goqu.L("months.month")).
+		Order(goqu.C("name").Asc(), goqu.C("key").Asc(), goqu.L("months.month").Asc())
+
+	query = applyFilters(query, filters)
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("GetChurn query")
+
+	rows, err := repo.DB.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*subscriptions.ServiceChurn, 0)
+	var churn *subscriptions.ServiceChurn
+	for rows.Next() {
+		var key, name string
+		var lifetime float64
+		month := &subscriptions.ChurnMonth{}
+
+		err = rows.Scan(&key, &name, &month.Month, &month.New, &month.Cancelled, &month.Active, &month.NewMRR, &month.CancelledMRR, &lifetime)
+		if err != nil {
+			return nil, err
+		}
+
+		if churn == nil || churn.Key != key {
+			churn = &subscriptions.ServiceChurn{Key: key, Name: name, AverageLifetime: lifetime}
+			res = append(res, churn)
+		}
+		churn.Months = append(churn.Months, month)
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+
+	"github.com/doug-martin/goqu/v9"
+)
+
+func (repo *SubscriptionRepository) GetCohorts(ctx context.Context, params subscriptions.CohortParams) ([]*subscriptions.Cohort, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.GetCohorts")
+	defer span.End()
+
+	filters := params.Filter
+	afterEnd := params.EndDate.AddDate(0, 1, 0)
+	filters.StartDate, filters.EndDate = nil, &afterEnd
+
+	// every subscription is expanded into the months since its start up to the current one
+	ages := goqu.L("generate_series(0, (" + monthSpan("subscriptions.start_date", "current_month.month") + ")::int - 1) AS ages(age)")
+
+	query := repo.QB.From("subscriptions").
+		CrossJoin(goqu.L("(SELECT ?::date) AS current_month(month)", currentMonth())).
+		CrossJoin(ages).
+		Select(
+			goqu.L("date_trunc('month', subscriptions.start_date)::date").As("cohort"),
+			goqu.I("ages.age"),
+			goqu.COUNT(goqu.Star()),
+			goqu.L("COUNT(*) FILTER (WHERE subscriptions.end_date IS NULL OR subscriptions.end_date >= date_trunc('month', subscriptions.start_date) + ages.age * INTERVAL '1 month')"),
+		).
+		Where(col("start_date").Gte(params.StartDate)).
+		GroupBy(goqu.C("cohort"), goqu.I("ages.age")).
+		Order(goqu.C("cohort").Asc(), goqu.I("ages.age").Asc())
+
+	query = applyFilters(query, filters)
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("GetCohorts query")
+
+	rows, err := repo.DB.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*subscriptions.Cohort, 0)
+	var cohort *subscriptions.Cohort
+	for rows.Next() {
+		row := &subscriptions.Cohort{}
+		var age, active int
+
+		err = rows.Scan(&row.Month, &age, &row.Size, &active)
+		if err != nil {
+			return nil, err
+		}
+
+		if cohort == nil || !cohort.Month.Equal(row.Month) {
+			cohort = row
+			res = append(res, cohort)
+		}
+		cohort.Active = append(cohort.Active, active)
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+)
+
+func (repo *SubscriptionRepository) GetDuplicates(ctx context.Context, userIds []uuid.UUID) ([]*subscriptions.Duplicate, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.GetDuplicates")
+	defer span.End()
+
+	month := subscriptions.MonthStart(time.Now())
+
+	// the periods are half-open, the month a subscription ends in is not shared with the one starting in it
+	overlapEnd := "(LEAST(a.end_date, b.end_date) - INTERVAL '1 month')::date"
+
+	// the cheaper subscription is wasted in every common month paid so far
+	waste := repo.QB.From(goqu.L(
+		"generate_series(GREATEST(a.start_date, b.start_date), LEAST("+overlapEnd+", ?::date), INTERVAL '1 month') AS months(month)",
+		month,
+	)).
+		Select(goqu.L("COALESCE(SUM(LEAST(a.price, b.price)), 0)").As("total")).
+		Where(chargedInMonth("a"), chargedInMonth("b"))
+
+	query := repo.QB.From(goqu.T("subscriptions").As("a")).
+		Join(goqu.T("subscriptions").As("b"), goqu.On(
+			goqu.L("b.user_id = a.user_id"),
+			goqu.L("a.id < b.id"),
+			goqu.L("? = ?", serviceKey("a"), serviceKey("b")),
+			goqu.L("a.start_date < COALESCE(b.end_date, 'infinity')"),
+			goqu.L("b.start_date < COALESCE(a.end_date, 'infinity')"),
+		)).
+		CrossJoin(goqu.Lateral(waste).As("waste")).
+		Select(
+			goqu.I("a.user_id"),
+			goqu.I("a.service_name"),
+			goqu.I("a.id"),
+			goqu.I("b.id"),
+			goqu.L("GREATEST(a.start_date, b.start_date)").As("overlap_start"),
+			goqu.L(overlapEnd).As("overlap_end"),
+			goqu.I("waste.total"),
+		).
+		Order(goqu.I("a.user_id").Asc(), goqu.C("overlap_start").Asc(), goqu.I("a.id").Asc(), goqu.I("b.id").Asc())
+
+	if len(userIds) > 0 {
+		query = query.Where(goqu.I("a.user_id").In(userIds))
+	}
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("GetDuplicates query")
+
+	rows, err := repo.DB.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*subscriptions.Duplicate, 0)
+	for rows.Next() {
+		duplicate := &subscriptions.Duplicate{}
+		err = rows.Scan(
+			&duplicate.UserId,
+			&duplicate.ServiceName,
+			&duplicate.FirstId,
+			&duplicate.SecondId,
+			&duplicate.OverlapStart,
+			&duplicate.OverlapEnd,
+			&duplicate.WastedPrice,
+		)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, duplicate)
+	}
+	return res, rows.Err()
+}
+
+func (repo *SubscriptionRepository) FindOverlapping(ctx context.Context, elem *subscriptions.Subscription) ([]*subscriptions.Subscription, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.FindOverlapping")
+	defer span.End()
+
+	query := repo.QB.From("subscriptions").
+		Select(subscriptionColumns...).
+		Where(
+			col("id").Neq(elem.ID),
+			col("user_id").Eq(elem.UserId),
+			goqu.L("? = ?", serviceKey("subscriptions"), elem.ServiceKey()),
+			goqu.Or(col("end_date").IsNull(), col("end_date").Gt(subscriptions.MonthStart(elem.StartDate))),
+		).
+		Order(col("start_date").Asc(), col("id").Asc())
+
+	if elem.EndDate != nil {
+		query = query.Where(col("start_date").Lt(*elem.EndDate))
+	}
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("FindOverlapping query")
+
+	rows, err := repo.DB.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*subscriptions.Subscription, 0)
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, subscription)
+	}
+	return items, rows.Err()
+}
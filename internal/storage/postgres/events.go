@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"ew/internal/logging"
+	"ew/internal/models/events"
+	"ew/internal/models/subscriptions"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// eventsChannel is notified with the latest id of every transaction writing events
+	eventsChannel = "subscription_events"
+	// eventsLock serializes the transactions writing events, so that the ids are committed in order
+	// and a stream resumed after an id cannot miss an event committed later with a lower one
+	eventsLock = 0x65766e74
+	// listenRetry is the pause before reconnecting a failed listener
+	listenRetry = time.Second
+)
+
+// EventRepository keeps the latest events in the subscription_events table and relays them between the instances
+// with LISTEN/NOTIFY. The repositories writing the subscriptions append the events in the same transactions,
+// every instance, the writing one included, delivers them to its subscribers from Listen.
+type EventRepository struct {
+	*events.Hub
+
+	DB   *pgxpool.Pool
+	QB   goqu.DialectWrapper
+	Size int
+}
+
+func NewEventRepo(db *pgxpool.Pool, qb goqu.DialectWrapper, size int) *EventRepository {
+	return &EventRepository{Hub: events.NewHub(), DB: db, QB: qb, Size: size}
+}
+
+// outbox collects the events of the writes made in a transaction, they are written to the log right before it commits.
+type outbox struct {
+	events []events.Event
+}
+
+// newEvents builds the events of the same type for the written subscriptions.
+func newEvents(typ events.Type, items []*subscriptions.Subscription) []events.Event {
+	res := make([]events.Event, 0, len(items))
+	for _, item := range items {
+		res = append(res, events.New(typ, item))
+	}
+	return res
+}
+
+// write appends the events of a transaction about to commit to the log, the listeners are notified on commit.
+// The lock is held from here to the commit only, a nil repository writes nothing.
+func (repo *EventRepository) write(ctx context.Context, tx pgx.Tx, items []events.Event) error {
+	if repo == nil || len(items) == 0 {
+		return nil
+	}
+
+	lock := repo.QB.Select(goqu.Func("pg_advisory_xact_lock", eventsLock))
+	q, args, _ := lock.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Lock events query")
+
+	if _, err := tx.Exec(ctx, q, args...); err != nil {
+		return err
+	}
+
+	rows := make([]any, 0, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item.Subscription)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, goqu.Record{
+			"type":            string(item.Type),
+			"subscription_id": item.SubscriptionID,
+			"user_id":         item.UserID,
+			"data":            data,
+		})
+	}
+
+	insert := repo.QB.Insert("subscription_events").
+		Rows(rows...).
+		Returning("id")
+	q, args, _ = insert.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Write events query")
+
+	res, err := tx.Query(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	ids, err := pgx.CollectRows(res, pgx.RowTo[int64])
+	if err != nil {
+		return err
+	}
+	last := slices.Max(ids)
+
+	trim := repo.QB.Delete("subscription_events").
+		Where(goqu.C("id").Lte(last - int64(repo.Size)))
+	q, args, _ = trim.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Trim events query")
+
+	if _, err = tx.Exec(ctx, q, args...); err != nil {
+		return err
+	}
+
+	// the notification is sent on commit
+	notify := repo.QB.Select(goqu.Func("pg_notify", eventsChannel, strconv.FormatInt(last, 10)))
+	q, args, _ = notify.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Notify event query")
+
+	_, err = tx.Exec(ctx, q, args...)
+	return err
+}
+
+func (repo *EventRepository) Since(ctx context.Context, id int64) ([]events.Event, error) {
+	return repo.since(ctx, repo.DB, id)
+}
+
+// since reports Expired when the first event after the id is not the next one. The id of a writing
+// transaction that failed to commit leaves a gap as well, the stream is then needlessly reset.
+func (repo *EventRepository) since(ctx context.Context, db Conn, id int64) ([]events.Event, error) {
+	query := repo.QB.From("subscription_events").
+		Select("id", "type", "subscription_id", "user_id", "data").
+		Where(goqu.C("id").Gt(id)).
+		Order(goqu.C("id").Asc())
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Events since query")
+
+	rows, err := db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []events.Event
+	for rows.Next() {
+		var (
+			event events.Event
+			typ   string
+			data  []byte
+		)
+		if err := rows.Scan(&event.ID, &typ, &event.SubscriptionID, &event.UserID, &data); err != nil {
+			return nil, err
+		}
+		event.Type = events.Type(typ)
+		if err := json.Unmarshal(data, &event.Subscription); err != nil {
+			return nil, err
+		}
+		res = append(res, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(res) > 0 && res[0].ID > id+1 {
+		return nil, events.Expired
+	}
+	return res, nil
+}
+
+// Listen delivers the events of every instance to the subscribers of this one until ctx is done.
+// After a lost connection the events published in the meantime are read from the log.
+func (repo *EventRepository) Listen(ctx context.Context) {
+	last := int64(-1)
+	for {
+		err := repo.listen(ctx, &last)
+		if ctx.Err() != nil {
+			return
+		}
+		logrus.WithError(err).Error("failed to listen for subscription events")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetry):
+		}
+	}
+}
+
+func (repo *EventRepository) listen(ctx context.Context, last *int64) error {
+	conn, err := repo.DB.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, "LISTEN "+eventsChannel); err != nil {
+		return err
+	}
+	defer conn.Exec(context.Background(), "UNLISTEN "+eventsChannel)
+
+	if *last < 0 {
+		if *last, err = repo.latest(ctx, conn); err != nil {
+			return err
+		}
+	}
+
+	for {
+		items, err := repo.since(ctx, conn, *last)
+		if errors.Is(err, events.Expired) {
+			// the subscribers resume from the log and learn that they missed events
+			logrus.Warn("subscription events were missed, resetting the streams")
+			repo.Reset()
+			*last, err = repo.latest(ctx, conn)
+		}
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			repo.Broadcast(item)
+			*last = item.ID
+		}
+
+		if _, err = conn.Conn().WaitForNotification(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (repo *EventRepository) latest(ctx context.Context, db Conn) (int64, error) {
+	query := repo.QB.From("subscription_events").Select(goqu.COALESCE(goqu.MAX("id"), 0))
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Latest event query")
+
+	var res int64
+	err := db.QueryRow(ctx, q, args...).Scan(&res)
+	return res, err
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"ew/internal/logging"
+	"ew/internal/models/idempotency"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// reserveAttempts bounds the retries of a reservation whose key is released or expires
+// between the insert and the read of the existing record.
+const reserveAttempts = 3
+
+type IdempotencyRepository struct {
+	DB *pgxpool.Pool
+	QB goqu.DialectWrapper
+}
+
+func NewIdempotencyRepo(db *pgxpool.Pool, qb goqu.DialectWrapper) *IdempotencyRepository {
+	return &IdempotencyRepository{DB: db, QB: qb}
+}
+
+// Reserve relies on the primary key: a concurrent insert of the same key waits for the first one to commit
+// and then takes over the row only if it has expired.
+func (repo *IdempotencyRepository) Reserve(ctx context.Context, key, requestHash string, lease time.Duration) (*idempotency.Record, error) {
+	insert := repo.QB.Insert("idempotency_keys").
+		Rows(goqu.Record{
+			"key":          key,
+			"request_hash": requestHash,
+			"expires_at":   goqu.L("now() + make_interval(secs => ?)", lease.Seconds()),
+		}).
+		OnConflict(goqu.DoUpdate("key", goqu.Record{
+			"request_hash": goqu.L("EXCLUDED.request_hash"),
+			"response":     nil,
+			"expires_at":   goqu.L("EXCLUDED.expires_at"),
+		}).Where(goqu.I("idempotency_keys.expires_at").Lte(goqu.L("now()"))))
+
+	q, args, _ := insert.Prepared(true).ToSQL()
+
+	query := repo.QB.From("idempotency_keys").
+		Select("key", "request_hash", "response", "expires_at").
+		Where(goqu.Ex{"key": key}, goqu.C("expires_at").Gt(goqu.L("now()")))
+
+	selectQ, selectArgs, _ := query.Prepared(true).ToSQL()
+
+	for range reserveAttempts {
+		logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Reserve idempotency key query")
+
+		result, err := repo.DB.Exec(ctx, q, args...)
+		if err != nil {
+			return nil, err
+		}
+		if result.RowsAffected() == 1 {
+			return nil, nil
+		}
+
+		logging.FromContext(ctx).WithFields(logging.QueryFields(selectQ, selectArgs)).Debug("Get idempotency key query")
+
+		record := &idempotency.Record{}
+		err = repo.DB.QueryRow(ctx, selectQ, selectArgs...).Scan(&record.Key, &record.RequestHash, &record.Response, &record.ExpiresAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return idempotency.Check(record, requestHash)
+	}
+	return nil, idempotency.InProgress
+}
+
+func (repo *IdempotencyRepository) Complete(ctx context.Context, key string, response []byte, ttl time.Duration) error {
+	query := repo.QB.Update("idempotency_keys").
+		Set(goqu.Record{
+			"response":   response,
+			"expires_at": goqu.L("now() + make_interval(secs => ?)", ttl.Seconds()),
+		}).
+		Where(goqu.Ex{"key": key})
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Complete idempotency key query")
+
+	_, err := repo.DB.Exec(ctx, q, args...)
+	return err
+}
+
+func (repo *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	query := repo.QB.Delete("idempotency_keys").
+		Where(goqu.Ex{"key": key, "response": nil})
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Release idempotency key query")
+
+	_, err := repo.DB.Exec(ctx, q, args...)
+	return err
+}
+
+func (repo *IdempotencyRepository) Purge(ctx context.Context) (int64, error) {
+	query := repo.QB.Delete("idempotency_keys").
+		Where(goqu.C("expires_at").Lte(goqu.L("now()")))
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Purge idempotency keys query")
+
+	result, err := repo.DB.Exec(ctx, q, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"ew/internal/logging"
+	"ew/internal/models/events"
+	"ew/internal/models/subscriptions"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// loadPauses fills in the pause history of the given subscriptions.
+func loadPauses(ctx context.Context, qb goqu.DialectWrapper, db querier, items []*subscriptions.Subscription) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*subscriptions.Subscription, len(items))
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+		ids = append(ids, item.ID)
+	}
+
+	query := qb.From("subscription_pauses").
+		Select("id", "subscription_id", "start_date", "resume_date").
+		Where(goqu.C("subscription_id").In(ids)).
+		Order(goqu.C("start_date").Asc())
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("loadPauses query")
+
+	rows, err := db.Query(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pause subscriptions.Pause
+		err = rows.Scan(&pause.ID, &pause.SubscriptionId, &pause.StartDate, &pause.ResumeDate)
+		if err != nil {
+			return err
+		}
+		item := byID[pause.SubscriptionId]
+		item.Pauses = append(item.Pauses, pause)
+	}
+	return rows.Err()
+}
+
+// lockSubscription reads the subscription with its pauses, locking it until the end of the transaction.
+func (repo *SubscriptionRepository) lockSubscription(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*subscriptions.Subscription, error) {
+	query := repo.QB.From("subscriptions").
+		Select(subscriptionColumns...).
+		Where(goqu.Ex{"id": id}).
+		ForUpdate(exp.Wait)
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("lockSubscription query")
+
+	subscription, err := scanSubscription(tx.QueryRow(ctx, q, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, subscriptions.NotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadPauses(ctx, repo.QB, tx, []*subscriptions.Subscription{subscription})
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// recordPauses records the subscription with the pause history as changed by the transaction.
+func (repo *SubscriptionRepository) recordPauses(ctx context.Context, tx pgx.Tx, subscription *subscriptions.Subscription) error {
+	if repo.Events == nil {
+		return nil
+	}
+
+	subscription.Pauses = nil
+	err := loadPauses(ctx, repo.QB, tx, []*subscriptions.Subscription{subscription})
+	if err != nil {
+		return err
+	}
+	return repo.record(ctx, tx, events.Updated, subscription)
+}
+
+func (repo *SubscriptionRepository) Pause(ctx context.Context, id uuid.UUID, start time.Time) error {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.Pause")
+	defer span.End()
+
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	subscription, err := repo.lockSubscription(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if err = subscription.CheckPause(start); err != nil {
+		return err
+	}
+
+	query := repo.QB.Insert("subscription_pauses").
+		Rows(subscriptions.Pause{SubscriptionId: id, StartDate: start})
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Pause query")
+
+	if _, err = tx.Exec(ctx, q, args...); err != nil {
+		return err
+	}
+	if err = repo.refreshSpend(ctx, tx, subscription.UserId); err != nil {
+		return err
+	}
+	if err = repo.recordPauses(ctx, tx, subscription); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (repo *SubscriptionRepository) Resume(ctx context.Context, id uuid.UUID, resume time.Time) error {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.Resume")
+	defer span.End()
+
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	subscription, err := repo.lockSubscription(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if err = subscription.CheckResume(resume); err != nil {
+		return err
+	}
+
+	query := repo.QB.Update("subscription_pauses").
+		Set(goqu.Record{"resume_date": resume}).
+		Where(goqu.Ex{"id": subscription.OpenPause().ID})
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Resume query")
+
+	if _, err = tx.Exec(ctx, q, args...); err != nil {
+		return err
+	}
+	if err = repo.refreshSpend(ctx, tx, subscription.UserId); err != nil {
+		return err
+	}
+	if err = repo.recordPauses(ctx, tx, subscription); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -4,63 +4,256 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"ew/internal/database"
+	"ew/internal/logging"
+	"ew/internal/models/events"
 	"ew/internal/models/subscriptions"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+)
+
+// tracer wraps every SubscriptionRepository method in a span, the queries under it are recorded by tracing.QueryTracer.
+var tracer = otel.Tracer("ew/internal/storage/postgres")
+
+// Conn is the part of pgxpool.Pool the repository uses. A pgx.Tx satisfies it as well,
+// the repository then runs inside the transaction and its own Begin makes a savepoint.
+type Conn interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+const (
+	checkViolation = "23514"
+	// periodCheck is the constraint keeping end_date from preceding start_date
+	periodCheck = "subscriptions_period_check"
 )
 
 type SubscriptionRepository struct {
-	DB *pgxpool.Pool
+	DB Conn
 	QB goqu.DialectWrapper
+
+	// tx is set on the repository WithTx passes on, DB is then the same transaction
+	tx pgx.Tx
+	// batch collects the users whose spend the writes of a batch changed, they are refreshed once before it commits
+	batch *spendRefresh
+	// Events is the log the writes append their events to, none are recorded without it
+	Events *EventRepository
+	// outbox collects the events of the transaction WithTx runs, set along with tx
+	outbox *outbox
+}
+
+// record writes the events of a write to the log in its transaction,
+// or leaves them to the end of the transaction the repository runs in.
+func (repo *SubscriptionRepository) record(ctx context.Context, tx pgx.Tx, typ events.Type, items ...*subscriptions.Subscription) error {
+	if repo.Events == nil {
+		return nil
+	}
+
+	pending := newEvents(typ, items)
+	if repo.outbox != nil {
+		repo.outbox.events = append(repo.outbox.events, pending...)
+		return nil
+	}
+	return repo.Events.write(ctx, tx, pending)
 }
 
 func NewRepo(db *pgxpool.Pool, qb goqu.DialectWrapper) *SubscriptionRepository {
 	return &SubscriptionRepository{DB: db, QB: qb}
 }
 
-func (repo *SubscriptionRepository) GetStats(ctx context.Context, params subscriptions.SubscriptionListParams) (int, error) {
-	if params.StartDate != nil && params.StartDate.After(time.Now()) {
-		return 0, nil
+var subscriptionColumns = []any{"id", "service_id", "service_name", "price", "user_id", "start_date", "end_date", "trial_end_date", "category", "tags"}
+
+// monthSpan counts the months from first to last inclusive, or zero if last is before first.
+func monthSpan(first, last string) string {
+	return fmt.Sprintf(
+		"GREATEST((extract(year from %[2]s) - extract(year from %[1]s)) * 12 + extract(month from %[2]s) - extract(month from %[1]s) + 1, 0)",
+		first, last,
+	)
+}
+
+// currentMonth is the last month of the subscriptions without an end date. It is passed to the queries
+// rather than computed with now(), which would follow the time zone of the database session.
+func currentMonth() time.Time {
+	return subscriptions.MonthStart(time.Now())
+}
+
+// costExpr multiplies the price by the number of months between the bounds joined by withBilling,
+// less the paused months.
+var costExpr = "subscriptions.price * (" + monthSpan("billing.first_month", "billing.last_month") + " - paused.months)"
+
+// chargedInMonth matches the subscription under the given alias against months.month the same way as withBilling:
+// after the trial, until the end date and outside of the pauses.
+func chargedInMonth(table string) exp.LiteralExpression {
+	return goqu.L(fmt.Sprintf(`months.month >= GREATEST(%[1]s.start_date, (%[1]s.trial_end_date + INTERVAL '1 month')::date)
+	AND (%[1]s.end_date IS NULL OR months.month <= %[1]s.end_date)
+	AND NOT EXISTS (
+		SELECT 1 FROM subscription_pauses AS pause
+		WHERE pause.subscription_id = %[1]s.id
+			AND pause.start_date <= months.month
+			AND (pause.resume_date IS NULL OR pause.resume_date > months.month)
+	)`, table))
+}
+
+// serviceKey identifies the service of the subscription under the given alias, like Subscription.ServiceKey.
+func serviceKey(table string) exp.LiteralExpression {
+	return goqu.L(fmt.Sprintf("COALESCE(%[1]s.service_id::text, lower(trim(%[1]s.service_name)))", table))
+}
+
+func col(name string) exp.IdentifierExpression {
+	return goqu.T("subscriptions").Col(name)
+}
+
+func scanSubscription(row pgx.Row) (*subscriptions.Subscription, error) {
+	subscription := &subscriptions.Subscription{}
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.ServiceId,
+		&subscription.ServiceName,
+		&subscription.Price,
+		&subscription.UserId,
+		&subscription.StartDate,
+		&subscription.EndDate,
+		&subscription.TrialEndDate,
+		&subscription.Category,
+		&subscription.Tags,
+	)
+	if err != nil {
+		return nil, err
 	}
+	return subscription, nil
+}
 
-	query := repo.QB.From("subscriptions").
-		Select(goqu.SUM(goqu.L(
-			"price * (extract(year from age(CASE WHEN end_date IS NULL THEN now() ELSE end_date END, start_date - INTERVAL '1 month')) * 12 + extract(month from age(CASE WHEN end_date IS NULL THEN now() ELSE end_date END, start_date - INTERVAL '1 month')))",
-		)).As("total"))
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
 
-	if params.ServiceName != nil {
-		query = query.Where(goqu.Ex{"service_name": params.ServiceName})
+func applyFilters(query *goqu.SelectDataset, params subscriptions.SubscriptionListParams) *goqu.SelectDataset {
+	if len(params.ServiceNames) > 0 {
+		query = query.Where(col("service_name").In(params.ServiceNames))
 	}
 
-	if params.UserId != nil {
-		query = query.Where(goqu.Ex{"user_id": params.UserId})
+	if params.ServiceNamePrefix != nil {
+		query = query.Where(col("service_name").ILike(likeEscaper.Replace(*params.ServiceNamePrefix) + "%"))
 	}
 
-	if params.EndDate == nil {
-		now := time.Now()
-		y, m, _ := now.Date()
-		end := time.Date(y, m, 1, 0, 0, 0, 0, now.Location())
-		params.EndDate = &end
+	if len(params.UserIds) > 0 {
+		query = query.Where(col("user_id").In(params.UserIds))
+	}
+
+	if len(params.Categories) > 0 {
+		query = query.Where(col("category").In(params.Categories))
+	}
+
+	if len(params.Tags) > 0 {
+		query = query.Where(goqu.L("? @> ?", col("tags"), database.TextArray(params.Tags)))
+	}
+
+	if params.PriceMin != nil {
+		query = query.Where(col("price").Gte(*params.PriceMin))
+	}
+
+	if params.PriceMax != nil {
+		query = query.Where(col("price").Lte(*params.PriceMax))
+	}
+
+	month := subscriptions.MonthStart(time.Now())
+
+	if params.Status != nil {
+		switch *params.Status {
+		case subscriptions.StatusActive:
+			query = query.Where(
+				col("start_date").Lte(month),
+				goqu.Or(col("end_date").IsNull(), col("end_date").Gte(month)),
+			)
+		case subscriptions.StatusEnded:
+			query = query.Where(col("end_date").Lt(month))
+		case subscriptions.StatusFuture:
+			query = query.Where(col("start_date").Gt(month))
+		case subscriptions.StatusTrial:
+			query = query.Where(
+				col("start_date").Lte(month),
+				col("trial_end_date").Gte(month),
+				goqu.Or(col("end_date").IsNull(), col("end_date").Gte(month)),
+			)
+		}
+	}
+
+	// status is already relative to the current month, so it replaces the default period bound
+	if params.EndDate == nil && params.Status == nil {
+		params.EndDate = &month
+	}
+
+	if params.EndDate != nil {
+		query = query.Where(col("start_date").Lt(params.EndDate))
 	}
 
 	if params.StartDate != nil {
-		query = query.Where(goqu.And(
-			goqu.C("start_date").Lt(params.EndDate),
-			goqu.Or(
-				goqu.C("end_date").IsNull(),
-				goqu.C("end_date").Gte(params.StartDate),
-			),
+		query = query.Where(goqu.Or(
+			col("end_date").IsNull(),
+			col("end_date").Gte(params.StartDate),
 		))
-	} else {
-		query = query.Where(goqu.C("start_date").Lt(params.EndDate))
 	}
 
+	return query
+}
+
+// withBilling joins the first and the last month each subscription is paid for within the requested period,
+// skipping the trial months, and the number of paused months between them.
+func (repo *SubscriptionRepository) withBilling(query *goqu.SelectDataset, params subscriptions.SubscriptionListParams) *goqu.SelectDataset {
+	billing := repo.QB.Select(
+		goqu.L(
+			"GREATEST(subscriptions.start_date, (subscriptions.trial_end_date + INTERVAL '1 month')::date, ?::date)",
+			params.StartDate,
+		).As("first_month"),
+		goqu.L(
+			"LEAST(COALESCE(subscriptions.end_date, ?::date), ?::date)",
+			currentMonth(), params.EndDate,
+		).As("last_month"),
+	)
+
+	// pauses are clipped to the billing bounds, so the trial and the months outside of the period are not subtracted twice
+	paused := repo.QB.From(goqu.T("subscription_pauses").As("pause")).
+		CrossJoin(goqu.Lateral(repo.QB.Select(
+			goqu.L("GREATEST(pause.start_date, billing.first_month)").As("first_month"),
+			goqu.L("LEAST((pause.resume_date - INTERVAL '1 month')::date, billing.last_month)").As("last_month"),
+		)).As("bounds")).
+		Select(goqu.L("COALESCE(SUM(" + monthSpan("bounds.first_month", "bounds.last_month") + "), 0)").As("months")).
+		Where(goqu.L("pause.subscription_id = subscriptions.id"))
+
+	return query.
+		CrossJoin(goqu.Lateral(billing).As("billing")).
+		CrossJoin(goqu.Lateral(paused).As("paused"))
+}
+
+func (repo *SubscriptionRepository) GetStats(ctx context.Context, params subscriptions.SubscriptionListParams) (int, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.GetStats")
+	defer span.End()
+
+	if params.StartDate != nil && params.StartDate.After(time.Now()) {
+		return 0, nil
+	}
+
+	if usesRollup(params) {
+		return repo.getRolledUpStats(ctx, params)
+	}
+
+	query := repo.QB.From("subscriptions").
+		Select(goqu.COALESCE(goqu.SUM(goqu.L(costExpr)), 0).As("total"))
+
+	query = repo.withBilling(query, params)
+	query = applyFilters(query, params)
+
 	q, args, _ := query.Prepared(true).ToSQL()
-	logrus.WithFields(logrus.Fields{"query": q, "args": args}).Debug("GetStat query")
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("GetStat query")
 
 	var total int
 	err := repo.DB.
@@ -74,41 +267,282 @@ func (repo *SubscriptionRepository) GetStats(ctx context.Context, params subscri
 	return total, nil
 }
 
-func (repo *SubscriptionRepository) GetList(ctx context.Context, params subscriptions.SubscriptionListParams) ([]*subscriptions.Subscription, error) {
+func (repo *SubscriptionRepository) GetGroupedStats(ctx context.Context, params subscriptions.SubscriptionListParams, groupBy subscriptions.GroupBy) ([]*subscriptions.StatsGroup, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.GetGroupedStats")
+	defer span.End()
+
 	if params.StartDate != nil && params.StartDate.After(time.Now()) {
-		return []*subscriptions.Subscription{}, nil
+		return []*subscriptions.StatsGroup{}, nil
+	}
+
+	var key, name exp.Aliaseable
+
+	switch groupBy {
+	case subscriptions.GroupByService:
+		key = serviceKey("subscriptions")
+		name = goqu.MIN(goqu.COALESCE(goqu.T("services").Col("name"), col("service_name")))
+	case subscriptions.GroupByCategory:
+		key = goqu.COALESCE(col("category"), "")
+		name = goqu.MIN(goqu.COALESCE(col("category"), ""))
+	default:
+		return nil, fmt.Errorf("unsupported stats grouping %q", groupBy)
 	}
 
 	query := repo.QB.From("subscriptions").
-		Select("id", "service_name", "price", "user_id", "start_date", "end_date")
+		LeftJoin(goqu.T("services"), goqu.On(goqu.T("services").Col("id").Eq(col("service_id")))).
+		Select(
+			key.As("key"),
+			name.As("name"),
+			goqu.SUM(goqu.L(costExpr)).As("total"),
+		).
+		GroupBy(goqu.C("key")).
+		Order(goqu.C("total").Desc(), goqu.C("name").Asc())
+
+	query = repo.withBilling(query, params)
+	query = applyFilters(query, params)
 
-	if params.ServiceName != nil {
-		query = query.Where(goqu.Ex{"service_name": params.ServiceName})
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("GetGroupedStats query")
+
+	rows, err := repo.DB.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	if params.UserId != nil {
-		query = query.Where(goqu.Ex{"user_id": params.UserId})
+	groups := make([]*subscriptions.StatsGroup, 0)
+	for rows.Next() {
+		group := &subscriptions.StatsGroup{}
+		err = rows.Scan(&group.Key, &group.Name, &group.TotalPrice)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
 	}
+	return groups, rows.Err()
+}
+
+func (repo *SubscriptionRepository) GetTop(ctx context.Context, params subscriptions.TopParams) ([]*subscriptions.TopItem, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.GetTop")
+	defer span.End()
 
-	if params.EndDate == nil {
-		now := time.Now()
-		y, m, _ := now.Date()
-		end := time.Date(y, m, 1, 0, 0, 0, 0, now.Location())
-		params.EndDate = &end
+	if params.Filter.StartDate != nil && params.Filter.StartDate.After(time.Now()) {
+		return []*subscriptions.TopItem{}, nil
 	}
 
-	if params.StartDate != nil {
-		query = query.Where(goqu.And(
-			goqu.C("start_date").Lt(params.EndDate),
-			goqu.Or(
-				goqu.C("end_date").IsNull(),
-				goqu.C("end_date").Gte(params.StartDate),
-			),
-		))
-	} else {
-		query = query.Where(goqu.C("start_date").Lt(params.EndDate))
+	var key, name exp.Aliaseable
+
+	switch params.Dimension {
+	case subscriptions.DimensionServiceName:
+		key = serviceKey("subscriptions")
+		name = goqu.MIN(goqu.COALESCE(goqu.T("services").Col("name"), col("service_name")))
+	case subscriptions.DimensionUserId:
+		key = goqu.L("subscriptions.user_id::text")
+		name = goqu.MIN(goqu.L("subscriptions.user_id::text"))
+	case subscriptions.DimensionCategory:
+		key = goqu.COALESCE(col("category"), "")
+		name = goqu.MIN(goqu.COALESCE(col("category"), ""))
+	default:
+		return nil, fmt.Errorf("unsupported top dimension %q", params.Dimension)
+	}
+
+	var value exp.Aliaseable
+
+	switch params.Metric {
+	case subscriptions.MetricTotal:
+		value = goqu.L("SUM(" + costExpr + ")")
+	case subscriptions.MetricRunRate:
+		value = goqu.L("COALESCE(SUM(subscriptions.price) FILTER (WHERE ?), 0)", chargedInMonth("subscriptions"))
+	case subscriptions.MetricCount:
+		value = goqu.COUNT(goqu.Star())
+	default:
+		return nil, fmt.Errorf("unsupported top metric %q", params.Metric)
+	}
+
+	query := repo.QB.From("subscriptions").
+		LeftJoin(goqu.T("services"), goqu.On(goqu.T("services").Col("id").Eq(col("service_id")))).
+		CrossJoin(goqu.Lateral(repo.QB.Select(goqu.L("?::date", params.RunRateMonth()).As("month"))).As("months")).
+		Select(
+			goqu.L("RANK() OVER (ORDER BY ? DESC)", value).As("rank"),
+			key.As("key"),
+			name.As("name"),
+			value.As("value"),
+		).
+		GroupBy(goqu.C("key")).
+		Having(goqu.L("? > 0", value)).
+		Order(goqu.C("rank").Asc(), goqu.C("name").Asc()).
+		Limit(uint(params.Limit))
+
+	query = repo.withBilling(query, params.Filter)
+	query = applyFilters(query, params.Filter)
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("GetTop query")
+
+	rows, err := repo.DB.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*subscriptions.TopItem, 0)
+	for rows.Next() {
+		item := &subscriptions.TopItem{}
+		err = rows.Scan(&item.Rank, &item.Key, &item.Name, &item.Value)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (repo *SubscriptionRepository) GetForecast(ctx context.Context, params subscriptions.ForecastParams) ([]*subscriptions.MonthTotal, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.GetForecast")
+	defer span.End()
+
+	filters := params.Filter
+	afterEnd := params.EndDate.AddDate(0, 1, 0)
+	filters.StartDate, filters.EndDate = &params.StartDate, &afterEnd
+
+	selected := applyFilters(repo.QB.From("subscriptions").Select(col("id"), col("price"), col("start_date"), col("end_date"), col("trial_end_date")), filters)
+	if len(params.Cancel) > 0 {
+		selected = selected.Where(col("id").NotIn(params.Cancel))
+	}
+
+	query := repo.QB.From(goqu.L("generate_series(?::date, ?::date, INTERVAL '1 month') AS months(month)", params.StartDate, params.EndDate)).
+		LeftJoin(selected.As("subscriptions"), goqu.On(chargedInMonth("subscriptions"))).
+		Select(
+			goqu.L("months.month::date"),
+			goqu.L("(COALESCE(SUM(subscriptions.price), 0) * ? + 50) / 100", 100+params.PriceChangePercent),
+		).
+		GroupBy(goqu.L("months.month")).
+		Order(goqu.L("months.month").Asc())
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("GetForecast query")
+
+	rows, err := repo.DB.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*subscriptions.MonthTotal, 0)
+	for rows.Next() {
+		month := &subscriptions.MonthTotal{}
+		err = rows.Scan(&month.Month, &month.TotalPrice)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, month)
+	}
+	return res, rows.Err()
+}
+
+func (repo *SubscriptionRepository) GetUserSummary(ctx context.Context, userId uuid.UUID) (*subscriptions.UserSummary, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.GetUserSummary")
+	defer span.End()
+
+	res, err := repo.userSummaries(ctx, []uuid.UUID{userId})
+	if err != nil {
+		return nil, err
 	}
+	return res[userId], nil
+}
+
+func (repo *SubscriptionRepository) GetUserSummaries(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID]*subscriptions.UserSummary, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.GetUserSummaries")
+	defer span.End()
+
+	return repo.userSummaries(ctx, userIds)
+}
+
+// userSummaries computes the summaries in one query grouped by user, the users without subscriptions get empty ones.
+func (repo *SubscriptionRepository) userSummaries(ctx context.Context, userIds []uuid.UUID) (map[uuid.UUID]*subscriptions.UserSummary, error) {
+	res := make(map[uuid.UUID]*subscriptions.UserSummary, len(userIds))
+	for _, userId := range userIds {
+		res[userId] = &subscriptions.UserSummary{}
+	}
+	if len(userIds) == 0 {
+		return res, nil
+	}
+
+	month := subscriptions.MonthStart(time.Now())
+	yearAgo := month.AddDate(0, -11, 0)
+	active := goqu.L("subscriptions.start_date <= ? AND (subscriptions.end_date IS NULL OR subscriptions.end_date >= ?)", month, month)
+
+	// every subscription is expanded into the months it is paid for up to the current one
+	spend := repo.QB.From(goqu.L("generate_series(subscriptions.start_date, ?::date, INTERVAL '1 month') AS months(month)", month)).
+		Select(
+			goqu.L("COALESCE(SUM(subscriptions.price) FILTER (WHERE months.month = ?), 0)", month).As("current"),
+			goqu.L("COALESCE(SUM(subscriptions.price), 0)").As("lifetime"),
+			goqu.L("COALESCE(SUM(subscriptions.price) FILTER (WHERE months.month >= ?), 0)", yearAgo).As("last_year"),
+		).
+		Where(chargedInMonth("subscriptions"))
+
+	query := repo.QB.From("subscriptions").
+		CrossJoin(goqu.Lateral(spend).As("spend")).
+		Select(
+			col("user_id"),
+			goqu.L("COUNT(*) FILTER (WHERE ?)", active),
+			goqu.L("COALESCE(SUM(spend.current), 0)"),
+			goqu.L("COALESCE(SUM(spend.lifetime), 0)"),
+			goqu.L("COALESCE(SUM(spend.last_year), 0)"),
+			goqu.L("(array_agg(subscriptions.service_name ORDER BY subscriptions.price DESC, subscriptions.service_name) FILTER (WHERE ?))[1]", active),
+			goqu.L("MAX(subscriptions.price) FILTER (WHERE ?)", active),
+			goqu.L("MIN(subscriptions.end_date) FILTER (WHERE subscriptions.end_date >= ?)", month),
+		).
+		Where(col("user_id").In(userIds)).
+		GroupBy(col("user_id"))
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("GetUserSummaries query")
+
+	rows, err := repo.DB.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userId uuid.UUID
+		summary := &subscriptions.UserSummary{}
+		err = rows.Scan(
+			&userId,
+			&summary.ActiveCount,
+			&summary.MonthlyRunRate,
+			&summary.LifetimeSpend,
+			&summary.LastYearSpend,
+			&summary.TopServiceName,
+			&summary.TopServicePrice,
+			&summary.NextEndDate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		res[userId] = summary
+	}
+	return res, rows.Err()
+}
+
+func (repo *SubscriptionRepository) GetList(ctx context.Context, params subscriptions.SubscriptionListParams) ([]*subscriptions.Subscription, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.GetList")
+	defer span.End()
+
+	if params.StartDate != nil && params.StartDate.After(time.Now()) {
+		return []*subscriptions.Subscription{}, nil
+	}
+
+	query := repo.QB.From("subscriptions").
+		Select(subscriptionColumns...)
+
+	query = applyFilters(query, params)
 
+	// pages must not overlap, which needs a stable order
+	if params.Limit != nil || params.Offset != nil {
+		query = query.Order(col("id").Asc())
+	}
 	if params.Limit != nil {
 		query = query.Limit(uint(*params.Limit))
 	}
@@ -117,7 +551,7 @@ func (repo *SubscriptionRepository) GetList(ctx context.Context, params subscrip
 	}
 
 	q, args, _ := query.Prepared(true).ToSQL()
-	logrus.WithFields(logrus.Fields{"query": q, "args": args}).Debug("GetList query")
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("GetList query")
 
 	rows, err := repo.DB.Query(ctx, q, args...)
 	if err != nil {
@@ -127,95 +561,220 @@ func (repo *SubscriptionRepository) GetList(ctx context.Context, params subscrip
 
 	items := make([]*subscriptions.Subscription, 0)
 	for rows.Next() {
-		subscription := &subscriptions.Subscription{}
-		err = rows.Scan(
-			&subscription.ID,
-			&subscription.ServiceName,
-			&subscription.Price,
-			&subscription.UserId,
-			&subscription.StartDate,
-			&subscription.EndDate,
-		)
+		subscription, err := scanSubscription(rows)
 		if err != nil {
 			return nil, err
 		}
 		items = append(items, subscription)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	err = loadPauses(ctx, repo.QB, repo.DB, items)
+	if err != nil {
+		return nil, err
+	}
 	return items, nil
 }
 
 func (repo *SubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*subscriptions.Subscription, error) {
-	subscription := &subscriptions.Subscription{}
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.GetByID")
+	defer span.End()
+
+	if repo.tx != nil {
+		return repo.lockSubscription(ctx, repo.tx, id)
+	}
 
 	query := repo.QB.From("subscriptions").
-		Select("id", "service_name", "price", "user_id", "start_date", "end_date").
+		Select(subscriptionColumns...).
 		Where(goqu.Ex{"id": id})
 
 	q, args, _ := query.Prepared(true).ToSQL()
-	logrus.WithFields(logrus.Fields{"query": q, "args": args}).Debug("GetByID query")
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("GetByID query")
 
-	err := repo.DB.
-		QueryRow(ctx, q, args...).
-		Scan(
-			&subscription.ID,
-			&subscription.ServiceName,
-			&subscription.Price,
-			&subscription.UserId,
-			&subscription.StartDate,
-			&subscription.EndDate,
-		)
+	subscription, err := scanSubscription(repo.DB.QueryRow(ctx, q, args...))
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, subscriptions.NotFound
 	}
 	if err != nil {
 		return nil, err
 	}
+
+	err = loadPauses(ctx, repo.QB, repo.DB, []*subscriptions.Subscription{subscription})
+	if err != nil {
+		return nil, err
+	}
 	return subscription, nil
 }
 
 func (repo *SubscriptionRepository) Add(ctx context.Context, elem *subscriptions.Subscription) (uuid.UUID, error) {
-	var newID uuid.UUID
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.Add")
+	defer span.End()
+
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if elem.ServiceId == nil {
+		price := elem.Price
+		service, linked, err := ensureService(ctx, repo.QB, tx, elem.ServiceName, &price)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+		err = repo.record(ctx, tx, events.Updated, linked...)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+		elem.ServiceId = &service.ID
+		elem.ServiceName = service.Name
+	}
 
 	query := repo.QB.Insert("subscriptions").
 		Rows(elem).
-		Returning("id")
+		Returning(subscriptionColumns...)
 
 	q, args, _ := query.Prepared(true).ToSQL()
-	logrus.WithFields(logrus.Fields{"query": q, "args": args}).Debug("Add query")
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Add query")
 
-	err := repo.DB.QueryRow(ctx, q, args...).Scan(&newID)
+	created, err := scanSubscription(tx.QueryRow(ctx, q, args...))
+	if err != nil {
+		return uuid.UUID{}, convertSubscriptionError(err)
+	}
+
+	err = repo.refreshSpend(ctx, tx, elem.UserId)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	err = repo.record(ctx, tx, events.Created, created)
 	if err != nil {
 		return uuid.UUID{}, err
 	}
-	return newID, nil
+	return created.ID, tx.Commit(ctx)
 }
 
 func (repo *SubscriptionRepository) Update(ctx context.Context, elem *subscriptions.SubscriptionPatch) (int64, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.Update")
+	defer span.End()
+
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// the subscription may move to another user, so the spend of both is refreshed
+	var oldUserId uuid.UUID
+	lock := repo.QB.From("subscriptions").
+		Select("user_id").
+		Where(goqu.Ex{"id": elem.ID}).
+		ForUpdate(exp.Wait)
+
+	q, args, _ := lock.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Update lock query")
+
+	err = tx.QueryRow(ctx, q, args...).Scan(&oldUserId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// a name without an id is new to the catalog, the default price stays unknown without a price
+	if elem.ServiceName != nil && elem.ServiceId == nil {
+		service, linked, err := ensureService(ctx, repo.QB, tx, *elem.ServiceName, elem.Price)
+		if err != nil {
+			return 0, err
+		}
+		err = repo.record(ctx, tx, events.Updated, linked...)
+		if err != nil {
+			return 0, err
+		}
+		elem.ServiceId = &service.ID
+		elem.ServiceName = &service.Name
+	}
+
+	// the omitted fields of the patch are kept, the cleared ones are set to NULL
+	record, err := exp.NewRecordFromStruct(*elem, false, true)
+	if err != nil {
+		return 0, err
+	}
+	for _, field := range elem.Clear {
+		record[string(field)] = nil
+	}
+
 	query := repo.QB.Update("subscriptions").
 		Where(goqu.Ex{"id": elem.ID}).
-		Set(elem)
+		Set(record).
+		Returning(subscriptionColumns...)
 
-	q, args, _ := query.Prepared(true).ToSQL()
-	logrus.WithFields(logrus.Fields{"query": q, "args": args}).Debug("Update query")
+	q, args, _ = query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Update query")
+
+	updated, err := scanSubscription(tx.QueryRow(ctx, q, args...))
+	if err != nil {
+		return 0, convertSubscriptionError(err)
+	}
 
-	result, err := repo.DB.Exec(ctx, q, args...)
+	err = repo.refreshSpend(ctx, tx, oldUserId, updated.UserId)
 	if err != nil {
 		return 0, err
 	}
-	return result.RowsAffected(), nil
+	err = loadPauses(ctx, repo.QB, tx, []*subscriptions.Subscription{updated})
+	if err != nil {
+		return 0, err
+	}
+	err = repo.record(ctx, tx, events.Updated, updated)
+	if err != nil {
+		return 0, err
+	}
+	return 1, tx.Commit(ctx)
+}
+
+func convertSubscriptionError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == checkViolation && pgErr.ConstraintName == periodCheck {
+		return subscriptions.InvalidPeriod
+	}
+	return err
 }
 
 func (repo *SubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) (int64, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.Delete")
+	defer span.End()
+
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := repo.QB.Delete("subscriptions").
-		Where(goqu.Ex{"id": id})
+		Where(goqu.Ex{"id": id}).
+		Returning(subscriptionColumns...)
 
 	q, args, _ := query.Prepared(true).ToSQL()
-	logrus.WithFields(logrus.Fields{"query": q, "args": args}).Debug("Delete query")
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Delete query")
 
-	result, err := repo.DB.Exec(ctx, q, args...)
+	deleted, err := scanSubscription(tx.QueryRow(ctx, q, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
 
+	err = repo.refreshSpend(ctx, tx, deleted.UserId)
+	if err != nil {
+		return 0, err
+	}
+	err = repo.record(ctx, tx, events.Deleted, deleted)
 	if err != nil {
 		return 0, err
 	}
-	return result.RowsAffected(), nil
+	return 1, tx.Commit(ctx)
 }
@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+	"slices"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// The monthly_spend table keeps the amount paid per user, service name, start month and month,
+// so GetStats doesn't have to expand every subscription into months on each call.
+// It is refreshed for the affected users in the same transaction as every write,
+// and the months after the end of the open subscriptions are appended by RefreshMonthlySpend
+// as the current month moves forward.
+
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// SpendMismatch is a user and service whose rolled up spend differs from the raw computation.
+type SpendMismatch struct {
+	UserId      uuid.UUID
+	ServiceName string
+	Expected    int
+	Actual      int
+}
+
+// spendRows expands the subscriptions into the monthly_spend rows, using the same month rules as GetStats.
+func spendRows(qb goqu.DialectWrapper) *goqu.SelectDataset {
+	return qb.From("subscriptions").
+		CrossJoin(goqu.L("generate_series(subscriptions.start_date, COALESCE(subscriptions.end_date, ?::date), INTERVAL '1 month') AS months(month)", currentMonth())).
+		Select(
+			col("user_id"),
+			col("service_name"),
+			goqu.L("date_trunc('month', subscriptions.start_date)::date"),
+			goqu.L("months.month::date"),
+			goqu.SUM(col("price")),
+		).
+		Where(chargedInMonth("subscriptions")).
+		GroupBy(goqu.L("1"), goqu.L("2"), goqu.L("3"), goqu.L("4"))
+}
+
+func insertSpend(ctx context.Context, qb goqu.DialectWrapper, db execer, rows *goqu.SelectDataset, msg string) error {
+	query := qb.Insert("monthly_spend").
+		Cols("user_id", "service_name", "start_month", "month", "amount").
+		FromQuery(rows)
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug(msg)
+
+	_, err := db.Exec(ctx, q, args...)
+	return err
+}
+
+// refreshSpend recomputes the monthly_spend rows of the given users inside the transaction of a write.
+func refreshSpend(ctx context.Context, qb goqu.DialectWrapper, tx pgx.Tx, userIds ...uuid.UUID) error {
+	userIds = slices.Compact(slices.SortedFunc(slices.Values(userIds), func(a, b uuid.UUID) int {
+		return slices.Compare(a[:], b[:])
+	}))
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	// concurrent writes of the same user would otherwise insert the same rows twice
+	for _, id := range userIds {
+		_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtextextended($1, 0))", id.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	query := qb.Delete("monthly_spend").
+		Where(goqu.C("user_id").In(userIds))
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("refreshSpend delete query")
+
+	if _, err := tx.Exec(ctx, q, args...); err != nil {
+		return err
+	}
+
+	rows := spendRows(qb).Where(col("user_id").In(userIds))
+	return insertSpend(ctx, qb, tx, rows, "refreshSpend insert query")
+}
+
+// refreshSpend refreshes the spend of the users in the transaction of a write,
+// or leaves it to the batch the repository runs in.
+func (repo *SubscriptionRepository) refreshSpend(ctx context.Context, tx pgx.Tx, userIds ...uuid.UUID) error {
+	if repo.batch != nil {
+		repo.batch.userIds = append(repo.batch.userIds, userIds...)
+		return nil
+	}
+	return refreshSpend(ctx, repo.QB, tx, userIds...)
+}
+
+// RefreshMonthlySpend recomputes the monthly_spend rows from the given month on,
+// which adds the current month of the open subscriptions once it starts.
+func (repo *SubscriptionRepository) RefreshMonthlySpend(ctx context.Context, from time.Time) error {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.RefreshMonthlySpend")
+	defer span.End()
+
+	from = subscriptions.MonthStart(from)
+
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// blocks the writes refreshing single users until the range is rebuilt
+	if _, err = tx.Exec(ctx, "LOCK TABLE monthly_spend IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+		return err
+	}
+
+	query := repo.QB.Delete("monthly_spend").
+		Where(goqu.C("month").Gte(from))
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("RefreshMonthlySpend delete query")
+
+	if _, err = tx.Exec(ctx, q, args...); err != nil {
+		return err
+	}
+
+	rows := spendRows(repo.QB).Where(goqu.L("months.month >= ?", from))
+	if err = insertSpend(ctx, repo.QB, tx, rows, "RefreshMonthlySpend insert query"); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// RebuildMonthlySpend recomputes the whole monthly_spend table.
+func (repo *SubscriptionRepository) RebuildMonthlySpend(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.RebuildMonthlySpend")
+	defer span.End()
+
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, "TRUNCATE monthly_spend"); err != nil {
+		return err
+	}
+	if err = insertSpend(ctx, repo.QB, tx, spendRows(repo.QB), "RebuildMonthlySpend query"); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// CheckMonthlySpend compares the rolled up spend of every user and service with the raw computation GetStats used to run.
+func (repo *SubscriptionRepository) CheckMonthlySpend(ctx context.Context) ([]*SpendMismatch, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.CheckMonthlySpend")
+	defer span.End()
+
+	raw := repo.withBilling(repo.QB.From("subscriptions"), subscriptions.SubscriptionListParams{}).
+		Select(
+			col("user_id"),
+			col("service_name"),
+			goqu.SUM(goqu.L(costExpr)).As("amount"),
+		).
+		GroupBy(col("user_id"), col("service_name"))
+
+	rollup := repo.QB.From("monthly_spend").
+		Select("user_id", "service_name", goqu.SUM("amount").As("amount")).
+		GroupBy("user_id", "service_name")
+
+	query := repo.QB.From(raw.As("raw")).
+		FullJoin(rollup.As("rollup"), goqu.Using("user_id", "service_name")).
+		Select(
+			goqu.C("user_id"),
+			goqu.C("service_name"),
+			goqu.COALESCE(goqu.T("raw").Col("amount"), 0),
+			goqu.COALESCE(goqu.T("rollup").Col("amount"), 0),
+		).
+		Where(goqu.L("COALESCE(raw.amount, 0) <> COALESCE(rollup.amount, 0)")).
+		Order(goqu.C("user_id").Asc(), goqu.C("service_name").Asc())
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("CheckMonthlySpend query")
+
+	rows, err := repo.DB.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*SpendMismatch, 0)
+	for rows.Next() {
+		mismatch := &SpendMismatch{}
+		err = rows.Scan(&mismatch.UserId, &mismatch.ServiceName, &mismatch.Expected, &mismatch.Actual)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, mismatch)
+	}
+	return res, rows.Err()
+}
+
+// usesRollup tells whether GetStats can be answered from monthly_spend, which only keeps the user and the service name.
+func usesRollup(params subscriptions.SubscriptionListParams) bool {
+	return len(params.Categories) == 0 &&
+		len(params.Tags) == 0 &&
+		params.PriceMin == nil &&
+		params.PriceMax == nil &&
+		params.Status == nil
+}
+
+// getRolledUpStats sums monthly_spend with the same period bounds applyFilters and withBilling put on the subscriptions.
+func (repo *SubscriptionRepository) getRolledUpStats(ctx context.Context, params subscriptions.SubscriptionListParams) (int, error) {
+	query := repo.QB.From("monthly_spend").
+		Select(goqu.COALESCE(goqu.SUM("amount"), 0).As("total"))
+
+	if len(params.ServiceNames) > 0 {
+		query = query.Where(goqu.C("service_name").In(params.ServiceNames))
+	}
+	if params.ServiceNamePrefix != nil {
+		query = query.Where(goqu.C("service_name").ILike(likeEscaper.Replace(*params.ServiceNamePrefix) + "%"))
+	}
+	if len(params.UserIds) > 0 {
+		query = query.Where(goqu.C("user_id").In(params.UserIds))
+	}
+
+	if params.EndDate != nil {
+		query = query.Where(goqu.C("start_month").Lt(params.EndDate), goqu.C("month").Lte(params.EndDate))
+	} else {
+		query = query.Where(goqu.C("start_month").Lt(subscriptions.MonthStart(time.Now())))
+	}
+	if params.StartDate != nil {
+		query = query.Where(goqu.C("month").Gte(params.StartDate))
+	}
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("GetStat rollup query")
+
+	var total int
+	err := repo.DB.QueryRow(ctx, q, args...).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
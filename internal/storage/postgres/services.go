@@ -0,0 +1,398 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"ew/internal/database"
+	"ew/internal/logging"
+	"ew/internal/models/events"
+	"ew/internal/models/services"
+	"ew/internal/models/subscriptions"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	uniqueViolation = "23505"
+	// catalogLock serializes the writes checking the names of the catalog, the aliases have no unique index
+	catalogLock = 0x73657276
+)
+
+type ServiceRepository struct {
+	DB *pgxpool.Pool
+	QB goqu.DialectWrapper
+	// Events is the log the subscriptions renamed, linked or unlinked by the writes are recorded to
+	Events *EventRepository
+}
+
+func NewServiceRepo(db *pgxpool.Pool, qb goqu.DialectWrapper) *ServiceRepository {
+	return &ServiceRepository{DB: db, QB: qb}
+}
+
+var serviceColumns = []any{"id", "name", "aliases", "category", "default_price", "logo_url"}
+
+func scanService(row pgx.Row) (*services.Service, error) {
+	service := &services.Service{}
+	err := row.Scan(
+		&service.ID,
+		&service.Name,
+		&service.Aliases,
+		&service.Category,
+		&service.DefaultPrice,
+		&service.LogoUrl,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+func (repo *ServiceRepository) GetList(ctx context.Context, params services.ServiceListParams) ([]*services.Service, error) {
+	query := repo.QB.From("services").
+		Select(serviceColumns...).
+		Order(goqu.C("name").Asc())
+
+	if params.Category != nil {
+		query = query.Where(goqu.Ex{"category": params.Category})
+	}
+
+	if params.Limit != nil {
+		query = query.Limit(uint(*params.Limit))
+	}
+	if params.Offset != nil {
+		query = query.Offset(uint(*params.Offset))
+	}
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("GetList services query")
+
+	rows, err := repo.DB.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*services.Service, 0)
+	for rows.Next() {
+		service, err := scanService(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, service)
+	}
+	return items, rows.Err()
+}
+
+func (repo *ServiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*services.Service, error) {
+	query := repo.QB.From("services").
+		Select(serviceColumns...).
+		Where(goqu.Ex{"id": id})
+
+	return repo.getOne(ctx, query, "GetByID service query")
+}
+
+func (repo *ServiceRepository) FindByName(ctx context.Context, name string) (*services.Service, error) {
+	return findService(ctx, repo.QB, repo.DB, name)
+}
+
+// findService looks the name up among the names and the aliases, which are unique across the catalog.
+func findService(ctx context.Context, qb goqu.DialectWrapper, db Conn, name string) (*services.Service, error) {
+	query := qb.From("services").
+		Select(serviceColumns...).
+		Where(namesMatch([]string{services.Key(name)}))
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("FindByName service query")
+
+	service, err := scanService(db.QueryRow(ctx, q, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, services.NotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// namesMatch selects the services having the name or an alias among the keys.
+func namesMatch(keys []string) exp.Expression {
+	return goqu.Or(
+		goqu.L("lower(btrim(name)) = ANY(?)", database.TextArray(keys)),
+		goqu.L("EXISTS (SELECT 1 FROM unnest(aliases) alias WHERE lower(btrim(alias)) = ANY(?))", database.TextArray(keys)),
+	)
+}
+
+// lockCatalog holds the names of the catalog until the transaction ends.
+func lockCatalog(ctx context.Context, tx pgx.Tx) error {
+	_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", catalogLock)
+	return err
+}
+
+// checkNames returns NameTaken if a name or an alias of the service is already used by another one.
+func checkNames(ctx context.Context, qb goqu.DialectWrapper, tx pgx.Tx, service *services.Service) error {
+	query := qb.From("services").
+		Select(goqu.L("1")).
+		Where(goqu.C("id").Neq(service.ID), namesMatch(service.Keys())).
+		Limit(1)
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Check service names query")
+
+	var taken int
+	err := tx.QueryRow(ctx, q, args...).Scan(&taken)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return services.NameTaken
+}
+
+// ensureService returns the catalog entry of the name, adding it inside the transaction of the subscription write
+// for names seen for the first time along with the subscriptions it links.
+func ensureService(ctx context.Context, qb goqu.DialectWrapper, tx pgx.Tx, name string, price *uint) (*services.Service, []*subscriptions.Subscription, error) {
+	err := lockCatalog(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	service, err := findService(ctx, qb, tx, name)
+	if !errors.Is(err, services.NotFound) {
+		return service, nil, err
+	}
+
+	service = &services.Service{Name: strings.TrimSpace(name), Aliases: database.TextArray{}, DefaultPrice: price}
+	query := qb.Insert("services").
+		Rows(service).
+		Returning("id")
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Add service query")
+
+	err = tx.QueryRow(ctx, q, args...).Scan(&service.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	logging.FromContext(ctx).WithField("name", service.Name).Info("added service to catalog")
+
+	linked, err := linkSubscriptions(ctx, qb, tx, service)
+	if err != nil {
+		return nil, nil, err
+	}
+	return service, linked, nil
+}
+
+func (repo *ServiceRepository) getOne(ctx context.Context, query *goqu.SelectDataset, msg string) (*services.Service, error) {
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug(msg)
+
+	service, err := scanService(repo.DB.QueryRow(ctx, q, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, services.NotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+func (repo *ServiceRepository) Add(ctx context.Context, elem *services.Service) (uuid.UUID, error) {
+	if elem.Aliases == nil {
+		elem.Aliases = database.TextArray{}
+	}
+
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = lockCatalog(ctx, tx)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	err = checkNames(ctx, repo.QB, tx, elem)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	query := repo.QB.Insert("services").
+		Rows(elem).
+		Returning("id")
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Add service query")
+
+	err = tx.QueryRow(ctx, q, args...).Scan(&elem.ID)
+	if err != nil {
+		return uuid.UUID{}, convertError(err)
+	}
+
+	linked, err := linkSubscriptions(ctx, repo.QB, tx, elem)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	err = repo.Events.write(ctx, tx, newEvents(events.Updated, linked))
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	return elem.ID, tx.Commit(ctx)
+}
+
+func (repo *ServiceRepository) Update(ctx context.Context, elem *services.ServicePatch) (int64, error) {
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = lockCatalog(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := repo.QB.Update("services").
+		Where(goqu.Ex{"id": elem.ID}).
+		Set(elem).
+		Returning(serviceColumns...)
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Update service query")
+
+	service, err := scanService(tx.QueryRow(ctx, q, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, convertError(err)
+	}
+
+	// the names are checked as they are after the update, a collision rolls it back
+	err = checkNames(ctx, repo.QB, tx, service)
+	if err != nil {
+		return 0, err
+	}
+	linked, err := linkSubscriptions(ctx, repo.QB, tx, service)
+	if err != nil {
+		return 0, err
+	}
+	err = repo.Events.write(ctx, tx, newEvents(events.Updated, linked))
+	if err != nil {
+		return 0, err
+	}
+
+	return 1, tx.Commit(ctx)
+}
+
+// Delete unlinks the subscriptions of the service itself rather than leaving it to the foreign key,
+// so that their events are recorded with the deletion.
+func (repo *ServiceRepository) Delete(ctx context.Context, id uuid.UUID) (int64, error) {
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	unlink := repo.QB.Update("subscriptions").
+		Set(goqu.Record{"service_id": nil}).
+		Where(goqu.Ex{"service_id": id}).
+		Returning(subscriptionColumns...)
+
+	q, args, _ := unlink.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Unlink subscriptions query")
+
+	unlinked, err := collectSubscriptions(ctx, repo.QB, tx, q, args)
+	if err != nil {
+		return 0, err
+	}
+
+	query := repo.QB.Delete("services").
+		Where(goqu.Ex{"id": id})
+
+	q, args, _ = query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Delete service query")
+
+	result, err := tx.Exec(ctx, q, args...)
+	if err != nil {
+		return 0, err
+	}
+	if result.RowsAffected() == 0 {
+		return 0, nil
+	}
+
+	err = repo.Events.write(ctx, tx, newEvents(events.Updated, unlinked))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), tx.Commit(ctx)
+}
+
+// linkSubscriptions attaches free-text subscriptions matching any of the service names
+// and keeps the denormalized service_name of already linked ones in sync, along with the monthly spend of their users.
+// It returns the subscriptions it changed.
+func linkSubscriptions(ctx context.Context, qb goqu.DialectWrapper, tx pgx.Tx, service *services.Service) ([]*subscriptions.Subscription, error) {
+	names := make(database.TextArray, 0, len(service.Aliases)+1)
+	for _, name := range service.Names() {
+		names = append(names, services.Key(name))
+	}
+
+	query := qb.Update("subscriptions").
+		Set(goqu.Record{"service_id": service.ID, "service_name": service.Name}).
+		Where(goqu.Or(
+			goqu.And(
+				goqu.C("service_id").Eq(service.ID),
+				goqu.C("service_name").Neq(service.Name),
+			),
+			goqu.And(
+				goqu.C("service_id").IsNull(),
+				goqu.L("lower(btrim(service_name)) = ANY(?)", names),
+			),
+		)).
+		Returning(subscriptionColumns...)
+
+	q, args, _ := query.Prepared(true).ToSQL()
+	logging.FromContext(ctx).WithFields(logging.QueryFields(q, args)).Debug("Link subscriptions query")
+
+	linked, err := collectSubscriptions(ctx, qb, tx, q, args)
+	if err != nil {
+		return nil, err
+	}
+
+	userIds := make([]uuid.UUID, 0, len(linked))
+	for _, item := range linked {
+		userIds = append(userIds, item.UserId)
+	}
+	return linked, refreshSpend(ctx, qb, tx, userIds...)
+}
+
+// collectSubscriptions reads the subscriptions a write returned, with their pauses.
+func collectSubscriptions(ctx context.Context, qb goqu.DialectWrapper, tx pgx.Tx, q string, args []any) ([]*subscriptions.Subscription, error) {
+	rows, err := tx.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*subscriptions.Subscription, error) {
+		return scanSubscription(row)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, loadPauses(ctx, qb, tx, items)
+}
+
+func convertError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return services.NameTaken
+	}
+	return err
+}
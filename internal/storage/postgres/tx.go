@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"ew/internal/models/subscriptions"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// WithTx hands fn a repository running on the transaction, whose own writes then begin savepoints of it
+// and whose GetByID locks the subscription until the transaction ends. The events of the writes are written
+// to the log with the commit.
+func (repo *SubscriptionRepository) WithTx(ctx context.Context, fn func(subscriptions.SubscriptionRepo) error) error {
+	ctx, span := tracer.Start(ctx, "SubscriptionRepository.WithTx")
+	defer span.End()
+
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	box := &outbox{}
+	err = fn(&SubscriptionRepository{DB: tx, QB: repo.QB, tx: tx, batch: repo.batch, Events: repo.Events, outbox: box})
+	if err != nil {
+		return err
+	}
+
+	if err = repo.flush(ctx, tx, box); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// flush passes the events of a transaction about to commit on to the transaction the repository runs in,
+// or writes them to the log.
+func (repo *SubscriptionRepository) flush(ctx context.Context, tx pgx.Tx, box *outbox) error {
+	if repo.outbox != nil {
+		repo.outbox.events = append(repo.outbox.events, box.events...)
+		return nil
+	}
+	return repo.Events.write(ctx, tx, box.events)
+}
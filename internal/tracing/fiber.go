@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const fiberTracerName = "ew/internal/tracing/fiber"
+
+// Middleware starts a server span for every request, continuing the trace from the W3C traceparent header,
+// and passes it on through the user context the strict handlers receive.
+func Middleware(c *fiber.Ctx) error {
+	headers := propagation.HeaderCarrier(http.Header(c.GetReqHeaders()))
+	ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headers)
+
+	ctx, span := otel.Tracer(fiberTracerName).Start(ctx, c.Method()+" "+c.Path(),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(c.Method()),
+			semconv.URLPath(c.Path()),
+		),
+	)
+	defer span.End()
+
+	c.SetUserContext(ctx)
+
+	err := c.Next()
+	if err != nil {
+		// let the error handler write the response now, so its status is recorded
+		if handleErr := c.App().ErrorHandler(c, err); handleErr != nil {
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+		}
+		span.RecordError(err)
+	}
+
+	status := c.Response().StatusCode()
+	span.SetName(c.Method() + " " + c.Route().Path)
+	span.SetAttributes(semconv.HTTPRoute(c.Route().Path), semconv.HTTPResponseStatusCode(status))
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMiddleware(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(NewProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var handlerSpan trace.SpanContext
+
+	webApp := fiber.New()
+	webApp.Use(Middleware)
+	webApp.Get("/items/:id", func(c *fiber.Ctx) error {
+		handlerSpan = trace.SpanContextFromContext(c.UserContext())
+		return fiber.ErrNotFound
+	})
+
+	req := httptest.NewRequest("GET", "/items/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 404 {
+		t.Errorf("invalid status code: %d", resp.StatusCode)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("not equal %v", spans)
+	}
+
+	span := spans[0]
+	if span.Name != "GET /items/:id" {
+		t.Errorf("not equal %s", span.Name)
+	}
+	if span.SpanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace is not propagated: %s", span.SpanContext.TraceID())
+	}
+	if span.Parent.SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("not equal %s", span.Parent.SpanID())
+	}
+	if handlerSpan.SpanID() != span.SpanContext.SpanID() {
+		t.Errorf("span is not passed to the handler")
+	}
+
+	statusRecorded := false
+	for _, attr := range span.Attributes {
+		if attr.Key == "http.response.status_code" && attr.Value.AsInt64() == 404 {
+			statusRecorded = true
+		}
+	}
+	if !statusRecorded {
+		t.Errorf("no status in %v", span.Attributes)
+	}
+}
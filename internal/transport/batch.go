@@ -0,0 +1,141 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+func registerBatchRules(validate *validator.Validate) {
+	batchRules := map[string]string{
+		"Mode":       "omitempty,oneof=atomic best_effort",
+		"Operations": "required,min=1,max=1000",
+	}
+	validate.RegisterStructValidationMapRules(batchRules, SubscriptionBatch{})
+}
+
+// batchOperation converts an operation of the batch with the checks of the single-subscription handlers
+// that need no stored subscription. The dates of an update are checked by the repository against the row it locks.
+func (s Server) batchOperation(ctx context.Context, op BatchOperation) (subscriptions.BatchOperation, *Problem, error) {
+	var res subscriptions.BatchOperation
+
+	required := func(field string) (subscriptions.BatchOperation, *Problem, error) {
+		invalid := s.fieldProblem(ctx, field, "required", "")
+		return res, &invalid, nil
+	}
+
+	switch op.Op {
+	case Create:
+		if op.Subscription == nil {
+			return required("subscription")
+		}
+		item, invalid, err := s.newSubscription(ctx, op.Subscription)
+		res.Create = item
+		return res, invalid, err
+	case Update:
+		if op.SubscriptionId == nil {
+			return required("subscription_id")
+		}
+		if op.Patch == nil {
+			return required("patch")
+		}
+		item, invalid, err := s.subscriptionPatch(ctx, *op.SubscriptionId, op.Patch)
+		res.Update = item
+		return res, invalid, err
+	case Delete:
+		if op.SubscriptionId == nil {
+			return required("subscription_id")
+		}
+		res.Delete = op.SubscriptionId
+		return res, nil, nil
+	}
+
+	invalid := s.fieldProblem(ctx, "op", "oneof", "create update delete")
+	return res, &invalid, nil
+}
+
+// BatchSubscriptions rejects the invalid operations before touching the repository:
+// an atomic batch is then not run at all, a best-effort one runs without them.
+func (s Server) BatchSubscriptions(ctx context.Context, request BatchSubscriptionsRequestObject) (BatchSubscriptionsResponseObject, error) {
+	err := s.validate(ctx, request.Body)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("BatchSubscriptions validation failed")
+		return BatchSubscriptions422ApplicationProblemPlusJSONResponse(s.validationProblem(ctx, err)), nil
+	}
+
+	atomic := request.Body.Mode == nil || *request.Body.Mode == Atomic
+
+	results := make([]BatchResult, len(request.Body.Operations))
+	ops := make([]subscriptions.BatchOperation, 0, len(request.Body.Operations))
+	// indexes maps the operations passed to the repository back to the request
+	indexes := make([]int, 0, len(request.Body.Operations))
+	rejected := false
+
+	for i, op := range request.Body.Operations {
+		item, invalid, err := s.batchOperation(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+		if invalid != nil {
+			results[i] = BatchResult{Status: Failed, SubscriptionId: op.SubscriptionId, Error: invalid}
+			rejected = true
+			continue
+		}
+		ops = append(ops, item)
+		indexes = append(indexes, i)
+	}
+
+	if rejected && atomic {
+		for _, i := range indexes {
+			results[i] = BatchResult{Status: RolledBack}
+		}
+		logging.FromContext(ctx).Info("rejected subscriptions batch")
+		return BatchSubscriptions200JSONResponse{Results: results}, nil
+	}
+
+	applied, err := s.Repo.Batch(ctx, ops, atomic)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("BatchSubscriptions failed")
+		return nil, InternalError
+	}
+
+	for j, result := range applied {
+		results[indexes[j]] = s.batchResult(ctx, result)
+	}
+	logging.FromContext(ctx).WithField("operations", len(ops)).Info("applied subscriptions batch")
+
+	return BatchSubscriptions200JSONResponse{Results: results}, nil
+}
+
+func (s Server) batchResult(ctx context.Context, result subscriptions.BatchResult) BatchResult {
+	res := BatchResult{Status: Ok}
+	if result.ID != uuid.Nil {
+		res.SubscriptionId = &result.ID
+	}
+
+	switch {
+	case result.Err == nil:
+		return res
+	case errors.Is(result.Err, subscriptions.RolledBack):
+		return BatchResult{Status: RolledBack}
+	case errors.Is(result.Err, subscriptions.NotFound):
+		invalid := s.problemFor(ctx, http.StatusNotFound, problemGeneric, "subscriptionNotFound")
+		res.Status, res.Error = Failed, &invalid
+	case errors.Is(result.Err, subscriptions.InvalidPeriod):
+		invalid := s.periodProblem(ctx)
+		res.Status, res.Error = Failed, &invalid
+	case errors.Is(result.Err, subscriptions.InvalidTrial):
+		invalid := s.trialProblem(ctx)
+		res.Status, res.Error = Failed, &invalid
+	default:
+		logging.FromContext(ctx).WithError(result.Err).WithField("subscription_id", result.ID).Error("batch operation failed")
+		invalid := problem(http.StatusInternalServerError, problemGeneric, "")
+		res.Status, res.Error = Failed, &invalid
+	}
+	return res
+}
@@ -0,0 +1,126 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"ew/internal/models/subscriptions"
+	"io"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func sendBatch(t *testing.T, webApp *fiber.App, batch map[string]any) (int, BatchResults) {
+	body, _ := json.Marshal(batch)
+
+	req := httptest.NewRequest("POST", "/subscriptions/batch", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := webApp.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var res BatchResults
+	respBody, _ := io.ReadAll(resp.Body)
+	_ = json.Unmarshal(respBody, &res)
+	return resp.StatusCode, res
+}
+
+func statuses(res BatchResults) []BatchResultStatus {
+	out := make([]BatchResultStatus, 0, len(res.Results))
+	for _, result := range res.Results {
+		out = append(out, result.Status)
+	}
+	return out
+}
+
+func TestImplBatch(t *testing.T) {
+	webApp := prepareServ()
+
+	created := map[string]any{"service_name": "batch", "price": 100, "user_id": uuid.New(), "start_date": "01-2025"}
+	invalid := map[string]any{"service_name": "batch", "price": 100, "user_id": uuid.New(), "start_date": "13-2025"}
+	count := len(repo.Items)
+
+	// an invalid operation keeps an atomic batch from running
+	status, res := sendBatch(t, webApp, map[string]any{"operations": []map[string]any{
+		{"op": "create", "subscription": created},
+		{"op": "delete", "subscription_id": id3},
+		{"op": "create", "subscription": invalid},
+	}})
+	if status != 200 || !slices.Equal(statuses(res), []BatchResultStatus{RolledBack, RolledBack, Failed}) {
+		t.Errorf("invalid atomic results: %d %v", status, statuses(res))
+	}
+	if res.Results[2].Error == nil || (*res.Results[2].Error.Errors)[0].Field != "start_date" {
+		t.Errorf("invalid error of the rejected operation: %v", res.Results[2].Error)
+	}
+	if len(repo.Items) != count {
+		t.Errorf("atomic batch applied: %d items", len(repo.Items))
+	}
+
+	// a missing subscription rolls back the writes made before it
+	status, res = sendBatch(t, webApp, map[string]any{"operations": []map[string]any{
+		{"op": "update", "subscription_id": id1, "patch": map[string]any{"price": 999}},
+		{"op": "delete", "subscription_id": uuid.New()},
+	}})
+	if status != 200 || !slices.Equal(statuses(res), []BatchResultStatus{RolledBack, Failed}) {
+		t.Errorf("invalid atomic results: %d %v", status, statuses(res))
+	}
+	if res.Results[1].Error == nil || res.Results[1].Error.Status != 404 {
+		t.Errorf("invalid error of the missing subscription: %v", res.Results[1].Error)
+	}
+	if item, _ := repo.GetByID(context.TODO(), id1); item.Price != 125 {
+		t.Errorf("atomic batch not rolled back: price %d", item.Price)
+	}
+
+	// the trial of an update is checked against the stored start date
+	status, res = sendBatch(t, webApp, map[string]any{"operations": []map[string]any{
+		{"op": "update", "subscription_id": id1, "patch": map[string]any{"trial_end_date": "01-2000"}},
+	}})
+	if status != 200 || !slices.Equal(statuses(res), []BatchResultStatus{Failed}) {
+		t.Errorf("invalid atomic results: %d %v", status, statuses(res))
+	}
+	if res.Results[0].Error == nil || (*res.Results[0].Error.Errors)[0].Field != "trial_end_date" {
+		t.Errorf("invalid error of the early trial: %v", res.Results[0].Error)
+	}
+	if item, _ := repo.GetByID(context.TODO(), id1); item.TrialEndDate != nil {
+		t.Errorf("early trial stored: %v", item.TrialEndDate)
+	}
+
+	status, res = sendBatch(t, webApp, map[string]any{"mode": "best_effort", "operations": []map[string]any{
+		{"op": "create", "subscription": created},
+		{"op": "update", "subscription_id": id1, "patch": map[string]any{"price": 999}},
+		{"op": "create", "subscription": invalid},
+		{"op": "delete", "subscription_id": uuid.New()},
+		{"op": "delete", "subscription_id": id3},
+		{"op": "update", "subscription_id": id2},
+	}})
+	expected := []BatchResultStatus{Ok, Ok, Failed, Failed, Ok, Failed}
+	if status != 200 || !slices.Equal(statuses(res), expected) {
+		t.Errorf("invalid best-effort results: %d %v", status, statuses(res))
+	}
+	if res.Results[0].SubscriptionId == nil || !slices.ContainsFunc(repo.Items, func(item *subscriptions.Subscription) bool {
+		return item.ID == *res.Results[0].SubscriptionId
+	}) {
+		t.Errorf("subscription not created: %v", res.Results[0].SubscriptionId)
+	}
+	if item, _ := repo.GetByID(context.TODO(), id1); item.Price != 999 {
+		t.Errorf("subscription not updated: price %d", item.Price)
+	}
+	if _, err := repo.GetByID(context.TODO(), id3); err == nil {
+		t.Errorf("subscription not deleted")
+	}
+
+	for _, batch := range []map[string]any{
+		{"operations": []map[string]any{}},
+		{"mode": "sometimes", "operations": []map[string]any{{"op": "delete", "subscription_id": id1}}},
+	} {
+		if status, _ = sendBatch(t, webApp, batch); status != 422 {
+			t.Errorf("invalid status code for %v: %d", batch, status)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package transport
+
+import (
+	"context"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type cacheReporter interface {
+	CacheStats() subscriptions.CacheStats
+}
+
+// CacheControl lets a request bypass the repository cache with Cache-Control: no-cache.
+func CacheControl(c *fiber.Ctx) error {
+	if strings.Contains(strings.ToLower(c.Get(fiber.HeaderCacheControl)), "no-cache") {
+		c.SetUserContext(subscriptions.WithoutCache(c.UserContext()))
+	}
+	return c.Next()
+}
+
+func (s Server) StatsCache(ctx context.Context, request StatsCacheRequestObject) (StatsCacheResponseObject, error) {
+	reporter, ok := s.Repo.(cacheReporter)
+	if !ok {
+		return StatsCache200JSONResponse{}, nil
+	}
+
+	stats := reporter.CacheStats()
+	logging.FromContext(ctx).Info("received cache stats")
+
+	return StatsCache200JSONResponse{
+		Enabled: true,
+		Hits:    stats.Hits,
+		Misses:  stats.Misses,
+		Entries: stats.Entries,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package transport
+
+import (
+	"bytes"
+	"ew/internal/storage/cache"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestImplStatsCache(t *testing.T) {
+	webApp := prepareServ()
+
+	req := httptest.NewRequest("GET", "/stats/cache", nil)
+
+	resp, _ := webApp.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	expected := `{"enabled":false,"entries":0,"hits":0,"misses":0}`
+	if !bytes.Equal(bytes.TrimSpace(body), []byte(expected)) {
+		t.Errorf("incorrect cache stats, response body: %s", string(body))
+	}
+
+	cachedRepo := cache.NewRepo(repo)
+	webApp = fiber.New()
+	webApp.Use(CacheControl)
+	RegisterHandlers(webApp, NewStrictHandler(
+		NewServer(cachedRepo, cache.NewServiceRepo(serviceRepo, cachedRepo), validator.New()),
+		[]StrictMiddlewareFunc{},
+	))
+
+	for _, noCache := range []bool{false, false, true} {
+		req = httptest.NewRequest("GET", "/stats?user_id="+repo.Items[0].UserId.String(), nil)
+		if noCache {
+			req.Header.Set("Cache-Control", "no-cache")
+		}
+
+		resp, _ = webApp.Test(req)
+		if resp.StatusCode != 200 {
+			t.Errorf("invalid status code: %d", resp.StatusCode)
+		}
+	}
+
+	req = httptest.NewRequest("GET", "/stats/cache", nil)
+
+	resp, _ = webApp.Test(req)
+	body, _ = io.ReadAll(resp.Body)
+
+	expected = `{"enabled":true,"entries":1,"hits":1,"misses":2}`
+	if !bytes.Equal(bytes.TrimSpace(body), []byte(expected)) {
+		t.Errorf("incorrect cache stats, response body: %s", string(body))
+	}
+}
@@ -0,0 +1,98 @@
+package transport
+
+import (
+	"context"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func registerChurnRules(validate *validator.Validate) {
+	churnRules := map[string]string{
+		"StartDate": "omitempty,dateFormat",
+		"EndDate":   "omitempty,dateFormat",
+	}
+	validate.RegisterStructValidationMapRules(churnRules, ChurnSubscriptionsParams{})
+}
+
+// round keeps two decimal places of the ratios in the response.
+func round(value float64) float32 {
+	return float32(math.Round(value*100) / 100)
+}
+
+func (s Server) ChurnSubscriptions(ctx context.Context, request ChurnSubscriptionsRequestObject) (ChurnSubscriptionsResponseObject, error) {
+	err := s.validate(ctx, request.Params)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("ChurnSubscriptions validation failed")
+		return ChurnSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.validationProblem(ctx, err), StatusCode: 422}, nil
+	}
+
+	params := subscriptions.ChurnParams{
+		EndDate: subscriptions.MonthStart(time.Now()),
+	}
+
+	if request.Params.EndDate != nil {
+		params.EndDate, _ = time.Parse("01-2006", *request.Params.EndDate)
+	}
+
+	params.StartDate = params.EndDate.AddDate(0, -11, 0)
+	if request.Params.StartDate != nil {
+		params.StartDate, _ = time.Parse("01-2006", *request.Params.StartDate)
+	}
+
+	months := subscriptions.MonthsBetween(params.StartDate, params.EndDate)
+	if months < 0 {
+		return ChurnSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.fieldProblem(ctx, "end_date", "gtefield", "start_date"), StatusCode: 422}, nil
+	}
+	if months >= maxReportMonths {
+		return ChurnSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.fieldProblem(ctx, "end_date", "maxPeriod", strconv.Itoa(maxReportMonths)), StatusCode: 422}, nil
+	}
+
+	if request.Params.UserId != nil {
+		params.Filter.UserIds = *request.Params.UserId
+	}
+
+	if request.Params.ServiceName != nil {
+		params.Filter.ServiceNames = *request.Params.ServiceName
+	}
+
+	if request.Params.Category != nil {
+		params.Filter.Categories = *request.Params.Category
+	}
+
+	churn, err := s.Repo.GetChurn(ctx, params)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("ChurnSubscriptions failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("received churn")
+
+	res := ChurnSubscriptions200JSONResponse{Services: make([]ServiceChurn, 0, len(churn))}
+	for _, service := range churn {
+		item := ServiceChurn{
+			Key:             service.Key,
+			ServiceName:     service.Name,
+			AverageLifetime: round(service.AverageLifetime),
+			Months:          make([]ChurnMonth, 0, len(service.Months)),
+		}
+		for _, month := range service.Months {
+			item.Months = append(item.Months, ChurnMonth{
+				Month:      month.Month.Format("01-2006"),
+				New:        month.New,
+				Cancelled:  month.Cancelled,
+				Active:     month.Active,
+				ChurnRate:  round(month.ChurnRate()),
+				NewMrr:     month.NewMRR,
+				ChurnedMrr: month.CancelledMRR,
+				NetMrr:     month.NetMRR(),
+			})
+		}
+		res.Services = append(res.Services, item)
+	}
+
+	return res, nil
+}
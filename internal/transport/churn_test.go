@@ -0,0 +1,40 @@
+package transport
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestImplChurn(t *testing.T) {
+	webApp := prepareServ()
+
+	end := time.Now().AddDate(0, -1, 0).Format("01-2006")
+	req := httptest.NewRequest("GET", "/stats/churn?service_name=item%202&service_name=nothing&end_date="+end, nil)
+
+	resp, _ := webApp.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	var churn ChurnSubscriptions200JSONResponse
+	_ = json.Unmarshal(body, &churn)
+
+	if len(churn.Services) != 2 || len(churn.Services[0].Months) != 12 {
+		t.Fatalf("incorrect churn, response body: %s", string(body))
+	}
+
+	last := churn.Services[1].Months[11]
+	if churn.Services[1].ServiceName != "nothing" || last.Month != end || last.New != 1 || last.NetMrr != 500 {
+		t.Errorf("incorrect churn, response body: %s", string(body))
+	}
+
+	for _, query := range []string{"start_date=05-2030&end_date=04-2030", "start_date=01-2000", "end_date=13-2030"} {
+		req = httptest.NewRequest("GET", "/stats/churn?"+query, nil)
+
+		resp, _ = webApp.Test(req)
+		if resp.StatusCode != 422 {
+			t.Errorf("%s: invalid status code: %d, expected %d", query, resp.StatusCode, 422)
+		}
+	}
+}
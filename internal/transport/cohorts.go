@@ -0,0 +1,133 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func registerCohortRules(validate *validator.Validate) {
+	cohortRules := map[string]string{
+		"StartDate": "omitempty,dateFormat",
+		"EndDate":   "omitempty,dateFormat",
+		"Value":     "omitempty,oneof=count share",
+		"Format":    "omitempty,oneof=json csv",
+	}
+	validate.RegisterStructValidationMapRules(cohortRules, CohortSubscriptionsParams{})
+}
+
+// cohortValues converts the active counts of a cohort into the requested matrix values.
+func cohortValues(cohort *subscriptions.Cohort, share bool) []float32 {
+	values := make([]float32, 0, len(cohort.Active))
+	for _, active := range cohort.Active {
+		if share {
+			values = append(values, round(float64(active)/float64(cohort.Size)))
+		} else {
+			values = append(values, float32(active))
+		}
+	}
+	return values
+}
+
+func writeCohortsCSV(cohorts []*subscriptions.Cohort, share bool) (*bytes.Buffer, error) {
+	columns := 0
+	for _, cohort := range cohorts {
+		columns = max(columns, len(cohort.Active))
+	}
+
+	header := []string{"cohort", "size"}
+	for i := range columns {
+		header = append(header, strconv.Itoa(i))
+	}
+
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, cohort := range cohorts {
+		record := []string{cohort.Month.Format("01-2006"), strconv.Itoa(cohort.Size)}
+		for _, value := range cohortValues(cohort, share) {
+			record = append(record, strconv.FormatFloat(float64(value), 'f', -1, 32))
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buf, writer.Error()
+}
+
+func (s Server) CohortSubscriptions(ctx context.Context, request CohortSubscriptionsRequestObject) (CohortSubscriptionsResponseObject, error) {
+	err := s.validate(ctx, request.Params)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("CohortSubscriptions validation failed")
+		return CohortSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.validationProblem(ctx, err), StatusCode: 422}, nil
+	}
+
+	params := subscriptions.CohortParams{
+		EndDate: subscriptions.MonthStart(time.Now()),
+	}
+
+	if request.Params.EndDate != nil {
+		params.EndDate, _ = time.Parse("01-2006", *request.Params.EndDate)
+	}
+
+	params.StartDate = params.EndDate.AddDate(0, -11, 0)
+	if request.Params.StartDate != nil {
+		params.StartDate, _ = time.Parse("01-2006", *request.Params.StartDate)
+	}
+
+	months := subscriptions.MonthsBetween(params.StartDate, params.EndDate)
+	if months < 0 {
+		return CohortSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.fieldProblem(ctx, "end_date", "gtefield", "start_date"), StatusCode: 422}, nil
+	}
+	if months >= maxReportMonths {
+		return CohortSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.fieldProblem(ctx, "end_date", "maxPeriod", strconv.Itoa(maxReportMonths)), StatusCode: 422}, nil
+	}
+
+	if request.Params.UserId != nil {
+		params.Filter.UserIds = *request.Params.UserId
+	}
+
+	if request.Params.ServiceName != nil {
+		params.Filter.ServiceNames = *request.Params.ServiceName
+	}
+
+	cohorts, err := s.Repo.GetCohorts(ctx, params)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("CohortSubscriptions failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("received cohorts")
+
+	share := request.Params.Value != nil && *request.Params.Value == CohortSubscriptionsParamsValueShare
+
+	if request.Params.Format != nil && *request.Params.Format == Csv {
+		buf, err := writeCohortsCSV(cohorts, share)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("failed to write cohorts csv")
+			return nil, InternalError
+		}
+		return CohortSubscriptions200TextcsvResponse{Body: buf, ContentLength: int64(buf.Len())}, nil
+	}
+
+	res := CohortSubscriptions200JSONResponse{Cohorts: make([]Cohort, 0, len(cohorts))}
+	for _, cohort := range cohorts {
+		res.Cohorts = append(res.Cohorts, Cohort{
+			Month:  cohort.Month.Format("01-2006"),
+			Size:   cohort.Size,
+			Values: cohortValues(cohort, share),
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,50 @@
+package transport
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestImplCohorts(t *testing.T) {
+	webApp := prepareServ()
+
+	end := time.Now().AddDate(0, 0, -time.Now().Day()+1)
+	repo.Items[2].EndDate = &end
+
+	first := time.Now().AddDate(0, -2, 0).Format("01-2006")
+	second := time.Now().AddDate(0, -1, 0).Format("01-2006")
+
+	cases := []struct {
+		query       string
+		contentType string
+		expected    string
+	}{
+		{"", "application/json", `{"cohorts":[{"month":"` + first + `","size":1,"values":[1,1,1]},{"month":"` + second + `","size":1,"values":[1,1]}]}`},
+		{"?format=csv", "text/csv", "cohort,size,0,1,2\n" + first + ",1,1,1,1\n" + second + ",1,1,1\n"},
+		{"?start_date=" + second + "&value=share&format=csv", "text/csv", "cohort,size,0,1\n" + second + ",1,1,1\n"},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest("GET", "/stats/cohorts"+c.query, nil)
+
+		resp, _ := webApp.Test(req)
+		body, _ := io.ReadAll(resp.Body)
+
+		if resp.Header.Get("Content-Type") != c.contentType {
+			t.Errorf("%s: invalid content type %s", c.query, resp.Header.Get("Content-Type"))
+		}
+		if !bytes.Equal(bytes.TrimSpace(body), bytes.TrimSpace([]byte(c.expected))) {
+			t.Errorf("%s: incorrect cohorts, response body: %s", c.query, string(body))
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/stats/cohorts?format=xml", nil)
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 422 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 422)
+	}
+}
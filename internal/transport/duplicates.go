@@ -0,0 +1,56 @@
+package transport
+
+import (
+	"context"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+
+	"github.com/google/uuid"
+)
+
+func convertDuplicatesToResponse(duplicates []*subscriptions.Duplicate) Duplicates {
+	res := Duplicates{Items: make([]Duplicate, 0, len(duplicates))}
+	for _, duplicate := range duplicates {
+		item := Duplicate{
+			UserId:          duplicate.UserId,
+			ServiceName:     duplicate.ServiceName,
+			SubscriptionIds: []UUID{duplicate.FirstId, duplicate.SecondId},
+			OverlapStart:    duplicate.OverlapStart.Format("01-2006"),
+			WastedPrice:     duplicate.WastedPrice,
+		}
+		if duplicate.OverlapEnd != nil {
+			date := duplicate.OverlapEnd.Format("01-2006")
+			item.OverlapEnd = &date
+		}
+		res.WastedPrice += duplicate.WastedPrice
+		res.Items = append(res.Items, item)
+	}
+	return res
+}
+
+func (s Server) UserDuplicates(ctx context.Context, request UserDuplicatesRequestObject) (UserDuplicatesResponseObject, error) {
+	duplicates, err := s.Repo.GetDuplicates(ctx, []uuid.UUID{request.UserId})
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("UserDuplicates failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("received user duplicates")
+
+	return UserDuplicates200JSONResponse(convertDuplicatesToResponse(duplicates)), nil
+}
+
+func (s Server) ListDuplicates(ctx context.Context, request ListDuplicatesRequestObject) (ListDuplicatesResponseObject, error) {
+	var userIds []uuid.UUID
+	if request.Params.UserId != nil {
+		userIds = *request.Params.UserId
+	}
+
+	duplicates, err := s.Repo.GetDuplicates(ctx, userIds)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("ListDuplicates failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("received duplicates")
+
+	return ListDuplicates200JSONResponse(convertDuplicatesToResponse(duplicates)), nil
+}
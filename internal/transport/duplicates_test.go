@@ -0,0 +1,65 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestImplDuplicates(t *testing.T) {
+	webApp := prepareServ()
+
+	item := Subscription{
+		Price:       300,
+		StartDate:   time.Now().AddDate(0, -1, 0).Format("01-2006"),
+		UserId:      repo.Items[0].UserId,
+		ServiceName: "Some Item",
+	}
+
+	jsonStr, _ := json.Marshal(item)
+
+	req := httptest.NewRequest("POST", "/subscriptions?reject_duplicates=true", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 409 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 409)
+	}
+
+	req = httptest.NewRequest("POST", "/subscriptions", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 200)
+	}
+	body, _ := io.ReadAll(resp.Body)
+
+	if !bytes.Contains(body, []byte(`"duplicates":["`+id1.String()+`"]`)) {
+		t.Errorf("duplicates are missing, response body: %s", string(body))
+	}
+
+	req = httptest.NewRequest("GET", "/users/"+item.UserId.String()+"/insights/duplicates", nil)
+
+	resp, _ = webApp.Test(req)
+	body, _ = io.ReadAll(resp.Body)
+
+	var duplicates Duplicates
+	_ = json.Unmarshal(body, &duplicates)
+
+	if len(duplicates.Items) != 1 || duplicates.WastedPrice != 2*125 || duplicates.Items[0].OverlapStart != item.StartDate {
+		t.Errorf("incorrect duplicates, response body: %s", string(body))
+	}
+
+	req = httptest.NewRequest("GET", "/insights/duplicates?user_id="+repo.Items[1].UserId.String(), nil)
+
+	resp, _ = webApp.Test(req)
+	body, _ = io.ReadAll(resp.Body)
+
+	if !bytes.Contains(body, []byte(`{"items":[],"wasted_price":0}`)) {
+		t.Errorf("incorrect duplicates, response body: %s", string(body))
+	}
+}
@@ -0,0 +1,155 @@
+package transport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"ew/internal/logging"
+	"ew/internal/models/events"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// keepAliveInterval is the period of the comments that keep an idle stream open and find the clients that are gone.
+const keepAliveInterval = 15 * time.Second
+
+// subscriptionEvent is the data of a stream event. It is SubscriptionEvent of the spec,
+// which is not generated because no JSON response refers to it.
+type subscriptionEvent struct {
+	SubscriptionId UUID          `json:"subscription_id"`
+	UserId         UUID          `json:"user_id"`
+	Subscription   *Subscription `json:"subscription,omitempty"`
+}
+
+// StreamSubscriptionEvents subscribes before reading the log, the live events already sent from the log are skipped.
+func (s Server) StreamSubscriptionEvents(ctx context.Context, request StreamSubscriptionEventsRequestObject) (StreamSubscriptionEventsResponseObject, error) {
+	if s.Events == nil {
+		return StreamSubscriptionEventsdefaultApplicationProblemPlusJSONResponse{Body: problem(http.StatusNotImplemented, problemGeneric, ""), StatusCode: http.StatusNotImplemented}, nil
+	}
+
+	live, cancel := s.Events.Subscribe()
+	stream := eventStream{live: live, cancel: cancel}
+	if request.Params.UserId != nil {
+		stream.users = *request.Params.UserId
+	}
+
+	if request.Params.LastEventID != nil {
+		stream.last = *request.Params.LastEventID
+
+		backlog, err := s.Events.Since(ctx, stream.last)
+		switch {
+		case errors.Is(err, events.Expired):
+			stream.reset = true
+		case err != nil:
+			cancel()
+			logging.FromContext(ctx).WithError(err).Error("StreamSubscriptionEvents failed")
+			return nil, InternalError
+		}
+		stream.backlog = backlog
+	}
+	logging.FromContext(ctx).WithField("backlog", len(stream.backlog)).Info("opened subscription events stream")
+
+	return stream, nil
+}
+
+type eventStream struct {
+	live    <-chan events.Event
+	cancel  func()
+	backlog []events.Event
+	// reset tells the client that the log no longer has the events after its Last-Event-ID
+	reset bool
+	// last is the id of the latest event sent or skipped
+	last  int64
+	users []uuid.UUID
+}
+
+func (stream eventStream) VisitStreamSubscriptionEventsResponse(ctx *fiber.Ctx) error {
+	ctx.Set(fiber.HeaderContentType, "text/event-stream")
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Status(http.StatusOK)
+
+	// the write timeout of the server covers the whole response, so every write of the stream extends it
+	conn := ctx.Context().Conn()
+	timeout := ctx.App().Config().WriteTimeout
+
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		stream.run(w, conn, timeout)
+	})
+	return nil
+}
+
+// run writes the events until the client is gone or the subscription is closed,
+// the client then reconnects with Last-Event-ID.
+func (stream eventStream) run(w *bufio.Writer, conn net.Conn, timeout time.Duration) {
+	defer stream.cancel()
+
+	flush := func() bool {
+		if conn != nil && timeout > 0 {
+			conn.SetWriteDeadline(time.Now().Add(timeout))
+		}
+		return w.Flush() == nil
+	}
+
+	// an empty flush does not send the headers, the comment makes the client see the stream open right away
+	fmt.Fprint(w, ": connected\n\n")
+	if stream.reset {
+		fmt.Fprint(w, "event: reset\ndata:\n\n")
+	}
+	if !flush() {
+		return
+	}
+
+	for _, event := range stream.backlog {
+		if !stream.send(w, event) || !flush() {
+			return
+		}
+	}
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case event, ok := <-stream.live:
+			if !ok {
+				return
+			}
+			if event.ID <= stream.last {
+				continue
+			}
+			if !stream.send(w, event) || !flush() {
+				return
+			}
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			if !flush() {
+				return
+			}
+		}
+	}
+}
+
+// send writes the event if it passes the filter, it reports false once the client is gone.
+func (stream *eventStream) send(w *bufio.Writer, event events.Event) bool {
+	stream.last = event.ID
+	if len(stream.users) > 0 && !slices.Contains(stream.users, event.UserID) {
+		return true
+	}
+
+	data := subscriptionEvent{SubscriptionId: event.SubscriptionID, UserId: event.UserID}
+	if event.Subscription != nil {
+		res := convertRepoToResponse(event.Subscription)
+		data.Subscription = &res
+	}
+	body, _ := json.Marshal(data)
+
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, body)
+	return err == nil
+}
@@ -0,0 +1,163 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"ew/internal/models/subscriptions"
+	"ew/internal/storage/inmemory"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type sseEvent struct {
+	id, event, data string
+}
+
+// prepareEvents serves the API on a port, since the streams do not end for fiber's Test.
+func prepareEvents(t *testing.T) (*fiber.App, string) {
+	repo := inmemory.NewRepo([]*subscriptions.Subscription{})
+	server := NewServer(repo, inmemory.NewServiceRepo(nil, repo), validator.New())
+	eventRepo := inmemory.NewEventRepo(3)
+	repo.Events = eventRepo
+	server.Events = eventRepo
+
+	webApp := fiber.New(fiber.Config{ErrorHandler: ErrorHandler, WriteTimeout: time.Second, DisableStartupMessage: true})
+	webApp.Use(ProblemContentType)
+	RegisterHandlers(webApp, NewStrictHandler(server, []StrictMiddlewareFunc{}))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go webApp.Listener(listener)
+	t.Cleanup(func() {
+		eventRepo.Close()
+		webApp.Shutdown()
+	})
+
+	return webApp, "http://" + listener.Addr().String()
+}
+
+func openStream(t *testing.T, url, lastEventID string) <-chan sseEvent {
+	req, _ := http.NewRequest("GET", url, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 200 || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected response %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	received := make(chan sseEvent, 16)
+	go func() {
+		defer resp.Body.Close()
+		defer close(received)
+
+		var event sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			field, value, _ := strings.Cut(scanner.Text(), ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "id":
+				event.id = value
+			case "event":
+				event.event = value
+			case "data":
+				event.data = value
+			case "":
+				if event.event != "" {
+					received <- event
+				}
+				event = sseEvent{}
+			}
+		}
+	}()
+	return received
+}
+
+func nextEvent(t *testing.T, received <-chan sseEvent) sseEvent {
+	t.Helper()
+	select {
+	case event, ok := <-received:
+		if !ok {
+			t.Fatal("stream ended")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+	return sseEvent{}
+}
+
+func TestImplEvents(t *testing.T) {
+	webApp, url := prepareEvents(t)
+	user := uuid.New()
+
+	received := openStream(t, url+"/subscriptions/events?user_id="+user.String(), "")
+
+	var ids []UUID
+	for _, userId := range []UUID{user, uuid.New()} {
+		body, _ := json.Marshal(Subscription{Price: 105, StartDate: "11-2000", UserId: userId, ServiceName: "test"})
+		req := httptest.NewRequest("POST", "/subscriptions", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := webApp.Test(req)
+
+		var res CreateSubscription200JSONResponse
+		json.NewDecoder(resp.Body).Decode(&res)
+		ids = append(ids, *res.SubscriptionId)
+	}
+
+	created := nextEvent(t, received)
+	var data subscriptionEvent
+	json.Unmarshal([]byte(created.data), &data)
+	if created.event != "created" || data.SubscriptionId != ids[0] || data.UserId != user || data.Subscription == nil || data.Subscription.ServiceName != "test" {
+		t.Errorf("unexpected event %v", created)
+	}
+
+	req := httptest.NewRequest("PATCH", "/subscriptions/"+ids[0].String(), strings.NewReader(`{"price": 110}`))
+	req.Header.Set("Content-Type", "application/json")
+	webApp.Test(req)
+	webApp.Test(httptest.NewRequest("DELETE", "/subscriptions/"+ids[0].String(), nil))
+
+	// the subscription of the other user is filtered out
+	updated := nextEvent(t, received)
+	data = subscriptionEvent{}
+	json.Unmarshal([]byte(updated.data), &data)
+	if updated.event != "updated" || data.Subscription == nil || data.Subscription.Price != 110 {
+		t.Errorf("unexpected event %v", updated)
+	}
+
+	deleted := nextEvent(t, received)
+	data = subscriptionEvent{}
+	json.Unmarshal([]byte(deleted.data), &data)
+	if deleted.event != "deleted" || data.SubscriptionId != ids[0] || data.UserId != user || data.Subscription != nil {
+		t.Errorf("unexpected event %v", deleted)
+	}
+
+	// the log of three events still has the ones after the first
+	resumed := openStream(t, url+"/subscriptions/events?user_id="+user.String(), created.id)
+	if event := nextEvent(t, resumed); event.id != updated.id {
+		t.Errorf("expected the update replayed, got %v", event)
+	}
+	if event := nextEvent(t, resumed); event.id != deleted.id {
+		t.Errorf("expected the deletion replayed, got %v", event)
+	}
+
+	expired := openStream(t, url+"/subscriptions/events", "0")
+	if event := nextEvent(t, expired); event.event != "reset" {
+		t.Errorf("expected a reset of the expired stream, got %v", event)
+	}
+}
@@ -0,0 +1,91 @@
+package transport
+
+import (
+	"context"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// maxReportMonths limits the length of the monthly reports, as every month is a row in the response.
+const maxReportMonths = 120
+
+func registerForecastRules(validate *validator.Validate) {
+	forecastRules := map[string]string{
+		"StartDate":   "omitempty,dateFormat",
+		"EndDate":     "omitempty,dateFormat",
+		"PriceChange": "omitempty,min=-100",
+	}
+	validate.RegisterStructValidationMapRules(forecastRules, ForecastSubscriptionsParams{})
+}
+
+func (s Server) ForecastSubscriptions(ctx context.Context, request ForecastSubscriptionsRequestObject) (ForecastSubscriptionsResponseObject, error) {
+	err := s.validate(ctx, request.Params)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("ForecastSubscriptions validation failed")
+		return ForecastSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.validationProblem(ctx, err), StatusCode: 422}, nil
+	}
+
+	params := subscriptions.ForecastParams{
+		StartDate: subscriptions.MonthStart(time.Now()).AddDate(0, 1, 0),
+	}
+
+	if request.Params.StartDate != nil {
+		params.StartDate, _ = time.Parse("01-2006", *request.Params.StartDate)
+	}
+
+	params.EndDate = params.StartDate.AddDate(0, 11, 0)
+	if request.Params.EndDate != nil {
+		params.EndDate, _ = time.Parse("01-2006", *request.Params.EndDate)
+	}
+
+	months := subscriptions.MonthsBetween(params.StartDate, params.EndDate)
+	if months < 0 {
+		return ForecastSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.fieldProblem(ctx, "end_date", "gtefield", "start_date"), StatusCode: 422}, nil
+	}
+	if months >= maxReportMonths {
+		return ForecastSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.fieldProblem(ctx, "end_date", "maxPeriod", strconv.Itoa(maxReportMonths)), StatusCode: 422}, nil
+	}
+
+	if request.Params.UserId != nil {
+		params.Filter.UserIds = *request.Params.UserId
+	}
+
+	if request.Params.ServiceName != nil {
+		params.Filter.ServiceNames = *request.Params.ServiceName
+	}
+
+	if request.Params.Category != nil {
+		params.Filter.Categories = *request.Params.Category
+	}
+
+	if request.Params.Tag != nil {
+		params.Filter.Tags = normalizeTags(*request.Params.Tag)
+	}
+
+	if request.Params.Cancel != nil {
+		params.Cancel = *request.Params.Cancel
+	}
+
+	if request.Params.PriceChange != nil {
+		params.PriceChangePercent = *request.Params.PriceChange
+	}
+
+	forecast, err := s.Repo.GetForecast(ctx, params)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("ForecastSubscriptions failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("received forecast")
+
+	res := ForecastSubscriptions200JSONResponse{Months: make([]ForecastMonth, 0, len(forecast))}
+	for _, month := range forecast {
+		res.TotalPrice += month.TotalPrice
+		res.Months = append(res.Months, ForecastMonth{Month: month.Month.Format("01-2006"), TotalPrice: month.TotalPrice})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,48 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestImplForecast(t *testing.T) {
+	webApp := prepareServ()
+
+	req := httptest.NewRequest("GET", "/stats/forecast", nil)
+
+	resp, _ := webApp.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	var forecast ForecastSubscriptions200JSONResponse
+	_ = json.Unmarshal(body, &forecast)
+
+	if len(forecast.Months) != 12 || forecast.TotalPrice != 12*(125+250+500) {
+		t.Errorf("incorrect forecast, response body: %s", string(body))
+	}
+
+	if forecast.Months[0].Month != time.Now().AddDate(0, 1, -time.Now().Day()+1).Format("01-2006") {
+		t.Errorf("incorrect first month, response body: %s", string(body))
+	}
+
+	req = httptest.NewRequest("GET", "/stats/forecast?cancel="+id3.String()+"&price_change=-20&end_date="+forecast.Months[1].Month, nil)
+
+	resp, _ = webApp.Test(req)
+	body, _ = io.ReadAll(resp.Body)
+
+	if !bytes.Contains(body, []byte(`"total_price":600`)) {
+		t.Errorf("incorrect scenario, response body: %s", string(body))
+	}
+
+	for _, query := range []string{"price_change=-101", "start_date=05-2030&end_date=04-2030", "end_date=01-2099", "start_date=13-2030"} {
+		req = httptest.NewRequest("GET", "/stats/forecast?"+query, nil)
+
+		resp, _ = webApp.Test(req)
+		if resp.StatusCode != 422 {
+			t.Errorf("%s: invalid status code: %d, expected %d", query, resp.StatusCode, 422)
+		}
+	}
+}
@@ -3,9 +3,18 @@ package transport
 import (
 	"context"
 	"errors"
+	"ew/internal/database"
+	"ew/internal/logging"
+	"ew/internal/models/events"
+	"ew/internal/models/idempotency"
+	"ew/internal/models/services"
 	"ew/internal/models/subscriptions"
+	"net/http"
+	"slices"
+	"strings"
 	"time"
 
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	"github.com/sirupsen/logrus"
 )
@@ -14,7 +23,20 @@ var InternalError = errors.New("internal server error")
 
 type Server struct {
 	Repo      subscriptions.SubscriptionRepo
+	Services  services.ServiceRepo
 	Validator *validator.Validate
+
+	// Translations is the registry of the messages for the validation rules
+	Translations *ut.UniversalTranslator
+	// DefaultLocale is used when Accept-Language names no supported language
+	DefaultLocale string
+
+	// Idempotency stores the Idempotency-Key of created subscriptions for IdempotencyTTL, the header is ignored without it
+	Idempotency    idempotency.Repo
+	IdempotencyTTL time.Duration
+
+	// Events is the log GET /subscriptions/events streams from, the endpoint is not implemented without it
+	Events events.Repo
 }
 
 func validateDateFormat(fl validator.FieldLevel) bool {
@@ -28,32 +50,49 @@ func validateDateFormat(fl validator.FieldLevel) bool {
 	return true
 }
 
-func NewServer(repo subscriptions.SubscriptionRepo, validate *validator.Validate) Server {
+// validateNotBlank rejects the names made of spaces, the storage trims them to nothing.
+func validateNotBlank(fl validator.FieldLevel) bool {
+	return strings.TrimSpace(fl.Field().String()) != ""
+}
+
+func NewServer(repo subscriptions.SubscriptionRepo, serviceRepo services.ServiceRepo, validate *validator.Validate) Server {
 	err := validate.RegisterValidation("dateFormat", validateDateFormat)
 	if err != nil {
 		logrus.WithError(err).Error("Failed to register validator")
 	}
+	err = validate.RegisterValidation("notBlank", validateNotBlank)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to register validator")
+	}
 
 	listRules := map[string]string{
-		"Offset": "omitempty,min=0",
-		"Limit":  "omitempty,min=1",
+		"Offset":   "omitempty,min=0",
+		"Limit":    "omitempty,min=1",
+		"PriceMin": "omitempty,min=0",
+		"PriceMax": "omitempty,min=0",
+		"Status":   "omitempty,oneof=active ended future trial",
 	}
 	validate.RegisterStructValidationMapRules(listRules, ListSubscriptionsParams{})
 
 	updateRules := map[string]string{
-		"Price":     "omitempty,min=1",
-		"UserId":    "omitempty",
-		"EndDate":   "omitempty,dateFormat",
-		"StartDate": "omitempty,dateFormat",
+		"ServiceName":  "omitempty,min=1,notBlank",
+		"Price":        "omitempty,min=1",
+		"UserId":       "omitempty",
+		"EndDate":      "omitempty,dateFormat",
+		"StartDate":    "omitempty,dateFormat",
+		"TrialEndDate": "omitempty,dateFormat",
+		"Tags":         "omitempty,dive,required",
 	}
 	validate.RegisterStructValidationMapRules(updateRules, UpdateSubscriptionJSONRequestBody{})
 
 	createRules := map[string]string{
-		"Price":       "required,min=1",
-		"UserId":      "required",
-		"EndDate":     "omitempty,dateFormat",
-		"StartDate":   "required,dateFormat",
-		"ServiceName": "required",
+		"Price":        "required,min=1",
+		"UserId":       "required",
+		"EndDate":      "omitempty,dateFormat",
+		"StartDate":    "required,dateFormat",
+		"TrialEndDate": "omitempty,dateFormat",
+		"ServiceName":  "required,notBlank",
+		"Tags":         "omitempty,dive,required",
 	}
 	validate.RegisterStructValidationMapRules(createRules, CreateSubscriptionJSONRequestBody{})
 
@@ -62,57 +101,279 @@ func NewServer(repo subscriptions.SubscriptionRepo, validate *validator.Validate
 		"EndDate":     "omitempty,dateFormat",
 		"StartDate":   "omitempty,dateFormat",
 		"ServiceName": "omitempty",
+		"GroupBy":     "omitempty,oneof=service category",
 	}
-	validate.RegisterStructValidationMapRules(statsRules, StatsSubscriptionsRequestObject{})
+	validate.RegisterStructValidationMapRules(statsRules, StatsSubscriptionsParams{})
 
-	return Server{Repo: repo, Validator: validate}
+	registerServiceRules(validate)
+	registerPauseRules(validate)
+	registerForecastRules(validate)
+	registerReminderRules(validate)
+	registerTopRules(validate)
+	registerChurnRules(validate)
+	registerCohortRules(validate)
+	registerIdempotencyRules(validate)
+	registerBatchRules(validate)
+
+	return Server{Repo: repo, Services: serviceRepo, Validator: validate, Translations: newTranslations(validate), DefaultLocale: DefaultLocale, IdempotencyTTL: DefaultIdempotencyTTL}
 }
 
-func (s Server) UpdateSubscription(ctx context.Context, request UpdateSubscriptionRequestObject) (UpdateSubscriptionResponseObject, error) {
-	err := s.Validator.Struct(request.Body)
+// normalizeTags lower-cases tags and drops duplicates so that tag filters are case-insensitive.
+func normalizeTags(tags []string) database.TextArray {
+	res := make(database.TextArray, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(res, tag) {
+			res = append(res, tag)
+		}
+	}
+	return res
+}
+
+// newSubscription checks a subscription to create with the rules NewServer registers and resolves its service.
+// A rejected subscription is reported with the problem, the error is only returned for internal failures.
+func (s Server) newSubscription(ctx context.Context, body *Subscription) (*subscriptions.Subscription, *Problem, error) {
+	err := s.validate(ctx, body)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("subscription validation failed")
+		invalid := s.validationProblem(ctx, err)
+		return nil, &invalid, nil
+	}
+
+	item := &subscriptions.Subscription{
+		ServiceName: body.ServiceName,
+		UserId:      body.UserId,
+		Price:       uint(body.Price),
+		Category:    body.Category,
+	}
+
+	if body.Tags != nil {
+		item.Tags = normalizeTags(*body.Tags)
+	}
+
+	parse, err := time.Parse("01-2006", body.StartDate)
 	if err != nil {
-		logrus.WithError(err).Error("UpdateSubscription validation failed")
-		return UpdateSubscription422JSONResponse{Code: 422, Message: err.Error()}, nil
+		logging.FromContext(ctx).WithError(err).Error("failed to parse start date")
+		invalid := s.fieldProblem(ctx, "start_date", "dateFormat", "")
+		return nil, &invalid, nil
+	}
+	item.StartDate = parse
+
+	if body.EndDate != nil {
+		parse, err = time.Parse("01-2006", *body.EndDate)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("failed to parse end date")
+			invalid := s.fieldProblem(ctx, "end_date", "dateFormat", "")
+			return nil, &invalid, nil
+		}
+		if parse.Before(item.StartDate) {
+			invalid := s.periodProblem(ctx)
+			return nil, &invalid, nil
+		}
+		item.EndDate = &parse
+	}
+
+	if body.TrialEndDate != nil {
+		parse, err = time.Parse("01-2006", *body.TrialEndDate)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("failed to parse trial end date")
+			invalid := s.fieldProblem(ctx, "trial_end_date", "dateFormat", "")
+			return nil, &invalid, nil
+		}
+		if parse.Before(item.StartDate) {
+			invalid := s.trialProblem(ctx)
+			return nil, &invalid, nil
+		}
+		item.TrialEndDate = &parse
+	}
+
+	// the service is looked up once the subscription is valid, the storage adds an unknown one to the catalog
+	service, err := s.findService(ctx, body.ServiceId, item.ServiceName)
+	if errors.Is(err, services.NotFound) {
+		invalid := s.fieldProblem(ctx, "service_id", "serviceExists", "")
+		return nil, &invalid, nil
+	}
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("failed to find service")
+		return nil, nil, InternalError
+	}
+	if service != nil {
+		item.ServiceId = &service.ID
+		item.ServiceName = service.Name
+
+		if item.Category == nil {
+			item.Category = service.Category
+		}
+	}
+
+	return item, nil, nil
+}
+
+// subscriptionPatch is newSubscription for the changes of an existing subscription.
+func (s Server) subscriptionPatch(ctx context.Context, id UUID, body *SubscriptionPatch) (*subscriptions.SubscriptionPatch, *Problem, error) {
+	err := s.validate(ctx, body)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("subscription validation failed")
+		invalid := s.validationProblem(ctx, err)
+		return nil, &invalid, nil
 	}
 
 	item := &subscriptions.SubscriptionPatch{
-		ID:          request.SubscriptionId,
-		ServiceName: request.Body.ServiceName,
-		UserId:      request.Body.UserId,
+		ID:       id,
+		UserId:   body.UserId,
+		Category: body.Category,
 	}
 
-	if request.Body.Price != nil {
-		price := uint(*request.Body.Price)
+	if body.Tags != nil {
+		tags := normalizeTags(*body.Tags)
+		item.Tags = &tags
+	}
+
+	if body.Price != nil {
+		price := uint(*body.Price)
 		item.Price = &price
 	}
 
-	if request.Body.StartDate != nil {
-		parse, err := time.Parse("01-2006", *request.Body.StartDate)
+	if body.StartDate != nil {
+		parse, err := time.Parse("01-2006", *body.StartDate)
 		if err != nil {
-			logrus.WithError(err).Error("failed to parse start date")
-			return UpdateSubscription422JSONResponse{Code: 422, Message: "incorrect start_date format"}, nil
+			logging.FromContext(ctx).WithError(err).Error("failed to parse start date")
+			invalid := s.fieldProblem(ctx, "start_date", "dateFormat", "")
+			return nil, &invalid, nil
 		}
 		item.StartDate = &parse
 	}
 
-	if request.Body.EndDate != nil {
-		parse, err := time.Parse("01-2006", *request.Body.EndDate)
+	if body.EndDate != nil {
+		parse, err := time.Parse("01-2006", *body.EndDate)
 		if err != nil {
-			logrus.WithError(err).Error("failed to parse end date")
-			return UpdateSubscription422JSONResponse{Code: 422, Message: "incorrect end_date format"}, nil
+			logging.FromContext(ctx).WithError(err).Error("failed to parse end date")
+			invalid := s.fieldProblem(ctx, "end_date", "dateFormat", "")
+			return nil, &invalid, nil
 		}
 		item.EndDate = &parse
 	}
 
-	updated, err := s.Repo.Update(ctx, item)
+	if body.TrialEndDate != nil {
+		parse, err := time.Parse("01-2006", *body.TrialEndDate)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("failed to parse trial end date")
+			invalid := s.fieldProblem(ctx, "trial_end_date", "dateFormat", "")
+			return nil, &invalid, nil
+		}
+		item.TrialEndDate = &parse
+	}
+
+	if body.ServiceId != nil || body.ServiceName != nil {
+		var name string
+		if body.ServiceName != nil {
+			name = *body.ServiceName
+		}
+
+		service, err := s.findService(ctx, body.ServiceId, name)
+		if errors.Is(err, services.NotFound) {
+			invalid := s.fieldProblem(ctx, "service_id", "serviceExists", "")
+			return nil, &invalid, nil
+		}
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("failed to find service")
+			return nil, nil, InternalError
+		}
+		if service != nil {
+			item.ServiceId = &service.ID
+			item.ServiceName = &service.Name
+		} else {
+			item.ServiceName = &name
+		}
+	}
+
+	return item, nil, nil
+}
+
+// periodProblem reports a subscription ending before it starts.
+func (s Server) periodProblem(ctx context.Context) Problem {
+	return s.fieldProblem(ctx, "end_date", "gtefield", "start_date")
+}
+
+// trialProblem reports a trial ending before the subscription starts.
+func (s Server) trialProblem(ctx context.Context) Problem {
+	return s.fieldProblem(ctx, "trial_end_date", "gtefield", "start_date")
+}
+
+// checkPatchedDates applies the date rules of newSubscription to the subscription as it would be after the patch.
+func (s Server) checkPatchedDates(ctx context.Context, current *subscriptions.Subscription, patch *subscriptions.SubscriptionPatch) *Problem {
+	var invalid Problem
+	switch patch.CheckDates(current) {
+	case subscriptions.InvalidPeriod:
+		invalid = s.periodProblem(ctx)
+	case subscriptions.InvalidTrial:
+		invalid = s.trialProblem(ctx)
+	default:
+		return nil
+	}
+	return &invalid
+}
+
+// patchSubscription writes the patch if it passes checkPatchedDates against the stored subscription,
+// which must not change before the write. A missing subscription is reported with subscriptions.NotFound.
+func (s Server) patchSubscription(ctx context.Context, item *subscriptions.SubscriptionPatch) (*Problem, error) {
+	var invalid *Problem
+
+	err := s.Repo.WithTx(ctx, func(repo subscriptions.SubscriptionRepo) error {
+		current, err := repo.GetByID(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+
+		invalid = s.checkPatchedDates(ctx, current, item)
+		if invalid != nil {
+			return nil
+		}
+
+		_, err = repo.Update(ctx, item)
+		return err
+	})
+	// the storage checks the period as well
+	if errors.Is(err, subscriptions.InvalidPeriod) {
+		invalid := s.periodProblem(ctx)
+		return &invalid, nil
+	}
+	return invalid, err
+}
+
+// UpdateSubscription takes either a plain patch, which only sets fields, or a merge patch, where null clears them.
+func (s Server) UpdateSubscription(ctx context.Context, request UpdateSubscriptionRequestObject) (UpdateSubscriptionResponseObject, error) {
+	var (
+		item    *subscriptions.SubscriptionPatch
+		invalid *Problem
+		err     error
+	)
+	switch {
+	case request.JSONBody != nil:
+		item, invalid, err = s.subscriptionPatch(ctx, request.SubscriptionId, request.JSONBody)
+	case request.ApplicationMergePatchPlusJSONBody != nil:
+		item, invalid, err = s.mergePatch(ctx, request.SubscriptionId, request.ApplicationMergePatchPlusJSONBody)
+	default:
+		return UpdateSubscriptiondefaultApplicationProblemPlusJSONResponse{Body: problem(http.StatusUnsupportedMediaType, problemGeneric, ""), StatusCode: http.StatusUnsupportedMediaType}, nil
+	}
 	if err != nil {
-		logrus.WithError(err).Error("UpdateSubscription failed")
-		return nil, InternalError
+		return nil, err
+	}
+	if invalid != nil {
+		return UpdateSubscription422ApplicationProblemPlusJSONResponse(*invalid), nil
 	}
-	if updated == 0 {
-		return UpdateSubscription404Response{}, nil
+
+	invalid, err = s.patchSubscription(ctx, item)
+	switch {
+	case errors.Is(err, subscriptions.NotFound):
+		return UpdateSubscription404ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusNotFound, problemGeneric, "subscriptionNotFound")), nil
+	case err != nil:
+		logging.FromContext(ctx).WithError(err).Error("UpdateSubscription failed")
+		return nil, InternalError
+	case invalid != nil:
+		return UpdateSubscription422ApplicationProblemPlusJSONResponse(*invalid), nil
 	}
-	logrus.Info("updated subscription")
+	logging.FromContext(ctx).Info("updated subscription")
 
 	return UpdateSubscription204Response{}, nil
 }
@@ -125,35 +386,92 @@ func convertRepoToResponse(item *subscriptions.Subscription) Subscription {
 		end = &date
 	}
 
+	var trialEnd *string
+
+	if item.TrialEndDate != nil {
+		date := item.TrialEndDate.Format("01-2006")
+		trialEnd = &date
+	}
+
+	tags := []string(item.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+
+	pauses := convertPausesToResponse(item.Pauses)
+
 	return Subscription{
 		SubscriptionId: &item.ID,
+		ServiceId:      item.ServiceId,
 		ServiceName:    item.ServiceName,
 		Price:          int(item.Price),
 		UserId:         item.UserId,
 		StartDate:      item.StartDate.Format("01-2006"),
 		EndDate:        end,
+		TrialEndDate:   trialEnd,
+		Category:       item.Category,
+		Tags:           &tags,
+		Pauses:         &pauses,
 	}
 }
 
 func (s Server) ListSubscriptions(ctx context.Context, request ListSubscriptionsRequestObject) (ListSubscriptionsResponseObject, error) {
-	err := s.Validator.Struct(request.Params)
+	err := s.validate(ctx, request.Params)
 	if err != nil {
-		logrus.WithError(err).Error("ListSubscriptions validation failed")
-		return ListSubscriptionsdefaultJSONResponse{Body: Error{Code: 422, Message: err.Error()}, StatusCode: 422}, nil
+		logging.FromContext(ctx).WithError(err).Error("ListSubscriptions validation failed")
+		return ListSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.validationProblem(ctx, err), StatusCode: 422}, nil
 	}
 
 	params := subscriptions.SubscriptionListParams{
-		Offset:      request.Params.Offset,
-		Limit:       request.Params.Limit,
-		UserId:      request.Params.UserId,
-		ServiceName: request.Params.ServiceName,
+		Offset:            request.Params.Offset,
+		Limit:             request.Params.Limit,
+		ServiceNamePrefix: request.Params.ServiceNamePrefix,
+	}
+
+	if request.Params.UserId != nil {
+		params.UserIds = *request.Params.UserId
+	}
+
+	if request.Params.ServiceName != nil {
+		params.ServiceNames, err = s.serviceNames(ctx, *request.Params.ServiceName)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("ListSubscriptions failed")
+			return nil, InternalError
+		}
+	}
+
+	if request.Params.Category != nil {
+		params.Categories = *request.Params.Category
+	}
+
+	if request.Params.Tag != nil {
+		params.Tags = normalizeTags(*request.Params.Tag)
+	}
+
+	if request.Params.PriceMin != nil {
+		price := uint(*request.Params.PriceMin)
+		params.PriceMin = &price
+	}
+
+	if request.Params.PriceMax != nil {
+		price := uint(*request.Params.PriceMax)
+		params.PriceMax = &price
+	}
+
+	if params.PriceMin != nil && params.PriceMax != nil && *params.PriceMin > *params.PriceMax {
+		return ListSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.fieldProblem(ctx, "price_max", "gtefield", "price_min"), StatusCode: 422}, nil
+	}
+
+	if request.Params.Status != nil {
+		status := subscriptions.Status(*request.Params.Status)
+		params.Status = &status
 	}
 
 	if request.Params.StartDate != nil {
 		parse, err := time.Parse("01-2006", *request.Params.StartDate)
 		if err != nil {
-			logrus.WithError(err).Error("failed to parse start date")
-			return ListSubscriptionsdefaultJSONResponse{Body: Error{Code: 422, Message: err.Error()}, StatusCode: 422}, nil
+			logging.FromContext(ctx).WithError(err).Error("failed to parse start date")
+			return ListSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.fieldProblem(ctx, "start_date", "dateFormat", ""), StatusCode: 422}, nil
 		}
 		params.StartDate = &parse
 	}
@@ -161,24 +479,24 @@ func (s Server) ListSubscriptions(ctx context.Context, request ListSubscriptions
 	if request.Params.EndDate != nil {
 		parse, err := time.Parse("01-2006", *request.Params.EndDate)
 		if err != nil {
-			logrus.WithError(err).Error("failed to parse end date")
-			return ListSubscriptionsdefaultJSONResponse{Body: Error{Code: 422, Message: err.Error()}, StatusCode: 422}, nil
+			logging.FromContext(ctx).WithError(err).Error("failed to parse end date")
+			return ListSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.fieldProblem(ctx, "end_date", "dateFormat", ""), StatusCode: 422}, nil
 		}
 		params.EndDate = &parse
 	}
 
 	items, err := s.Repo.GetList(ctx, params)
 	if err != nil {
-		logrus.WithError(err).Error("ListSubscriptions failed")
+		logging.FromContext(ctx).WithError(err).Error("ListSubscriptions failed")
 		return nil, InternalError
 	}
-	logrus.Info("received subscriptions list")
+	logging.FromContext(ctx).Info("received subscriptions list")
 
 	res := make([]Subscription, 0, len(items))
 	for _, item := range items {
 		res = append(res, convertRepoToResponse(item))
 	}
-	logrus.WithFields(logrus.Fields{
+	logging.FromContext(ctx).WithFields(logrus.Fields{
 		"length": len(res),
 	}).Info("prepared subscriptions list")
 
@@ -188,76 +506,78 @@ func (s Server) ListSubscriptions(ctx context.Context, request ListSubscriptions
 func (s Server) ReadSubscription(ctx context.Context, request ReadSubscriptionRequestObject) (ReadSubscriptionResponseObject, error) {
 	item, err := s.Repo.GetByID(ctx, request.SubscriptionId)
 	if errors.Is(err, subscriptions.NotFound) {
-		return ReadSubscription404Response{}, nil
+		return ReadSubscription404ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusNotFound, problemGeneric, "subscriptionNotFound")), nil
 	}
 	if err != nil {
-		logrus.WithError(err).Error("ReadSubscription failed")
+		logging.FromContext(ctx).WithError(err).Error("ReadSubscription failed")
 		return nil, InternalError
 	}
-	logrus.Info("received subscription")
+	logging.FromContext(ctx).Info("received subscription")
 
 	return ReadSubscription200JSONResponse(convertRepoToResponse(item)), nil
 }
 
-func (s Server) CreateSubscription(ctx context.Context, request CreateSubscriptionRequestObject) (CreateSubscriptionResponseObject, error) {
-	err := s.Validator.Struct(request.Body)
+func (s Server) createSubscription(ctx context.Context, request CreateSubscriptionRequestObject) (CreateSubscriptionResponseObject, error) {
+	item, invalid, err := s.newSubscription(ctx, request.Body)
 	if err != nil {
-		logrus.WithError(err).Error("CreateSubscription validation failed")
-		return CreateSubscription422JSONResponse{Code: 422, Message: err.Error()}, nil
+		return nil, err
 	}
-
-	item := &subscriptions.Subscription{
-		ServiceName: request.Body.ServiceName,
-		UserId:      request.Body.UserId,
-		Price:       uint(request.Body.Price),
+	if invalid != nil {
+		return CreateSubscription422ApplicationProblemPlusJSONResponse(*invalid), nil
 	}
 
-	parse, err := time.Parse("01-2006", request.Body.StartDate)
+	overlapping, err := s.Repo.FindOverlapping(ctx, item)
 	if err != nil {
-		logrus.WithError(err).Error("failed to parse start date")
-		return CreateSubscription422JSONResponse{Code: 422, Message: "incorrect start_date format"}, nil
+		logging.FromContext(ctx).WithError(err).Error("failed to find overlapping subscriptions")
+		return nil, InternalError
 	}
-	item.StartDate = parse
-
-	if request.Body.EndDate != nil {
-		parse, err = time.Parse("01-2006", *request.Body.EndDate)
-		if err != nil {
-			logrus.WithError(err).Error("failed to parse end date")
-			return CreateSubscription422JSONResponse{Code: 422, Message: "incorrect end_date format"}, nil
-		}
-		item.EndDate = &parse
+	if len(overlapping) > 0 && request.Params.RejectDuplicates != nil && *request.Params.RejectDuplicates {
+		return CreateSubscription409ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusConflict, problemConflict, "subscriptionOverlaps")), nil
 	}
 
 	id, err := s.Repo.Add(ctx, item)
+	if errors.Is(err, subscriptions.InvalidPeriod) {
+		return CreateSubscription422ApplicationProblemPlusJSONResponse(s.periodProblem(ctx)), nil
+	}
 	if err != nil {
-		logrus.WithError(err).Error("CreateSubscription failed")
+		logging.FromContext(ctx).WithError(err).Error("CreateSubscription failed")
 		return nil, InternalError
 	}
 
-	logrus.Info("created subscription")
+	logging.FromContext(ctx).Info("created subscription")
+
+	res := CreateSubscription200JSONResponse{SubscriptionId: &id}
+	if len(overlapping) > 0 {
+		duplicates := make([]UUID, 0, len(overlapping))
+		for _, other := range overlapping {
+			duplicates = append(duplicates, other.ID)
+		}
+		res.Duplicates = &duplicates
+		logging.FromContext(ctx).WithField("duplicates", duplicates).Warn("created subscription overlaps existing ones")
+	}
 
-	return CreateSubscription200JSONResponse{&id}, nil
+	return res, nil
 }
 
 func (s Server) DeleteSubscription(ctx context.Context, request DeleteSubscriptionRequestObject) (DeleteSubscriptionResponseObject, error) {
 	deleted, err := s.Repo.Delete(ctx, request.SubscriptionId)
 	if err != nil {
-		logrus.WithError(err).Error("DeleteSubscription failed")
+		logging.FromContext(ctx).WithError(err).Error("DeleteSubscription failed")
 		return nil, InternalError
 	}
 	if deleted == 0 {
-		return DeleteSubscription404Response{}, nil
+		return DeleteSubscription404ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusNotFound, problemGeneric, "subscriptionNotFound")), nil
 	}
-	logrus.Info("deleted subscription")
+	logging.FromContext(ctx).Info("deleted subscription")
 
 	return DeleteSubscription204Response{}, nil
 }
 
 func (s Server) StatsSubscriptions(ctx context.Context, request StatsSubscriptionsRequestObject) (StatsSubscriptionsResponseObject, error) {
-	err := s.Validator.Struct(request.Params)
+	err := s.validate(ctx, request.Params)
 	if err != nil {
-		logrus.WithError(err).Error("StatsSubscriptions validation failed")
-		return StatsSubscriptionsdefaultJSONResponse{Body: Error{Code: 422, Message: err.Error()}, StatusCode: 422}, nil
+		logging.FromContext(ctx).WithError(err).Error("StatsSubscriptions validation failed")
+		return StatsSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.validationProblem(ctx, err), StatusCode: 422}, nil
 	}
 
 	var periodStart, periodEnd *time.Time
@@ -270,20 +590,58 @@ func (s Server) StatsSubscriptions(ctx context.Context, request StatsSubscriptio
 		en, _ := time.Parse("01-2006", *request.Params.EndDate)
 		periodEnd = &en
 	}
-	logrus.WithFields(logrus.Fields{"end": periodEnd, "start": periodStart}).Debug("stats subscriptions")
+	logging.FromContext(ctx).WithFields(logrus.Fields{"end": periodEnd, "start": periodStart}).Debug("stats subscriptions")
 
-	total, err := s.Repo.GetStats(ctx, subscriptions.SubscriptionListParams{
-		UserId:      request.Params.UserId,
-		ServiceName: request.Params.ServiceName,
-		StartDate:   periodStart,
-		EndDate:     periodEnd,
-	})
+	params := subscriptions.SubscriptionListParams{
+		StartDate: periodStart,
+		EndDate:   periodEnd,
+	}
+
+	if request.Params.UserId != nil {
+		params.UserIds = *request.Params.UserId
+	}
+
+	if request.Params.ServiceName != nil {
+		params.ServiceNames, err = s.serviceNames(ctx, *request.Params.ServiceName)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("StatsSubscriptions failed")
+			return nil, InternalError
+		}
+	}
+
+	if request.Params.Category != nil {
+		params.Categories = *request.Params.Category
+	}
+
+	if request.Params.Tag != nil {
+		params.Tags = normalizeTags(*request.Params.Tag)
+	}
+
+	if request.Params.GroupBy != nil {
+		groups, err := s.Repo.GetGroupedStats(ctx, params, subscriptions.GroupBy(*request.Params.GroupBy))
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("StatsSubscriptions failed")
+			return nil, InternalError
+		}
+		logging.FromContext(ctx).Info("received grouped stats")
+
+		total := 0
+		res := make([]StatsGroup, 0, len(groups))
+		for _, group := range groups {
+			total += group.TotalPrice
+			res = append(res, StatsGroup{Key: group.Key, Name: group.Name, TotalPrice: group.TotalPrice})
+		}
+
+		return StatsSubscriptions200JSONResponse{TotalPrice: &total, Groups: &res}, nil
+	}
+
+	total, err := s.Repo.GetStats(ctx, params)
 	if err != nil {
-		logrus.WithError(err).Error("StatsSubscriptions failed")
+		logging.FromContext(ctx).WithError(err).Error("StatsSubscriptions failed")
 		return nil, InternalError
 	}
 
-	logrus.Info("received stats")
+	logging.FromContext(ctx).Info("received stats")
 
 	return StatsSubscriptions200JSONResponse{TotalPrice: &total}, nil
 }
@@ -2,6 +2,7 @@ package transport
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"ew/internal/models/subscriptions"
 	"ew/internal/storage/inmemory"
@@ -18,10 +19,11 @@ import (
 )
 
 var (
-	id1  = uuid.New()
-	id2  = uuid.New()
-	id3  = uuid.New()
-	repo *inmemory.SubscriptionRepository
+	id1         = uuid.New()
+	id2         = uuid.New()
+	id3         = uuid.New()
+	repo        *inmemory.SubscriptionRepository
+	serviceRepo *inmemory.ServiceRepository
 )
 
 func prepareServ() *fiber.App {
@@ -30,11 +32,15 @@ func prepareServ() *fiber.App {
 		{ID: id2, ServiceName: "item 2", Price: 250, StartDate: time.Now().AddDate(0, -2, 0), UserId: uuid.New()},
 		{ID: id3, ServiceName: "nothing", Price: 500, StartDate: time.Now().AddDate(0, -1, 0), UserId: uuid.New()},
 	})
+	serviceRepo = inmemory.NewServiceRepo(nil, repo)
 	validate := validator.New()
 
-	server := NewServer(repo, validate)
+	server := NewServer(repo, serviceRepo, validate)
+	server.Idempotency = inmemory.NewIdempotencyRepo()
 
-	webApp := fiber.New()
+	webApp := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	webApp.Use(ProblemContentType)
+	webApp.Use(Locale)
 
 	RegisterHandlers(webApp, NewStrictHandler(
 		server,
@@ -84,6 +90,44 @@ func TestImplList(t *testing.T) {
 	if !bytes.Contains(body, []byte(title)) {
 		t.Errorf("no text found")
 	}
+
+	req = httptest.NewRequest("GET", "/subscriptions?user_id="+repo.Items[0].UserId.String()+"&user_id="+repo.Items[1].UserId.String()+"&price_min=200", nil)
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d", resp.StatusCode)
+	}
+	body, _ = io.ReadAll(resp.Body)
+
+	if bytes.Contains(body, []byte(title)) || !bytes.Contains(body, []byte("item 2")) {
+		t.Errorf("incorrect filtering, response body: %s", string(body))
+	}
+
+	req = httptest.NewRequest("GET", "/subscriptions?service_name_prefix=SOME&status=active", nil)
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d", resp.StatusCode)
+	}
+	body, _ = io.ReadAll(resp.Body)
+
+	if !bytes.Contains(body, []byte(title)) || bytes.Contains(body, []byte("item 2")) {
+		t.Errorf("incorrect filtering, response body: %s", string(body))
+	}
+
+	req = httptest.NewRequest("GET", "/subscriptions?status=unknown", nil)
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 422 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 422)
+	}
+
+	req = httptest.NewRequest("GET", "/subscriptions?price_min=500&price_max=100", nil)
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 422 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 422)
+	}
 }
 
 func TestImplGet(t *testing.T) {
@@ -169,6 +213,81 @@ func TestImplUpdate(t *testing.T) {
 	}
 }
 
+func TestImplUpdateChecks(t *testing.T) {
+	webApp := prepareServ()
+
+	cases := []struct {
+		id     uuid.UUID
+		body   string
+		status int
+	}{
+		{id2, `{"trial_end_date":"` + time.Now().AddDate(0, -1, 0).Format("01-2006") + `"}`, 204},
+		// the stored trial end must stay after the new start
+		{id2, `{"start_date":"` + time.Now().Format("01-2006") + `"}`, 422},
+		{uuid.New(), `{"price":1}`, 404},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest("PATCH", "/subscriptions/"+c.id.String(), bytes.NewBufferString(c.body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, _ := webApp.Test(req)
+		if resp.StatusCode != c.status {
+			t.Errorf("%s: invalid status code: %d, expected %d", c.body, resp.StatusCode, c.status)
+		}
+	}
+
+	item, _ := repo.GetByID(context.TODO(), id2)
+	if item.TrialEndDate == nil || item.StartDate.After(*item.TrialEndDate) {
+		t.Errorf("rejected patch applied: %v", item)
+	}
+}
+
+func TestImplPeriod(t *testing.T) {
+	webApp := prepareServ()
+
+	before := time.Now().AddDate(0, -5, 0).Format("01-2006")
+	cases := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{"POST", "/subscriptions/", `{"service_name":"test","price":100,"user_id":"` + uuid.New().String() + `","start_date":"03-2024","end_date":"02-2024"}`},
+		{"PUT", "/subscriptions/" + id1.String(), `{"service_name":"test","price":100,"user_id":"` + uuid.New().String() + `","start_date":"03-2024","end_date":"02-2024"}`},
+		// the stored start date is two months ago
+		{"PATCH", "/subscriptions/" + id2.String(), `{"end_date":"` + before + `"}`},
+		{"PATCH", "/subscriptions/" + id2.String(), `{"start_date":"` + time.Now().AddDate(1, 0, 0).Format("01-2006") + `","end_date":"` + time.Now().Format("01-2006") + `"}`},
+		{"POST", "/subscriptions/batch", `{"operations":[{"op":"update","subscription_id":"` + id2.String() + `","patch":{"end_date":"` + before + `"}}]}`},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(c.method, c.path, bytes.NewBufferString(c.body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, _ := webApp.Test(req)
+		body, _ := io.ReadAll(resp.Body)
+
+		var res struct {
+			Problem
+			Results []BatchResult `json:"results"`
+		}
+		_ = json.Unmarshal(body, &res)
+
+		invalid := res.Problem
+		if len(res.Results) == 1 && res.Results[0].Error != nil {
+			invalid = *res.Results[0].Error
+		}
+		if invalid.Status != 422 || invalid.Errors == nil || (*invalid.Errors)[0].Field != "end_date" || (*invalid.Errors)[0].Rule != "gtefield" {
+			t.Errorf("%s %s: period not rejected: %s", c.method, c.path, string(body))
+		}
+	}
+
+	item, _ := repo.GetByID(context.TODO(), id2)
+	if item.EndDate != nil {
+		t.Errorf("rejected end date applied: %v", item)
+	}
+}
+
 func TestImplCreate(t *testing.T) {
 	webApp := prepareServ()
 
@@ -297,3 +416,151 @@ func TestImplStats(t *testing.T) {
 		t.Errorf("incorrect total, response body: %s", string(body))
 	}
 }
+
+func TestImplCategoriesTags(t *testing.T) {
+	webApp := prepareServ()
+
+	category := "music"
+	tags := []string{"Family", "work", "family"}
+	item := Subscription{
+		Price:       300,
+		StartDate:   time.Now().AddDate(0, -2, 0).Format("01-2006"),
+		UserId:      uuid.New(),
+		ServiceName: "Spotify",
+		Category:    &category,
+		Tags:        &tags,
+	}
+
+	jsonStr, _ := json.Marshal(item)
+
+	req := httptest.NewRequest("POST", "/subscriptions", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 200)
+	}
+
+	created := repo.Items[len(repo.Items)-1]
+	if !slices.Equal(created.Tags, []string{"family", "work"}) {
+		t.Errorf("tags not normalized: %v", created.Tags)
+	}
+
+	video := "video"
+	repo.Items[0].Category = &video
+	repo.Items[1].Category = &video
+
+	req = httptest.NewRequest("GET", "/subscriptions?category=music&tag=FAMILY", nil)
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d", resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+
+	if !bytes.Contains(body, []byte("Spotify")) || bytes.Contains(body, []byte("some item")) {
+		t.Errorf("incorrect filtering, response body: %s", string(body))
+	}
+
+	req = httptest.NewRequest("GET", "/subscriptions?tag=family&tag=other", nil)
+
+	resp, _ = webApp.Test(req)
+	body, _ = io.ReadAll(resp.Body)
+
+	if bytes.Contains(body, []byte("Spotify")) {
+		t.Errorf("incorrect filtering, response body: %s", string(body))
+	}
+
+	start := time.Now().Format("01-2006")
+	req = httptest.NewRequest("GET", "/stats?group_by=category&start_date="+start, nil)
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d", resp.StatusCode)
+	}
+	body, _ = io.ReadAll(resp.Body)
+
+	stats := StatsSubscriptions200JSONResponse{}
+	_ = json.Unmarshal(body, &stats)
+
+	expected := []StatsGroup{
+		{Key: "", Name: "", TotalPrice: 500},
+		{Key: "video", Name: "video", TotalPrice: 375},
+		{Key: "music", Name: "music", TotalPrice: 300},
+	}
+	if stats.Groups == nil || !slices.Equal(*stats.Groups, expected) {
+		t.Errorf("incorrect groups, response body: %s", string(body))
+	}
+
+	req = httptest.NewRequest("GET", "/stats?category=video&start_date="+start, nil)
+
+	resp, _ = webApp.Test(req)
+	body, _ = io.ReadAll(resp.Body)
+
+	if !bytes.Contains(body, []byte("375")) {
+		t.Errorf("incorrect total, response body: %s", string(body))
+	}
+}
+
+func TestImplTrial(t *testing.T) {
+	webApp := prepareServ()
+
+	trialEnd := time.Now().AddDate(0, -1, 0).Format("01-2006")
+	item := Subscription{
+		Price:        200,
+		StartDate:    time.Now().AddDate(0, -3, 0).Format("01-2006"),
+		TrialEndDate: &trialEnd,
+		UserId:       uuid.New(),
+		ServiceName:  "trial service",
+	}
+
+	jsonStr, _ := json.Marshal(item)
+
+	req := httptest.NewRequest("POST", "/subscriptions", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 200)
+	}
+
+	req = httptest.NewRequest("GET", "/stats?user_id="+item.UserId.String(), nil)
+
+	resp, _ = webApp.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	if !bytes.Contains(body, []byte(`"total_price":200`)) {
+		t.Errorf("incorrect total, response body: %s", string(body))
+	}
+
+	trialEnd = time.Now().AddDate(0, -4, 0).Format("01-2006")
+	jsonStr, _ = json.Marshal(item)
+
+	req = httptest.NewRequest("POST", "/subscriptions", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 422 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 422)
+	}
+
+	trialEnd = time.Now().AddDate(0, 1, 0).Format("01-2006")
+	jsonStr, _ = json.Marshal(SubscriptionPatch{TrialEndDate: &trialEnd})
+
+	req = httptest.NewRequest("PATCH", "/subscriptions/"+id2.String(), bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 204 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 204)
+	}
+
+	req = httptest.NewRequest("GET", "/subscriptions?status=trial", nil)
+
+	resp, _ = webApp.Test(req)
+	body, _ = io.ReadAll(resp.Body)
+
+	if !bytes.Contains(body, []byte("item 2")) || bytes.Contains(body, []byte("some item")) {
+		t.Errorf("incorrect filtering, response body: %s", string(body))
+	}
+}
@@ -0,0 +1,104 @@
+package transport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"ew/internal/logging"
+	"ew/internal/models/idempotency"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	DefaultIdempotencyTTL = 24 * time.Hour
+	// idempotencyLease is how long a key is held for a request in progress, the replay TTL starts once it completes
+	idempotencyLease = time.Minute
+)
+
+func registerIdempotencyRules(validate *validator.Validate) {
+	createParamsRules := map[string]string{
+		"IdempotencyKey": "omitempty,min=1,max=255",
+	}
+	validate.RegisterStructValidationMapRules(createParamsRules, CreateSubscriptionParams{})
+}
+
+// requestHash identifies the request a key was used with, the parameters count as part of it.
+func requestHash(request CreateSubscriptionRequestObject) string {
+	rejectDuplicates := request.Params.RejectDuplicates != nil && *request.Params.RejectDuplicates
+
+	body, _ := json.Marshal(struct {
+		Body             *CreateSubscriptionJSONRequestBody
+		RejectDuplicates bool
+	}{request.Body, rejectDuplicates})
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// scopedKey keeps the keys of different users apart, as the API has no other notion of a client.
+func scopedKey(request CreateSubscriptionRequestObject) string {
+	if request.Body == nil {
+		return *request.Params.IdempotencyKey
+	}
+	return request.Body.UserId.String() + ":" + *request.Params.IdempotencyKey
+}
+
+// CreateSubscription replays the stored response when the Idempotency-Key was already used with the same request
+// for the same user.
+// Only successful responses are stored, the key of a failed request is released for a retry.
+func (s Server) CreateSubscription(ctx context.Context, request CreateSubscriptionRequestObject) (CreateSubscriptionResponseObject, error) {
+	if request.Params.IdempotencyKey == nil || s.Idempotency == nil {
+		return s.createSubscription(ctx, request)
+	}
+
+	err := s.validate(ctx, request.Params)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("CreateSubscription validation failed")
+		return CreateSubscription422ApplicationProblemPlusJSONResponse(s.validationProblem(ctx, err)), nil
+	}
+
+	key := scopedKey(request)
+	log := logging.FromContext(ctx).WithField("idempotency_key", key)
+
+	record, err := s.Idempotency.Reserve(ctx, key, requestHash(request), idempotencyLease)
+	switch {
+	case errors.Is(err, idempotency.Mismatch):
+		return CreateSubscription409ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusConflict, problemConflict, "idempotencyMismatch")), nil
+	case errors.Is(err, idempotency.InProgress):
+		return CreateSubscription409ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusConflict, problemConflict, "idempotencyInProgress")), nil
+	case err != nil:
+		log.WithError(err).Error("failed to reserve idempotency key")
+		return nil, InternalError
+	case record != nil:
+		var res CreateSubscription200JSONResponse
+		if err = json.Unmarshal(record.Response, &res); err != nil {
+			log.WithError(err).Error("failed to decode stored response")
+			return nil, InternalError
+		}
+		log.Info("replayed created subscription")
+		return res, nil
+	}
+
+	// the outcome is stored even if the client has gone away, as the subscription is already created
+	storeCtx := context.WithoutCancel(ctx)
+
+	res, err := s.createSubscription(ctx, request)
+	created, ok := res.(CreateSubscription200JSONResponse)
+	if err != nil || !ok {
+		if err := s.Idempotency.Release(storeCtx, key); err != nil {
+			log.WithError(err).Error("failed to release idempotency key")
+		}
+		return res, err
+	}
+
+	response, _ := json.Marshal(created)
+	if err := s.Idempotency.Complete(storeCtx, key, response, s.IdempotencyTTL); err != nil {
+		log.WithError(err).Error("failed to store idempotent response")
+	}
+	return res, nil
+}
@@ -0,0 +1,111 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestImplIdempotency(t *testing.T) {
+	webApp := prepareServ()
+
+	item := Subscription{Price: 105, StartDate: "11-2000", UserId: uuid.New(), ServiceName: "test"}
+	created := len(repo.Items)
+
+	status, first := createWithKey(t, webApp, "key-1", item)
+	if status != 200 || first.SubscriptionId == nil {
+		t.Fatalf("invalid first response: %d", status)
+	}
+
+	status, replay := createWithKey(t, webApp, "key-1", item)
+	if status != 200 || replay.SubscriptionId == nil || *replay.SubscriptionId != *first.SubscriptionId {
+		t.Errorf("invalid replay: %d %v", status, replay.SubscriptionId)
+	}
+	if len(repo.Items) != created+1 {
+		t.Errorf("expected a single created subscription, got %d", len(repo.Items)-created)
+	}
+
+	item.Price = 106
+	status, _ = createWithKey(t, webApp, "key-1", item)
+	if status != 409 {
+		t.Errorf("invalid status code for a different body: %d", status)
+	}
+
+	// the keys of another user are their own
+	other := item
+	other.UserId = uuid.New()
+	status, res := createWithKey(t, webApp, "key-1", other)
+	if status != 200 || res.SubscriptionId == nil || *res.SubscriptionId == *first.SubscriptionId {
+		t.Errorf("invalid response for another user: %d %v", status, res.SubscriptionId)
+	}
+
+	// the key of a rejected request stays free for the corrected one
+	invalid := item
+	invalid.StartDate = "13-2000"
+	status, _ = createWithKey(t, webApp, "key-2", invalid)
+	if status != 422 {
+		t.Errorf("invalid status code for an invalid body: %d", status)
+	}
+	status, _ = createWithKey(t, webApp, "key-2", item)
+	if status != 200 {
+		t.Errorf("invalid status code after a failed request: %d", status)
+	}
+}
+
+func TestImplIdempotencyConcurrent(t *testing.T) {
+	webApp := prepareServ()
+
+	item := Subscription{Price: 105, StartDate: "11-2000", UserId: uuid.New(), ServiceName: "test"}
+	created := len(repo.Items)
+
+	var wg sync.WaitGroup
+	statuses := make([]int, 8)
+	ids := make([]*UUID, 8)
+	for i := range statuses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var res CreateSubscription200JSONResponse
+			statuses[i], res = createWithKey(t, webApp, "concurrent", item)
+			ids[i] = res.SubscriptionId
+		}()
+	}
+	wg.Wait()
+
+	if len(repo.Items) != created+1 {
+		t.Errorf("expected a single created subscription, got %d", len(repo.Items)-created)
+	}
+	for i, status := range statuses {
+		switch {
+		case status == 409:
+		case status == 200 && ids[i] != nil && *ids[i] == repo.Items[len(repo.Items)-1].ID:
+		default:
+			t.Errorf("invalid response: %d %v", status, ids[i])
+		}
+	}
+}
+
+func createWithKey(t *testing.T, webApp *fiber.App, key string, item Subscription) (int, CreateSubscription200JSONResponse) {
+	body, _ := json.Marshal(item)
+
+	req := httptest.NewRequest("POST", "/subscriptions", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+
+	resp, err := webApp.Test(req)
+	if err != nil {
+		t.Error(err)
+		return 0, CreateSubscription200JSONResponse{}
+	}
+
+	var res CreateSubscription200JSONResponse
+	respBody, _ := io.ReadAll(resp.Body)
+	_ = json.Unmarshal(respBody, &res)
+	return resp.StatusCode, res
+}
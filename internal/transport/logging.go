@@ -0,0 +1,23 @@
+package transport
+
+import (
+	"ew/internal/logging"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Logging is a strict middleware adding the operation and, when the route or the query has it, the user_id
+// to the request logger the handlers take from the context.
+func Logging(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+	return func(c *fiber.Ctx, args interface{}) (interface{}, error) {
+		c.Locals(logging.OperationLocal, operationID)
+
+		entry := logging.FromContext(c.UserContext()).WithField("operation", operationID)
+		if userId := c.Params("user_id", c.Query("user_id")); userId != "" {
+			entry = entry.WithField("user_id", userId)
+		}
+		c.SetUserContext(logging.WithLogger(c.UserContext(), entry))
+
+		return f(c, args)
+	}
+}
@@ -0,0 +1,48 @@
+package transport
+
+import (
+	"ew/internal/logging"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestImplLogging(t *testing.T) {
+	prepareServ()
+
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.InfoLevel)
+
+	webApp := fiber.New()
+	webApp.Use(func(c *fiber.Ctx) error {
+		c.SetUserContext(logging.WithLogger(c.UserContext(), logrus.NewEntry(logger)))
+		return c.Next()
+	})
+	webApp.Use(logging.Middleware)
+	RegisterHandlers(webApp, NewStrictHandler(
+		NewServer(repo, serviceRepo, validator.New()),
+		[]StrictMiddlewareFunc{Logging},
+	))
+
+	userId := repo.Items[0].UserId.String()
+	req := httptest.NewRequest("GET", "/users/"+userId+"/summary", nil)
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d", resp.StatusCode)
+	}
+
+	requestID := resp.Header.Get(logging.HeaderRequestID)
+	for _, entry := range hook.AllEntries() {
+		if entry.Data["request_id"] != requestID || entry.Data["operation"] != "UserSummary" || entry.Data["user_id"] != userId {
+			t.Errorf("missing request fields in %q: %v", entry.Message, entry.Data)
+		}
+	}
+	if len(hook.AllEntries()) != 2 {
+		t.Errorf("not equal %v", hook.AllEntries())
+	}
+}
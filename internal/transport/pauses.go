@@ -0,0 +1,103 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func registerPauseRules(validate *validator.Validate) {
+	pauseRules := map[string]string{
+		"StartDate": "omitempty,dateFormat",
+	}
+	validate.RegisterStructValidationMapRules(pauseRules, PauseSubscriptionJSONRequestBody{})
+
+	resumeRules := map[string]string{
+		"ResumeDate": "omitempty,dateFormat",
+	}
+	validate.RegisterStructValidationMapRules(resumeRules, ResumeSubscriptionJSONRequestBody{})
+}
+
+// pauseMonth parses an optional month, defaulting to the current one.
+func pauseMonth(date *Date) (time.Time, error) {
+	if date == nil {
+		return subscriptions.MonthStart(time.Now()), nil
+	}
+	return time.Parse("01-2006", *date)
+}
+
+func convertPausesToResponse(pauses []subscriptions.Pause) []Pause {
+	res := make([]Pause, 0, len(pauses))
+	for _, pause := range pauses {
+		item := Pause{StartDate: pause.StartDate.Format("01-2006")}
+		if pause.ResumeDate != nil {
+			date := pause.ResumeDate.Format("01-2006")
+			item.ResumeDate = &date
+		}
+		res = append(res, item)
+	}
+	return res
+}
+
+func (s Server) PauseSubscription(ctx context.Context, request PauseSubscriptionRequestObject) (PauseSubscriptionResponseObject, error) {
+	err := s.validate(ctx, request.Body)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("PauseSubscription validation failed")
+		return PauseSubscription422ApplicationProblemPlusJSONResponse(s.validationProblem(ctx, err)), nil
+	}
+
+	start, err := pauseMonth(request.Body.StartDate)
+	if err != nil {
+		return PauseSubscription422ApplicationProblemPlusJSONResponse(s.fieldProblem(ctx, "start_date", "dateFormat", "")), nil
+	}
+
+	err = s.Repo.Pause(ctx, request.SubscriptionId, start)
+	switch {
+	case errors.Is(err, subscriptions.NotFound):
+		return PauseSubscription404ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusNotFound, problemGeneric, "subscriptionNotFound")), nil
+	case errors.Is(err, subscriptions.AlreadyPaused):
+		return PauseSubscription409ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusConflict, problemConflict, "alreadyPaused")), nil
+	case errors.Is(err, subscriptions.InvalidPauseDates):
+		return PauseSubscription422ApplicationProblemPlusJSONResponse(s.fieldProblem(ctx, "start_date", "pauseDates", "")), nil
+	case err != nil:
+		logging.FromContext(ctx).WithError(err).Error("PauseSubscription failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("paused subscription")
+
+	return PauseSubscription204Response{}, nil
+}
+
+func (s Server) ResumeSubscription(ctx context.Context, request ResumeSubscriptionRequestObject) (ResumeSubscriptionResponseObject, error) {
+	err := s.validate(ctx, request.Body)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("ResumeSubscription validation failed")
+		return ResumeSubscription422ApplicationProblemPlusJSONResponse(s.validationProblem(ctx, err)), nil
+	}
+
+	resume, err := pauseMonth(request.Body.ResumeDate)
+	if err != nil {
+		return ResumeSubscription422ApplicationProblemPlusJSONResponse(s.fieldProblem(ctx, "resume_date", "dateFormat", "")), nil
+	}
+
+	err = s.Repo.Resume(ctx, request.SubscriptionId, resume)
+	switch {
+	case errors.Is(err, subscriptions.NotFound):
+		return ResumeSubscription404ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusNotFound, problemGeneric, "subscriptionNotFound")), nil
+	case errors.Is(err, subscriptions.NotPaused):
+		return ResumeSubscription409ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusConflict, problemConflict, "notPaused")), nil
+	case errors.Is(err, subscriptions.InvalidPauseDates):
+		return ResumeSubscription422ApplicationProblemPlusJSONResponse(s.fieldProblem(ctx, "resume_date", "pauseDates", "")), nil
+	case err != nil:
+		logging.FromContext(ctx).WithError(err).Error("ResumeSubscription failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("resumed subscription")
+
+	return ResumeSubscription204Response{}, nil
+}
@@ -0,0 +1,79 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestImplPauseResume(t *testing.T) {
+	webApp := prepareServ()
+
+	userId := repo.Items[1].UserId.String()
+	pauseStart := time.Now().AddDate(0, -1, 0).Format("01-2006")
+	resume := time.Now().Format("01-2006")
+	early := time.Now().AddDate(0, -2, 0).Format("01-2006")
+	invalid := "13-2025"
+
+	req := httptest.NewRequest("GET", "/stats?user_id="+userId, nil)
+
+	resp, _ := webApp.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	var before StatsSubscriptions200JSONResponse
+	_ = json.Unmarshal(body, &before)
+
+	cases := []struct {
+		path   string
+		body   any
+		status int
+	}{
+		{"/subscriptions/" + id2.String() + "/pause", PauseSubscriptionJSONBody{StartDate: &pauseStart}, 204},
+		{"/subscriptions/" + id2.String() + "/pause", PauseSubscriptionJSONBody{}, 409},
+		{"/subscriptions/" + id2.String() + "/resume", ResumeSubscriptionJSONBody{ResumeDate: &early}, 422},
+		{"/subscriptions/" + id2.String() + "/resume", ResumeSubscriptionJSONBody{}, 204},
+		{"/subscriptions/" + id2.String() + "/resume", ResumeSubscriptionJSONBody{}, 409},
+		{"/subscriptions/" + id2.String() + "/pause", PauseSubscriptionJSONBody{StartDate: &early}, 422},
+		{"/subscriptions/" + id2.String() + "/pause", PauseSubscriptionJSONBody{StartDate: &invalid}, 422},
+		{"/subscriptions/" + uuid.New().String() + "/pause", PauseSubscriptionJSONBody{}, 404},
+	}
+
+	for _, c := range cases {
+		jsonStr, _ := json.Marshal(c.body)
+
+		req = httptest.NewRequest("POST", c.path, bytes.NewBuffer(jsonStr))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, _ = webApp.Test(req)
+		if resp.StatusCode != c.status {
+			t.Errorf("%s %s: invalid status code: %d, expected %d", c.path, jsonStr, resp.StatusCode, c.status)
+		}
+	}
+
+	req = httptest.NewRequest("GET", "/stats?user_id="+userId, nil)
+
+	resp, _ = webApp.Test(req)
+	body, _ = io.ReadAll(resp.Body)
+
+	var after StatsSubscriptions200JSONResponse
+	_ = json.Unmarshal(body, &after)
+
+	if *after.TotalPrice != *before.TotalPrice-250 {
+		t.Errorf("paused month is not excluded, before %d, after %d", *before.TotalPrice, *after.TotalPrice)
+	}
+
+	req = httptest.NewRequest("GET", "/subscriptions/"+id2.String(), nil)
+
+	resp, _ = webApp.Test(req)
+	body, _ = io.ReadAll(resp.Body)
+
+	expected := `"pauses":[{"resume_date":"` + resume + `","start_date":"` + pauseStart + `"}]`
+	if !bytes.Contains(body, []byte(expected)) {
+		t.Errorf("pause history is missing, response body: %s", string(body))
+	}
+}
@@ -0,0 +1,125 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Problem types, relative to the API root.
+const (
+	problemValidation = "/problems/validation-error"
+	problemConflict   = "/problems/conflict"
+	problemGeneric    = "about:blank"
+
+	mimeProblemJSON = "application/problem+json"
+)
+
+func problem(status int, problemType, detail string) Problem {
+	res := Problem{
+		Type:   problemType,
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	if detail != "" {
+		res.Detail = &detail
+	}
+	return res
+}
+
+// problemFor builds a problem with the title and the detail in the locale of the request.
+func (s Server) problemFor(ctx context.Context, status int, problemType, key string) Problem {
+	res := problem(status, problemType, s.message(ctx, key))
+	titleKey := "status-" + strconv.Itoa(status)
+	if title := s.message(ctx, titleKey); title != titleKey {
+		res.Title = title
+	}
+	return res
+}
+
+// validationProblem lists every failed rule of a validator error under the json name of its field.
+func (s Server) validationProblem(ctx context.Context, err error) Problem {
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return problem(http.StatusUnprocessableEntity, problemValidation, err.Error())
+	}
+
+	trans := s.translator(ctx)
+
+	fields := make([]ProblemField, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		fields = append(fields, ProblemField{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: fe.Translate(trans),
+		})
+	}
+
+	res := s.problemFor(ctx, http.StatusUnprocessableEntity, problemValidation, "validationFailed")
+	res.Errors = &fields
+	return res
+}
+
+// fieldProblem reports a single field failing a rule checked outside of the validator,
+// the message comes from the same registry as the validator ones.
+func (s Server) fieldProblem(ctx context.Context, field, rule, param string) Problem {
+	message, err := s.translator(ctx).T(rule, field, param)
+	if err != nil {
+		message = field + " failed the " + rule + " rule"
+	}
+
+	res := s.problemFor(ctx, http.StatusUnprocessableEntity, problemValidation, "validationFailed")
+	res.Errors = &[]ProblemField{{Field: field, Rule: rule, Message: message}}
+	return res
+}
+
+// ParseUUID parses an identifier for the transports that do not bind the REST parameters,
+// a malformed one is reported under the uuid rule in the locale of the request.
+func (s Server) ParseUUID(ctx context.Context, field, value string) (UUID, *Problem) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		invalid := s.fieldProblem(ctx, field, "uuid", "")
+		return id, &invalid
+	}
+	return id, nil
+}
+
+// fieldPath drops the struct name from the namespace, leaving e.g. "tags[0]".
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+// ErrorHandler writes the errors returned to fiber, such as malformed parameters or InternalError, as problem+json.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	detail := ""
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		status = fiberErr.Code
+		detail = fiberErr.Message
+	}
+
+	c.Status(status)
+	return c.JSON(problem(status, problemGeneric, detail), mimeProblemJSON)
+}
+
+// ProblemContentType restores the problem+json content type of error responses,
+// the generated response visitors set it but ctx.JSON then overwrites it with application/json.
+func ProblemContentType(c *fiber.Ctx) error {
+	err := c.Next()
+	if c.Response().StatusCode() >= fiber.StatusBadRequest && string(c.Response().Header.ContentType()) == fiber.MIMEApplicationJSON {
+		c.Set(fiber.HeaderContentType, mimeProblemJSON)
+	}
+	return err
+}
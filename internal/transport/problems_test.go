@@ -0,0 +1,87 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestImplProblems(t *testing.T) {
+	webApp := prepareServ()
+
+	price := -1
+	tags := []string{"video", ""}
+	body, _ := json.Marshal(map[string]any{
+		"service_name": "Some item",
+		"price":        price,
+		"user_id":      uuid.New(),
+		"start_date":   "13-2025",
+		"tags":         tags,
+	})
+
+	req := httptest.NewRequest("POST", "/subscriptions", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 422 {
+		t.Errorf("invalid status code: %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Content-Type") != "application/problem+json" {
+		t.Errorf("invalid content type: %s", resp.Header.Get("Content-Type"))
+	}
+
+	var res Problem
+	respBody, _ := io.ReadAll(resp.Body)
+	_ = json.Unmarshal(respBody, &res)
+
+	if res.Type != problemValidation || res.Title != "Unprocessable Entity" || res.Status != 422 {
+		t.Errorf("incorrect problem: %s", string(respBody))
+	}
+
+	expected := []ProblemField{
+		{Field: "price", Rule: "min", Message: "price must be 1 or greater"},
+		{Field: "start_date", Rule: "dateFormat", Message: "start_date must be a date in the MM-YYYY format"},
+		{Field: "tags[1]", Rule: "required", Message: "tags[1] is a required field"},
+	}
+	if res.Errors == nil || !reflect.DeepEqual(*res.Errors, expected) {
+		t.Errorf("incorrect field errors: %s", string(respBody))
+	}
+
+	cases := []struct {
+		method   string
+		path     string
+		status   int
+		expected string
+	}{
+		{"GET", "/subscriptions?price_min=5&price_max=1", 422, `{"errors":[{"field":"price_max","message":"price_max must be greater than or equal to price_min","rule":"gtefield"}],"detail":"request validation failed","status":422,"title":"Unprocessable Entity","type":"/problems/validation-error"}`},
+		{"GET", "/subscriptions?status=paused", 422, `{"errors":[{"field":"status","message":"status must be one of [active ended future trial]","rule":"oneof"}],"detail":"request validation failed","status":422,"title":"Unprocessable Entity","type":"/problems/validation-error"}`},
+		{"GET", "/subscriptions/not-an-id", 400, `{"detail":"Invalid format for parameter subscription_id: error unmarshaling 'not-an-id' text as *uuid.UUID: invalid UUID length: 9","status":400,"title":"Bad Request","type":"about:blank"}`},
+	}
+
+	for _, c := range cases {
+		req = httptest.NewRequest(c.method, c.path, nil)
+
+		resp, _ = webApp.Test(req)
+		respBody, _ = io.ReadAll(resp.Body)
+
+		if resp.StatusCode != c.status {
+			t.Errorf("%s: invalid status code: %d", c.path, resp.StatusCode)
+		}
+		if !jsonEqual(respBody, []byte(c.expected)) {
+			t.Errorf("%s: incorrect problem: %s", c.path, string(respBody))
+		}
+	}
+}
+
+func jsonEqual(a, b []byte) bool {
+	var x, y any
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
@@ -0,0 +1,56 @@
+package transport
+
+import (
+	"context"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+func registerReminderRules(validate *validator.Validate) {
+	reminderRules := map[string]string{
+		"Month": "omitempty,dateFormat",
+	}
+	validate.RegisterStructValidationMapRules(reminderRules, UserRemindersParams{})
+}
+
+// UserReminders lists the charges of the user's subscriptions in the month, the first charge after a trial included.
+func (s Server) UserReminders(ctx context.Context, request UserRemindersRequestObject) (UserRemindersResponseObject, error) {
+	err := s.validate(ctx, request.Params)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("UserReminders validation failed")
+		return UserRemindersdefaultApplicationProblemPlusJSONResponse{Body: s.validationProblem(ctx, err), StatusCode: 422}, nil
+	}
+
+	month := subscriptions.MonthStart(time.Now()).AddDate(0, 1, 0)
+	if request.Params.Month != nil {
+		month, _ = time.Parse("01-2006", *request.Params.Month)
+	}
+
+	items, err := s.Repo.GetList(ctx, subscriptions.SubscriptionListParams{UserIds: []uuid.UUID{request.UserId}})
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("UserReminders failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("received reminders")
+
+	res := UserReminders200JSONResponse{Month: month.Format("01-2006"), Items: make([]Reminder, 0)}
+	for _, item := range items {
+		reason, charged := item.ChargeIn(month)
+		if !charged {
+			continue
+		}
+		res.TotalPrice += int(item.Price)
+		res.Items = append(res.Items, Reminder{
+			SubscriptionId: item.ID,
+			ServiceName:    item.ServiceName,
+			Price:          int(item.Price),
+			Reason:         ReminderReason(reason),
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,66 @@
+package transport
+
+import (
+	"encoding/json"
+	"ew/internal/models/subscriptions"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestImplReminders(t *testing.T) {
+	webApp := prepareServ()
+
+	userId := uuid.New()
+	month := subscriptions.MonthStart(time.Now()).AddDate(0, 1, 0)
+	trialEnd := subscriptions.MonthStart(time.Now())
+	ended := subscriptions.MonthStart(time.Now())
+	trial := &subscriptions.Subscription{ID: uuid.New(), ServiceName: "trial", Price: 100, StartDate: trialEnd.AddDate(0, -1, 0), TrialEndDate: &trialEnd, UserId: userId}
+	renewal := &subscriptions.Subscription{ID: uuid.New(), ServiceName: "renewal", Price: 200, StartDate: month.AddDate(0, -3, 0), UserId: userId}
+	repo.Items = append(repo.Items,
+		trial,
+		renewal,
+		&subscriptions.Subscription{ID: uuid.New(), ServiceName: "ended", Price: 400, StartDate: month.AddDate(0, -3, 0), EndDate: &ended, UserId: userId},
+	)
+
+	// the month after the trial is reminded of as its end, the ended subscription is not charged
+	req := httptest.NewRequest("GET", "/users/"+userId.String()+"/reminders", nil)
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("invalid status code: %d", resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+
+	var res Reminders
+	_ = json.Unmarshal(body, &res)
+	expected := []Reminder{
+		{SubscriptionId: trial.ID, ServiceName: "trial", Price: 100, Reason: TrialEnd},
+		{SubscriptionId: renewal.ID, ServiceName: "renewal", Price: 200, Reason: Renewal},
+	}
+	if res.Month != month.Format("01-2006") || res.TotalPrice != 300 || len(res.Items) != 2 || res.Items[0] != expected[0] || res.Items[1] != expected[1] {
+		t.Errorf("incorrect reminders, response body: %s", string(body))
+	}
+
+	// the trial months are not charged
+	req = httptest.NewRequest("GET", "/users/"+userId.String()+"/reminders?month="+trialEnd.Format("01-2006"), nil)
+
+	resp, _ = webApp.Test(req)
+	body, _ = io.ReadAll(resp.Body)
+
+	res = Reminders{}
+	_ = json.Unmarshal(body, &res)
+	if len(res.Items) != 2 || res.Items[0].SubscriptionId != renewal.ID || res.Items[1].Reason != Renewal || res.TotalPrice != 600 {
+		t.Errorf("incorrect reminders, response body: %s", string(body))
+	}
+
+	req = httptest.NewRequest("GET", "/users/"+userId.String()+"/reminders?month=13-2025", nil)
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 422 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 422)
+	}
+}
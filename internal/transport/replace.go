@@ -0,0 +1,129 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"ew/internal/database"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+	"net/http"
+
+	"github.com/oapi-codegen/nullable"
+)
+
+// mergeValue splits a field of a merge patch into the value to set and whether it is cleared with null.
+func mergeValue[T any](field nullable.Nullable[T]) (*T, bool) {
+	if !field.IsSpecified() {
+		return nil, false
+	}
+	if field.IsNull() {
+		return nil, true
+	}
+	value := field.MustGet()
+	return &value, false
+}
+
+// mergePatch is subscriptionPatch for a JSON Merge Patch, the set fields are checked with the same rules.
+func (s Server) mergePatch(ctx context.Context, id UUID, body *SubscriptionMergePatch) (*subscriptions.SubscriptionPatch, *Problem, error) {
+	patch := SubscriptionPatch{ServiceId: body.ServiceId}
+
+	var (
+		clear   []subscriptions.Field
+		cleared bool
+	)
+	// the fields every subscription has cannot be cleared
+	if patch.ServiceName, cleared = mergeValue(body.ServiceName); cleared {
+		return nil, s.nullProblem(ctx, "service_name"), nil
+	}
+	if patch.Price, cleared = mergeValue(body.Price); cleared {
+		return nil, s.nullProblem(ctx, "price"), nil
+	}
+	if patch.UserId, cleared = mergeValue(body.UserId); cleared {
+		return nil, s.nullProblem(ctx, "user_id"), nil
+	}
+	if patch.StartDate, cleared = mergeValue(body.StartDate); cleared {
+		return nil, s.nullProblem(ctx, "start_date"), nil
+	}
+	if patch.EndDate, cleared = mergeValue(body.EndDate); cleared {
+		clear = append(clear, subscriptions.FieldEndDate)
+	}
+	if patch.TrialEndDate, cleared = mergeValue(body.TrialEndDate); cleared {
+		clear = append(clear, subscriptions.FieldTrialEndDate)
+	}
+	if patch.Category, cleared = mergeValue(body.Category); cleared {
+		clear = append(clear, subscriptions.FieldCategory)
+	}
+	// tags are never NULL, clearing them leaves none
+	if patch.Tags, cleared = mergeValue(body.Tags); cleared {
+		patch.Tags = &[]string{}
+	}
+
+	item, invalid, err := s.subscriptionPatch(ctx, id, &patch)
+	if item != nil {
+		item.Clear = clear
+	}
+	return item, invalid, err
+}
+
+// nullProblem reports a field of a merge patch cleared with null while every subscription has it.
+func (s Server) nullProblem(ctx context.Context, field string) *Problem {
+	invalid := s.fieldProblem(ctx, field, "required", "")
+	return &invalid
+}
+
+// replacement is the patch setting every field of the subscription, the optional ones it lacks are cleared.
+func replacement(id UUID, item *subscriptions.Subscription) *subscriptions.SubscriptionPatch {
+	tags := item.Tags
+	if tags == nil {
+		tags = database.TextArray{}
+	}
+
+	res := &subscriptions.SubscriptionPatch{
+		ID:           id,
+		ServiceId:    item.ServiceId,
+		ServiceName:  &item.ServiceName,
+		Price:        &item.Price,
+		UserId:       &item.UserId,
+		StartDate:    &item.StartDate,
+		EndDate:      item.EndDate,
+		TrialEndDate: item.TrialEndDate,
+		Category:     item.Category,
+		Tags:         &tags,
+	}
+
+	if item.EndDate == nil {
+		res.Clear = append(res.Clear, subscriptions.FieldEndDate)
+	}
+	if item.TrialEndDate == nil {
+		res.Clear = append(res.Clear, subscriptions.FieldTrialEndDate)
+	}
+	if item.Category == nil {
+		res.Clear = append(res.Clear, subscriptions.FieldCategory)
+	}
+	return res
+}
+
+// ReplaceSubscription checks the body as a new subscription, the pauses of the replaced one are kept.
+func (s Server) ReplaceSubscription(ctx context.Context, request ReplaceSubscriptionRequestObject) (ReplaceSubscriptionResponseObject, error) {
+	item, invalid, err := s.newSubscription(ctx, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if invalid != nil {
+		return ReplaceSubscription422ApplicationProblemPlusJSONResponse(*invalid), nil
+	}
+
+	invalid, err = s.patchSubscription(ctx, replacement(request.SubscriptionId, item))
+	switch {
+	case errors.Is(err, subscriptions.NotFound):
+		return ReplaceSubscription404ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusNotFound, problemGeneric, "subscriptionNotFound")), nil
+	case err != nil:
+		logging.FromContext(ctx).WithError(err).Error("ReplaceSubscription failed")
+		return nil, InternalError
+	case invalid != nil:
+		return ReplaceSubscription422ApplicationProblemPlusJSONResponse(*invalid), nil
+	}
+	logging.FromContext(ctx).Info("replaced subscription")
+
+	return ReplaceSubscription204Response{}, nil
+}
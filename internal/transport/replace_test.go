@@ -0,0 +1,97 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestImplReplace(t *testing.T) {
+	webApp := prepareServ()
+
+	userId := uuid.New()
+	body := `{"service_name":"replaced","price":99,"user_id":"` + userId.String() + `","start_date":"03-2024"}`
+
+	cases := []struct {
+		id     uuid.UUID
+		body   string
+		status int
+	}{
+		{id1, body, 204},
+		{id1, `{"price":99}`, 422},
+		{uuid.New(), body, 404},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest("PUT", "/subscriptions/"+c.id.String(), bytes.NewBufferString(c.body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, _ := webApp.Test(req)
+		if resp.StatusCode != c.status {
+			t.Errorf("%s: invalid status code: %d, expected %d", c.body, resp.StatusCode, c.status)
+		}
+	}
+
+	item, _ := repo.GetByID(context.TODO(), id1)
+	if item.ServiceName != "replaced" || item.Price != 99 || item.UserId != userId || item.StartDate.Format("01-2006") != "03-2024" {
+		t.Errorf("not replaced: %v", item)
+	}
+	if item.EndDate != nil || item.Category != nil || len(item.Tags) != 0 {
+		t.Errorf("optional fields not cleared: %v", item)
+	}
+}
+
+func TestImplMergePatch(t *testing.T) {
+	webApp := prepareServ()
+
+	end := time.Now().Format("01-2006")
+	cases := []struct {
+		body        string
+		contentType string
+		status      int
+	}{
+		{`{"end_date":"` + end + `","category":"video","tags":["work"]}`, "application/json", 204},
+		// null is not a value of a plain patch
+		{`{"end_date":null}`, "application/json", 204},
+		{`{"end_date":null,"category":null,"tags":null,"price":300}`, "application/merge-patch+json", 204},
+		{`{"end_date":"2025-07"}`, "application/merge-patch+json", 422},
+		// the fields every subscription has are not cleared
+		{`{"service_name":null}`, "application/merge-patch+json", 422},
+		{`{"price":null}`, "application/merge-patch+json", 422},
+		{`{"user_id":null}`, "application/merge-patch+json", 422},
+		{`{"start_date":null}`, "application/merge-patch+json", 422},
+		{`{"price":1}`, "text/plain", 415},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest("PATCH", "/subscriptions/"+id2.String(), bytes.NewBufferString(c.body))
+		req.Header.Set("Content-Type", c.contentType)
+
+		resp, _ := webApp.Test(req)
+		if resp.StatusCode != c.status {
+			t.Errorf("%s: invalid status code: %d, expected %d", c.body, resp.StatusCode, c.status)
+		}
+	}
+
+	req := httptest.NewRequest("PATCH", "/subscriptions/"+id2.String(), bytes.NewBufferString(`{"price":null}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, _ := webApp.Test(req)
+	var problem Problem
+	_ = json.NewDecoder(resp.Body).Decode(&problem)
+	if problem.Errors == nil || len(*problem.Errors) != 1 || (*problem.Errors)[0].Field != "price" || (*problem.Errors)[0].Rule != "required" {
+		t.Errorf("unexpected problem of a cleared price %+v", problem)
+	}
+
+	item, _ := repo.GetByID(context.TODO(), id2)
+	if item.EndDate != nil || item.Category != nil || len(item.Tags) != 0 {
+		t.Errorf("fields not cleared: %v", item)
+	}
+	if item.Price != 300 || item.ServiceName != "item 2" {
+		t.Errorf("unexpected fields: %v", item)
+	}
+}
@@ -6,75 +6,594 @@ package transport
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/nullable"
 	"github.com/oapi-codegen/runtime"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for BatchOperationOp.
+const (
+	Create BatchOperationOp = "create"
+	Delete BatchOperationOp = "delete"
+	Update BatchOperationOp = "update"
+)
+
+// Defines values for BatchResultStatus.
+const (
+	Failed     BatchResultStatus = "failed"
+	Ok         BatchResultStatus = "ok"
+	RolledBack BatchResultStatus = "rolled_back"
+)
+
+// Defines values for ReminderReason.
+const (
+	Renewal  ReminderReason = "renewal"
+	Start    ReminderReason = "start"
+	TrialEnd ReminderReason = "trial_end"
+)
+
+// Defines values for StatsDimension.
+const (
+	StatsDimensionCategory    StatsDimension = "category"
+	StatsDimensionServiceName StatsDimension = "service_name"
+	StatsDimensionUserId      StatsDimension = "user_id"
+)
+
+// Defines values for StatsGroupBy.
+const (
+	StatsGroupByCategory StatsGroupBy = "category"
+	StatsGroupByService  StatsGroupBy = "service"
+)
+
+// Defines values for StatsMetric.
+const (
+	StatsMetricCount   StatsMetric = "count"
+	StatsMetricRunRate StatsMetric = "run_rate"
+	StatsMetricTotal   StatsMetric = "total"
+)
+
+// Defines values for SubscriptionBatchMode.
+const (
+	Atomic     SubscriptionBatchMode = "atomic"
+	BestEffort SubscriptionBatchMode = "best_effort"
+)
+
+// Defines values for SubscriptionStatus.
+const (
+	Active SubscriptionStatus = "active"
+	Ended  SubscriptionStatus = "ended"
+	Future SubscriptionStatus = "future"
+	Trial  SubscriptionStatus = "trial"
+)
+
+// Defines values for CohortSubscriptionsParamsValue.
+const (
+	CohortSubscriptionsParamsValueCount CohortSubscriptionsParamsValue = "count"
+	CohortSubscriptionsParamsValueShare CohortSubscriptionsParamsValue = "share"
+)
+
+// Defines values for CohortSubscriptionsParamsFormat.
+const (
+	Csv  CohortSubscriptionsParamsFormat = "csv"
+	Json CohortSubscriptionsParamsFormat = "json"
+)
+
+// BatchOperation defines model for BatchOperation.
+type BatchOperation struct {
+	Op BatchOperationOp `json:"op"`
+
+	// Patch Изменения подписки для update
+	Patch *SubscriptionPatch `json:"patch,omitempty"`
+
+	// Subscription Новая подписка для create
+	Subscription *Subscription `json:"subscription,omitempty"`
+
+	// SubscriptionId Идентификатор изменяемой или удаляемой подписки
+	SubscriptionId *UUID `json:"subscription_id,omitempty"`
+}
+
+// BatchOperationOp defines model for BatchOperation.Op.
+type BatchOperationOp string
+
+// BatchResult defines model for BatchResult.
+type BatchResult struct {
+	// Error Описание ошибки в формате RFC 7807
+	Error *Problem `json:"error,omitempty"`
+
+	// Status ok - операция применена, failed - операция не выполнена, rolled_back - операция отменена из-за ошибки другой операции пакета
+	Status         BatchResultStatus `json:"status"`
+	SubscriptionId *UUID             `json:"subscription_id,omitempty"`
+}
+
+// BatchResultStatus ok - операция применена, failed - операция не выполнена, rolled_back - операция отменена из-за ошибки другой операции пакета
+type BatchResultStatus string
+
+// BatchResults defines model for BatchResults.
+type BatchResults struct {
+	Results []BatchResult `json:"results"`
+}
+
+// CacheStats defines model for CacheStats.
+type CacheStats struct {
+	// Enabled Включен ли кэш
+	Enabled bool `json:"enabled"`
+
+	// Entries Количество записей в кэше
+	Entries int `json:"entries"`
+
+	// Hits Количество ответов из кэша
+	Hits int `json:"hits"`
+
+	// Misses Количество запросов, переданных в хранилище
+	Misses int `json:"misses"`
+}
+
+// ChurnMonth defines model for ChurnMonth.
+type ChurnMonth struct {
+	// Active Количество подписок, действовавших на начало месяца
+	Active int `json:"active"`
+
+	// Cancelled Количество подписок, закончившихся в этом месяце
+	Cancelled int `json:"cancelled"`
+
+	// ChurnRate Доля закончившихся подписок от действовавших на начало месяца
+	ChurnRate float32 `json:"churn_rate"`
+
+	// ChurnedMrr Ежемесячная стоимость закончившихся подписок
+	ChurnedMrr int  `json:"churned_mrr"`
+	Month      Date `json:"month"`
+
+	// NetMrr Изменение ежемесячной стоимости подписок
+	NetMrr int `json:"net_mrr"`
+
+	// New Количество подписок, начавшихся в этом месяце
+	New int `json:"new"`
+
+	// NewMrr Ежемесячная стоимость новых подписок
+	NewMrr int `json:"new_mrr"`
+}
+
+// Cohort defines model for Cohort.
+type Cohort struct {
+	Month Date `json:"month"`
+
+	// Size Количество подписок, начавшихся в этом месяце
+	Size int `json:"size"`
+
+	// Values Количество или доля подписок когорты, действующих через соответствующее индексу число месяцев
+	Values []float32 `json:"values"`
+}
+
 // Date defines model for Date.
 type Date = string
 
-// Error defines model for Error.
-type Error struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+// Duplicate defines model for Duplicate.
+type Duplicate struct {
+	// OverlapEnd Последний месяц пересечения, отсутствует для бессрочного пересечения
+	OverlapEnd      *Date       `json:"overlap_end,omitempty"`
+	OverlapStart    Date        `json:"overlap_start"`
+	ServiceName     ServiceName `json:"service_name"`
+	SubscriptionIds []UUID      `json:"subscription_ids"`
+	UserId          UUID        `json:"user_id"`
+
+	// WastedPrice Лишние расходы за оплаченные месяцы пересечения, по цене более дешевой подписки
+	WastedPrice int `json:"wasted_price"`
+}
+
+// Duplicates defines model for Duplicates.
+type Duplicates struct {
+	Items []Duplicate `json:"items"`
+
+	// WastedPrice Суммарные лишние расходы по всем пересечениям
+	WastedPrice int `json:"wasted_price"`
+}
+
+// Forecast defines model for Forecast.
+type Forecast struct {
+	Months     []ForecastMonth `json:"months"`
+	TotalPrice int             `json:"total_price"`
+}
+
+// ForecastMonth defines model for ForecastMonth.
+type ForecastMonth struct {
+	Month      Date `json:"month"`
+	TotalPrice int  `json:"total_price"`
+}
+
+// Pause defines model for Pause.
+type Pause struct {
+	ResumeDate *Date `json:"resume_date,omitempty"`
+	StartDate  Date  `json:"start_date"`
 }
 
 // Price defines model for Price.
 type Price = int
 
+// Problem Описание ошибки в формате RFC 7807
+type Problem struct {
+	// Detail Описание конкретного случая ошибки
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Ошибки в отдельных полях запроса
+	Errors *[]ProblemField `json:"errors,omitempty"`
+
+	// Status HTTP-код ответа
+	Status int `json:"status"`
+
+	// Title Краткое описание типа ошибки
+	Title string `json:"title"`
+
+	// Type Ссылка на тип ошибки
+	Type string `json:"type"`
+}
+
+// ProblemField defines model for ProblemField.
+type ProblemField struct {
+	// Field Имя поля в запросе, как в JSON или в строке запроса
+	Field string `json:"field"`
+
+	// Message Описание ошибки для пользователя
+	Message string `json:"message"`
+
+	// Rule Нарушенное правило проверки
+	Rule string `json:"rule"`
+}
+
+// Reminder defines model for Reminder.
+type Reminder struct {
+	Price int `json:"price"`
+
+	// Reason Причина списания, trial_end - первое списание после окончания пробного периода, start - первое списание без пробного периода, renewal - продление
+	Reason         ReminderReason `json:"reason"`
+	ServiceName    ServiceName    `json:"service_name"`
+	SubscriptionId UUID           `json:"subscription_id"`
+}
+
+// ReminderReason Причина списания, trial_end - первое списание после окончания пробного периода, start - первое списание без пробного периода, renewal - продление
+type ReminderReason string
+
+// Reminders defines model for Reminders.
+type Reminders struct {
+	Items []Reminder `json:"items"`
+	Month Date       `json:"month"`
+
+	// TotalPrice Сумма всех списаний месяца
+	TotalPrice int `json:"total_price"`
+}
+
+// Service defines model for Service.
+type Service struct {
+	Aliases      *[]ServiceName `json:"aliases,omitempty"`
+	Category     *string        `json:"category,omitempty"`
+	DefaultPrice *Price         `json:"default_price,omitempty"`
+	LogoUrl      *string        `json:"logo_url,omitempty"`
+	Name         ServiceName    `json:"name"`
+	ServiceId    *UUID          `json:"service_id,omitempty"`
+}
+
+// ServiceChurn defines model for ServiceChurn.
+type ServiceChurn struct {
+	// AverageLifetime Средняя длительность подписки в месяцах, для действующих подписок - по текущий месяц
+	AverageLifetime float32      `json:"average_lifetime"`
+	Key             string       `json:"key"`
+	Months          []ChurnMonth `json:"months"`
+	ServiceName     ServiceName  `json:"service_name"`
+}
+
+// ServiceCreate defines model for ServiceCreate.
+type ServiceCreate struct {
+	Aliases      *[]ServiceName `json:"aliases,omitempty"`
+	Category     *string        `json:"category,omitempty"`
+	DefaultPrice *Price         `json:"default_price,omitempty"`
+	LogoUrl      *string        `json:"logo_url,omitempty"`
+	Name         ServiceName    `json:"name"`
+}
+
 // ServiceName defines model for ServiceName.
 type ServiceName = string
 
+// ServicePatch defines model for ServicePatch.
+type ServicePatch struct {
+	Aliases      *[]ServiceName `json:"aliases,omitempty"`
+	Category     *string        `json:"category,omitempty"`
+	DefaultPrice *Price         `json:"default_price,omitempty"`
+	LogoUrl      *string        `json:"logo_url,omitempty"`
+	Name         *ServiceName   `json:"name,omitempty"`
+}
+
+// ServicePrice Самая дорогая из активных подписок
+type ServicePrice struct {
+	Price       Price       `json:"price"`
+	ServiceName ServiceName `json:"service_name"`
+}
+
+// Services defines model for Services.
+type Services = []Service
+
+// StatsDimension defines model for StatsDimension.
+type StatsDimension string
+
+// StatsGroup defines model for StatsGroup.
+type StatsGroup struct {
+	Key        string `json:"key"`
+	Name       string `json:"name"`
+	TotalPrice int    `json:"total_price"`
+}
+
+// StatsGroupBy defines model for StatsGroupBy.
+type StatsGroupBy string
+
+// StatsMetric total - суммарная стоимость за период,
+// run_rate - стоимость за последний месяц периода,
+// count - количество подписок за период
+type StatsMetric string
+
 // Subscription defines model for Subscription.
 type Subscription struct {
+	Category *string `json:"category,omitempty"`
+
+	// EndDate Месяц окончания, не раньше start_date
 	EndDate        *Date       `json:"end_date,omitempty"`
+	Pauses         *[]Pause    `json:"pauses,omitempty"`
 	Price          Price       `json:"price"`
+	ServiceId      *UUID       `json:"service_id,omitempty"`
 	ServiceName    ServiceName `json:"service_name"`
 	StartDate      Date        `json:"start_date"`
 	SubscriptionId *UUID       `json:"subscription_id,omitempty"`
+	Tags           *[]string   `json:"tags,omitempty"`
+	TrialEndDate   *Date       `json:"trial_end_date,omitempty"`
 	UserId         UUID        `json:"user_id"`
 }
 
+// SubscriptionBatch defines model for SubscriptionBatch.
+type SubscriptionBatch struct {
+	// Mode atomic - при ошибке любой операции не применяется ни одна, best_effort - ошибочные операции пропускаются, остальные применяются
+	Mode       *SubscriptionBatchMode `json:"mode,omitempty"`
+	Operations []BatchOperation       `json:"operations"`
+}
+
+// SubscriptionBatchMode atomic - при ошибке любой операции не применяется ни одна, best_effort - ошибочные операции пропускаются, остальные применяются
+type SubscriptionBatchMode string
+
 // SubscriptionCreate defines model for SubscriptionCreate.
 type SubscriptionCreate struct {
-	EndDate     *Date       `json:"end_date,omitempty"`
-	Price       Price       `json:"price"`
-	ServiceName ServiceName `json:"service_name"`
-	StartDate   Date        `json:"start_date"`
-	UserId      UUID        `json:"user_id"`
+	Category *string `json:"category,omitempty"`
+
+	// EndDate Месяц окончания, не раньше start_date
+	EndDate      *Date       `json:"end_date,omitempty"`
+	Price        Price       `json:"price"`
+	ServiceId    *UUID       `json:"service_id,omitempty"`
+	ServiceName  ServiceName `json:"service_name"`
+	StartDate    Date        `json:"start_date"`
+	Tags         *[]string   `json:"tags,omitempty"`
+	TrialEndDate *Date       `json:"trial_end_date,omitempty"`
+	UserId       UUID        `json:"user_id"`
+}
+
+// SubscriptionMergePatch Изменения подписки в формате JSON Merge Patch (RFC 7396): null очищает end_date, trial_end_date, category и tags
+type SubscriptionMergePatch struct {
+	Category nullable.Nullable[string] `json:"category,omitempty"`
+
+	// EndDate Месяц окончания, не раньше start_date
+	EndDate nullable.Nullable[string] `json:"end_date,omitempty"`
+
+	// Price Обязательное поле, null отклоняется с кодом 422
+	Price     nullable.Nullable[Price] `json:"price,omitempty"`
+	ServiceId *UUID                    `json:"service_id,omitempty"`
+
+	// ServiceName Обязательное поле, null отклоняется с кодом 422
+	ServiceName nullable.Nullable[ServiceName] `json:"service_name,omitempty"`
+
+	// StartDate Обязательное поле, null отклоняется с кодом 422
+	StartDate    nullable.Nullable[Date]     `json:"start_date,omitempty"`
+	Tags         nullable.Nullable[[]string] `json:"tags,omitempty"`
+	TrialEndDate nullable.Nullable[string]   `json:"trial_end_date,omitempty"`
+
+	// UserId Обязательное поле, null отклоняется с кодом 422
+	UserId nullable.Nullable[UUID] `json:"user_id,omitempty"`
 }
 
 // SubscriptionPatch defines model for SubscriptionPatch.
 type SubscriptionPatch struct {
-	EndDate     *Date        `json:"end_date,omitempty"`
-	Price       *Price       `json:"price,omitempty"`
-	ServiceName *ServiceName `json:"service_name,omitempty"`
-	StartDate   *Date        `json:"start_date,omitempty"`
-	UserId      *UUID        `json:"user_id,omitempty"`
+	Category *string `json:"category,omitempty"`
+
+	// EndDate Месяц окончания, не раньше start_date
+	EndDate      *Date        `json:"end_date,omitempty"`
+	Price        *Price       `json:"price,omitempty"`
+	ServiceId    *UUID        `json:"service_id,omitempty"`
+	ServiceName  *ServiceName `json:"service_name,omitempty"`
+	StartDate    *Date        `json:"start_date,omitempty"`
+	Tags         *[]string    `json:"tags,omitempty"`
+	TrialEndDate *Date        `json:"trial_end_date,omitempty"`
+	UserId       *UUID        `json:"user_id,omitempty"`
 }
 
+// SubscriptionStatus defines model for SubscriptionStatus.
+type SubscriptionStatus string
+
 // Subscriptions defines model for Subscriptions.
 type Subscriptions = []Subscription
 
+// TopItem defines model for TopItem.
+type TopItem struct {
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	Rank  int    `json:"rank"`
+	Value int    `json:"value"`
+}
+
 // UUID defines model for UUID.
 type UUID = openapi_types.UUID
 
+// UserSummary defines model for UserSummary.
+type UserSummary struct {
+	// ActiveCount Количество активных в текущем месяце подписок
+	ActiveCount int `json:"active_count"`
+
+	// Last12MonthsSpend Расходы за последние 12 месяцев, включая текущий
+	Last12MonthsSpend int `json:"last_12_months_spend"`
+
+	// LifetimeSpend Расходы за все время
+	LifetimeSpend int `json:"lifetime_spend"`
+
+	// MonthlyRunRate Расходы за текущий месяц
+	MonthlyRunRate int `json:"monthly_run_rate"`
+
+	// MostExpensiveService Самая дорогая из активных подписок
+	MostExpensiveService *ServicePrice `json:"most_expensive_service,omitempty"`
+
+	// NextEndDate Ближайшая дата окончания подписки
+	NextEndDate *Date `json:"next_end_date,omitempty"`
+}
+
+// ListDuplicatesParams defines parameters for ListDuplicates.
+type ListDuplicatesParams struct {
+	// UserId Фильтр по id пользователей
+	UserId *[]UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+}
+
+// ListServicesParams defines parameters for ListServices.
+type ListServicesParams struct {
+	// Offset Смещение от начала списка
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit Количество получаемых элементов
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Category Фильтр по категории сервиса
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+}
+
 // StatsSubscriptionsParams defines parameters for StatsSubscriptions.
 type StatsSubscriptionsParams struct {
+	// StartDate Первый месяц периода включительно
+	StartDate *Date `form:"start_date,omitempty" json:"start_date,omitempty"`
+
+	// EndDate Последний месяц периода включительно
+	EndDate *Date `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// UserId Фильтр по id пользователей
+	UserId *[]UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// ServiceName Фильтр по названиям подписок, названия и псевдонимы сервисов каталога приводятся к основному названию
+	ServiceName *[]ServiceName `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Category Фильтр по категориям подписок
+	Category *[]string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Tag Фильтр по тегам, подписка должна содержать все указанные теги
+	Tag *[]string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// GroupBy Разбивка суммарной стоимости по группам
+	GroupBy *StatsGroupBy `form:"group_by,omitempty" json:"group_by,omitempty"`
+}
+
+// ChurnSubscriptionsParams defines parameters for ChurnSubscriptions.
+type ChurnSubscriptionsParams struct {
+	// StartDate Первый месяц периода, по умолчанию за 11 месяцев до последнего
+	StartDate *Date `form:"start_date,omitempty" json:"start_date,omitempty"`
+
+	// EndDate Последний месяц периода, по умолчанию текущий
+	EndDate *Date `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// UserId Фильтр по id пользователей
+	UserId *[]UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// ServiceName Фильтр по названиям подписок
+	ServiceName *[]ServiceName `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Category Фильтр по категориям подписок
+	Category *[]string `form:"category,omitempty" json:"category,omitempty"`
+}
+
+// CohortSubscriptionsParams defines parameters for CohortSubscriptions.
+type CohortSubscriptionsParams struct {
+	// StartDate Первая когорта, по умолчанию за 11 месяцев до последней
+	StartDate *Date `form:"start_date,omitempty" json:"start_date,omitempty"`
+
+	// EndDate Последняя когорта, по умолчанию текущий месяц
+	EndDate *Date `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// UserId Фильтр по id пользователей
+	UserId *[]UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// ServiceName Фильтр по названиям подписок
+	ServiceName *[]ServiceName `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Value Значения матрицы, количество или доля действующих подписок когорты
+	Value *CohortSubscriptionsParamsValue `form:"value,omitempty" json:"value,omitempty"`
+
+	// Format Формат ответа
+	Format *CohortSubscriptionsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// CohortSubscriptionsParamsValue defines parameters for CohortSubscriptions.
+type CohortSubscriptionsParamsValue string
+
+// CohortSubscriptionsParamsFormat defines parameters for CohortSubscriptions.
+type CohortSubscriptionsParamsFormat string
+
+// ForecastSubscriptionsParams defines parameters for ForecastSubscriptions.
+type ForecastSubscriptionsParams struct {
+	// StartDate Первый месяц прогноза, по умолчанию следующий месяц
+	StartDate *Date `form:"start_date,omitempty" json:"start_date,omitempty"`
+
+	// EndDate Последний месяц прогноза, по умолчанию через 12 месяцев от начала
+	EndDate *Date `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// UserId Фильтр по id пользователей
+	UserId *[]UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// ServiceName Фильтр по названиям подписок
+	ServiceName *[]ServiceName `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Category Фильтр по категориям подписок
+	Category *[]string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Tag Фильтр по тегам, подписка должна содержать все указанные теги
+	Tag *[]string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Cancel Сценарий отмены, подписки с указанными id не учитываются в прогнозе
+	Cancel *[]UUID `form:"cancel,omitempty" json:"cancel,omitempty"`
+
+	// PriceChange Сценарий изменения цен, процент изменения стоимости всех подписок
+	PriceChange *int `form:"price_change,omitempty" json:"price_change,omitempty"`
+}
+
+// TopSubscriptionsParams defines parameters for TopSubscriptions.
+type TopSubscriptionsParams struct {
+	// Dimension Признак группировки, по умолчанию название сервиса
+	Dimension *StatsDimension `form:"dimension,omitempty" json:"dimension,omitempty"`
+
+	// Metric Показатель для сортировки, по умолчанию суммарная стоимость
+	Metric *StatsMetric `form:"metric,omitempty" json:"metric,omitempty"`
+
+	// Limit Количество мест в рейтинге
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
 	// StartDate Дата начала интервала
 	StartDate *Date `form:"start_date,omitempty" json:"start_date,omitempty"`
 
 	// EndDate Дата окончания интервала
 	EndDate *Date `form:"end_date,omitempty" json:"end_date,omitempty"`
 
-	// UserId Фильтр по id пользователя
-	UserId *UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+	// UserId Фильтр по id пользователей
+	UserId *[]UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
 
-	// ServiceName Фильтр по названию подписки
-	ServiceName *ServiceName `form:"service_name,omitempty" json:"service_name,omitempty"`
+	// ServiceName Фильтр по названиям подписок
+	ServiceName *[]ServiceName `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Category Фильтр по категориям подписок
+	Category *[]string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Tag Фильтр по тегам, подписка должна содержать все указанные теги
+	Tag *[]string `form:"tag,omitempty" json:"tag,omitempty"`
 }
 
 // ListSubscriptionsParams defines parameters for ListSubscriptions.
@@ -91,30 +610,144 @@ type ListSubscriptionsParams struct {
 	// EndDate Дата окончания интервала
 	EndDate *Date `form:"end_date,omitempty" json:"end_date,omitempty"`
 
-	// UserId Фильтр по id пользователя
-	UserId *UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+	// UserId Фильтр по id пользователей
+	UserId *[]UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// ServiceName Фильтр по названиям подписок, названия и псевдонимы сервисов каталога приводятся к основному названию
+	ServiceName *[]ServiceName `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Category Фильтр по категориям подписок
+	Category *[]string `form:"category,omitempty" json:"category,omitempty"`
+
+	// Tag Фильтр по тегам, подписка должна содержать все указанные теги
+	Tag *[]string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// ServiceNamePrefix Поиск по началу названия подписки без учета регистра
+	ServiceNamePrefix *string `form:"service_name_prefix,omitempty" json:"service_name_prefix,omitempty"`
+
+	// PriceMin Минимальная стоимость подписки
+	PriceMin *int `form:"price_min,omitempty" json:"price_min,omitempty"`
+
+	// PriceMax Максимальная стоимость подписки
+	PriceMax *int `form:"price_max,omitempty" json:"price_max,omitempty"`
+
+	// Status Статус подписки относительно текущего месяца
+	Status *SubscriptionStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// CreateSubscriptionParams defines parameters for CreateSubscription.
+type CreateSubscriptionParams struct {
+	// RejectDuplicates Отклонить создание подписки, пересекающейся с существующей подпиской на тот же сервис
+	RejectDuplicates *bool `form:"reject_duplicates,omitempty" json:"reject_duplicates,omitempty"`
+
+	// IdempotencyKey Ключ идемпотентности. Повтор запроса с тем же ключом и телом возвращает исходный ответ вместо создания новой подписки. Ключ действует в пределах пользователя подписки (user_id)
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// StreamSubscriptionEventsParams defines parameters for StreamSubscriptionEvents.
+type StreamSubscriptionEventsParams struct {
+	// UserId Фильтр по id пользователей
+	UserId *[]UUID `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// LastEventID Идентификатор последнего полученного события
+	LastEventID *int64 `json:"Last-Event-ID,omitempty"`
+}
+
+// PauseSubscriptionJSONBody defines parameters for PauseSubscription.
+type PauseSubscriptionJSONBody struct {
+	// StartDate Первый неоплачиваемый месяц, по умолчанию текущий
+	StartDate *Date `json:"start_date,omitempty"`
+}
+
+// ResumeSubscriptionJSONBody defines parameters for ResumeSubscription.
+type ResumeSubscriptionJSONBody struct {
+	// ResumeDate Месяц возобновления оплаты, по умолчанию текущий
+	ResumeDate *Date `json:"resume_date,omitempty"`
+}
 
-	// ServiceName Фильтр по названию подписки
-	ServiceName *ServiceName `form:"service_name,omitempty" json:"service_name,omitempty"`
+// UserRemindersParams defines parameters for UserReminders.
+type UserRemindersParams struct {
+	// Month Месяц списаний, по умолчанию следующий месяц
+	Month *Date `form:"month,omitempty" json:"month,omitempty"`
 }
 
+// CreateServiceJSONRequestBody defines body for CreateService for application/json ContentType.
+type CreateServiceJSONRequestBody = ServiceCreate
+
+// UpdateServiceJSONRequestBody defines body for UpdateService for application/json ContentType.
+type UpdateServiceJSONRequestBody = ServicePatch
+
 // CreateSubscriptionJSONRequestBody defines body for CreateSubscription for application/json ContentType.
 type CreateSubscriptionJSONRequestBody = Subscription
 
+// BatchSubscriptionsJSONRequestBody defines body for BatchSubscriptions for application/json ContentType.
+type BatchSubscriptionsJSONRequestBody = SubscriptionBatch
+
 // UpdateSubscriptionJSONRequestBody defines body for UpdateSubscription for application/json ContentType.
 type UpdateSubscriptionJSONRequestBody = SubscriptionPatch
 
+// UpdateSubscriptionApplicationMergePatchPlusJSONRequestBody defines body for UpdateSubscription for application/merge-patch+json ContentType.
+type UpdateSubscriptionApplicationMergePatchPlusJSONRequestBody = SubscriptionMergePatch
+
+// ReplaceSubscriptionJSONRequestBody defines body for ReplaceSubscription for application/json ContentType.
+type ReplaceSubscriptionJSONRequestBody = Subscription
+
+// PauseSubscriptionJSONRequestBody defines body for PauseSubscription for application/json ContentType.
+type PauseSubscriptionJSONRequestBody PauseSubscriptionJSONBody
+
+// ResumeSubscriptionJSONRequestBody defines body for ResumeSubscription for application/json ContentType.
+type ResumeSubscriptionJSONRequestBody ResumeSubscriptionJSONBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Пересекающиеся подписки на один сервис по всем пользователям
+	// (GET /insights/duplicates)
+	ListDuplicates(c *fiber.Ctx, params ListDuplicatesParams) error
+	// Получение каталога сервисов
+	// (GET /services)
+	ListServices(c *fiber.Ctx, params ListServicesParams) error
+	// Добавление сервиса в каталог
+	// (POST /services)
+	CreateService(c *fiber.Ctx) error
+	// Удаление сервиса из каталога
+	// (DELETE /services/{service_id})
+	DeleteService(c *fiber.Ctx, serviceId UUID) error
+	// Получить сервис по идентификатору
+	// (GET /services/{service_id})
+	ReadService(c *fiber.Ctx, serviceId UUID) error
+	// Изменение сервиса
+	// (PATCH /services/{service_id})
+	UpdateService(c *fiber.Ctx, serviceId UUID) error
 	// Статистика по всем подпискам за период (суммарная стоимость)
 	// (GET /stats)
 	StatsSubscriptions(c *fiber.Ctx, params StatsSubscriptionsParams) error
+	// Счетчики попаданий и промахов кэша статистики
+	// (GET /stats/cache)
+	StatsCache(c *fiber.Ctx) error
+	// Показатели оттока и удержания по сервисам за период
+	// (GET /stats/churn)
+	ChurnSubscriptions(c *fiber.Ctx, params ChurnSubscriptionsParams) error
+	// Когортный анализ удержания подписок по месяцу начала
+	// (GET /stats/cohorts)
+	CohortSubscriptions(c *fiber.Ctx, params CohortSubscriptionsParams) error
+	// Прогноз расходов на подписки по месяцам
+	// (GET /stats/forecast)
+	ForecastSubscriptions(c *fiber.Ctx, params ForecastSubscriptionsParams) error
+	// Рейтинг групп подписок по выбранному показателю за период
+	// (GET /stats/top)
+	TopSubscriptions(c *fiber.Ctx, params TopSubscriptionsParams) error
 	// Получение списка подписок
 	// (GET /subscriptions)
 	ListSubscriptions(c *fiber.Ctx, params ListSubscriptionsParams) error
 	// Создание подписки
 	// (POST /subscriptions)
-	CreateSubscription(c *fiber.Ctx) error
+	CreateSubscription(c *fiber.Ctx, params CreateSubscriptionParams) error
+	// Пакетное создание, изменение и удаление подписок
+	// (POST /subscriptions/batch)
+	BatchSubscriptions(c *fiber.Ctx) error
+	// Поток изменений подписок
+	// (GET /subscriptions/events)
+	StreamSubscriptionEvents(c *fiber.Ctx, params StreamSubscriptionEventsParams) error
 	// Удаление подписки по идентификатору
 	// (DELETE /subscriptions/{subscription_id})
 	DeleteSubscription(c *fiber.Ctx, subscriptionId UUID) error
@@ -124,6 +757,24 @@ type ServerInterface interface {
 	// Изменение подписки
 	// (PATCH /subscriptions/{subscription_id})
 	UpdateSubscription(c *fiber.Ctx, subscriptionId UUID) error
+	// Замена подписки
+	// (PUT /subscriptions/{subscription_id})
+	ReplaceSubscription(c *fiber.Ctx, subscriptionId UUID) error
+	// Приостановка оплаты подписки
+	// (POST /subscriptions/{subscription_id}/pause)
+	PauseSubscription(c *fiber.Ctx, subscriptionId UUID) error
+	// Возобновление оплаты подписки
+	// (POST /subscriptions/{subscription_id}/resume)
+	ResumeSubscription(c *fiber.Ctx, subscriptionId UUID) error
+	// Пересекающиеся подписки пользователя на один сервис
+	// (GET /users/{user_id}/insights/duplicates)
+	UserDuplicates(c *fiber.Ctx, userId UUID) error
+	// Напоминания о списаниях по подпискам пользователя за месяц
+	// (GET /users/{user_id}/reminders)
+	UserReminders(c *fiber.Ctx, userId UUID, params UserRemindersParams) error
+	// Сводка расходов пользователя
+	// (GET /users/{user_id}/summary)
+	UserSummary(c *fiber.Ctx, userId UUID) error
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -133,13 +784,13 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc fiber.Handler
 
-// StatsSubscriptions operation middleware
-func (siw *ServerInterfaceWrapper) StatsSubscriptions(c *fiber.Ctx) error {
+// ListDuplicates operation middleware
+func (siw *ServerInterfaceWrapper) ListDuplicates(c *fiber.Ctx) error {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params StatsSubscriptionsParams
+	var params ListDuplicatesParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -147,20 +798,6 @@ func (siw *ServerInterfaceWrapper) StatsSubscriptions(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "start_date" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "start_date", query, &params.StartDate)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter start_date: %w", err).Error())
-	}
-
-	// ------------- Optional query parameter "end_date" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "end_date", query, &params.EndDate)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter end_date: %w", err).Error())
-	}
-
 	// ------------- Optional query parameter "user_id" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "user_id", query, &params.UserId)
@@ -168,23 +805,16 @@ func (siw *ServerInterfaceWrapper) StatsSubscriptions(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "service_name" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "service_name", query, &params.ServiceName)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service_name: %w", err).Error())
-	}
-
-	return siw.Handler.StatsSubscriptions(c, params)
+	return siw.Handler.ListDuplicates(c, params)
 }
 
-// ListSubscriptions operation middleware
-func (siw *ServerInterfaceWrapper) ListSubscriptions(c *fiber.Ctx) error {
+// ListServices operation middleware
+func (siw *ServerInterfaceWrapper) ListServices(c *fiber.Ctx) error {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListSubscriptionsParams
+	var params ListServicesParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -206,399 +836,2217 @@ func (siw *ServerInterfaceWrapper) ListSubscriptions(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "start_date" -------------
+	// ------------- Optional query parameter "category" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "start_date", query, &params.StartDate)
+	err = runtime.BindQueryParameter("form", true, false, "category", query, &params.Category)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter start_date: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter category: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "end_date" -------------
+	return siw.Handler.ListServices(c, params)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "end_date", query, &params.EndDate)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter end_date: %w", err).Error())
-	}
+// CreateService operation middleware
+func (siw *ServerInterfaceWrapper) CreateService(c *fiber.Ctx) error {
 
-	// ------------- Optional query parameter "user_id" -------------
+	return siw.Handler.CreateService(c)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "user_id", query, &params.UserId)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
-	}
+// DeleteService operation middleware
+func (siw *ServerInterfaceWrapper) DeleteService(c *fiber.Ctx) error {
 
-	// ------------- Optional query parameter "service_name" -------------
+	var err error
 
-	err = runtime.BindQueryParameter("form", true, false, "service_name", query, &params.ServiceName)
+	// ------------- Path parameter "service_id" -------------
+	var serviceId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "service_id", c.Params("service_id"), &serviceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service_name: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service_id: %w", err).Error())
 	}
 
-	return siw.Handler.ListSubscriptions(c, params)
+	return siw.Handler.DeleteService(c, serviceId)
 }
 
-// CreateSubscription operation middleware
-func (siw *ServerInterfaceWrapper) CreateSubscription(c *fiber.Ctx) error {
+// ReadService operation middleware
+func (siw *ServerInterfaceWrapper) ReadService(c *fiber.Ctx) error {
 
-	return siw.Handler.CreateSubscription(c)
-}
+	var err error
 
-// DeleteSubscription operation middleware
-func (siw *ServerInterfaceWrapper) DeleteSubscription(c *fiber.Ctx) error {
+	// ------------- Path parameter "service_id" -------------
+	var serviceId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "service_id", c.Params("service_id"), &serviceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service_id: %w", err).Error())
+	}
+
+	return siw.Handler.ReadService(c, serviceId)
+}
+
+// UpdateService operation middleware
+func (siw *ServerInterfaceWrapper) UpdateService(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "subscription_id" -------------
-	var subscriptionId UUID
+	// ------------- Path parameter "service_id" -------------
+	var serviceId UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Params("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "service_id", c.Params("service_id"), &serviceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscription_id: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service_id: %w", err).Error())
 	}
 
-	return siw.Handler.DeleteSubscription(c, subscriptionId)
+	return siw.Handler.UpdateService(c, serviceId)
 }
 
-// ReadSubscription operation middleware
-func (siw *ServerInterfaceWrapper) ReadSubscription(c *fiber.Ctx) error {
+// StatsSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) StatsSubscriptions(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "subscription_id" -------------
-	var subscriptionId UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StatsSubscriptionsParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Params("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscription_id: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	return siw.Handler.ReadSubscription(c, subscriptionId)
-}
+	// ------------- Optional query parameter "start_date" -------------
 
-// UpdateSubscription operation middleware
-func (siw *ServerInterfaceWrapper) UpdateSubscription(c *fiber.Ctx) error {
+	err = runtime.BindQueryParameter("form", true, false, "start_date", query, &params.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter start_date: %w", err).Error())
+	}
 
-	var err error
+	// ------------- Optional query parameter "end_date" -------------
 
-	// ------------- Path parameter "subscription_id" -------------
-	var subscriptionId UUID
+	err = runtime.BindQueryParameter("form", true, false, "end_date", query, &params.EndDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter end_date: %w", err).Error())
+	}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Params("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", query, &params.UserId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscription_id: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
 	}
 
-	return siw.Handler.UpdateSubscription(c, subscriptionId)
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", query, &params.ServiceName)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service_name: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", query, &params.Category)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter category: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", query, &params.Tag)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter tag: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "group_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "group_by", query, &params.GroupBy)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter group_by: %w", err).Error())
+	}
+
+	return siw.Handler.StatsSubscriptions(c, params)
 }
 
-// FiberServerOptions provides options for the Fiber server.
-type FiberServerOptions struct {
-	BaseURL     string
-	Middlewares []MiddlewareFunc
+// StatsCache operation middleware
+func (siw *ServerInterfaceWrapper) StatsCache(c *fiber.Ctx) error {
+
+	return siw.Handler.StatsCache(c)
 }
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router fiber.Router, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, FiberServerOptions{})
+// ChurnSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) ChurnSubscriptions(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ChurnSubscriptionsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "start_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_date", query, &params.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter start_date: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_date", query, &params.EndDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter end_date: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", query, &params.UserId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", query, &params.ServiceName)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service_name: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", query, &params.Category)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter category: %w", err).Error())
+	}
+
+	return siw.Handler.ChurnSubscriptions(c, params)
 }
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, options FiberServerOptions) {
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+// CohortSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) CohortSubscriptions(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CohortSubscriptionsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	for _, m := range options.Middlewares {
-		router.Use(fiber.Handler(m))
+	// ------------- Optional query parameter "start_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_date", query, &params.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter start_date: %w", err).Error())
 	}
 
-	router.Get(options.BaseURL+"/stats", wrapper.StatsSubscriptions)
+	// ------------- Optional query parameter "end_date" -------------
 
-	router.Get(options.BaseURL+"/subscriptions", wrapper.ListSubscriptions)
+	err = runtime.BindQueryParameter("form", true, false, "end_date", query, &params.EndDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter end_date: %w", err).Error())
+	}
 
-	router.Post(options.BaseURL+"/subscriptions", wrapper.CreateSubscription)
+	// ------------- Optional query parameter "user_id" -------------
 
-	router.Delete(options.BaseURL+"/subscriptions/:subscription_id", wrapper.DeleteSubscription)
+	err = runtime.BindQueryParameter("form", true, false, "user_id", query, &params.UserId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
+	}
 
-	router.Get(options.BaseURL+"/subscriptions/:subscription_id", wrapper.ReadSubscription)
+	// ------------- Optional query parameter "service_name" -------------
 
-	router.Patch(options.BaseURL+"/subscriptions/:subscription_id", wrapper.UpdateSubscription)
+	err = runtime.BindQueryParameter("form", true, false, "service_name", query, &params.ServiceName)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service_name: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "value" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "value", query, &params.Value)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter value: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter format: %w", err).Error())
+	}
 
+	return siw.Handler.CohortSubscriptions(c, params)
 }
 
-type StatsSubscriptionsRequestObject struct {
-	Params StatsSubscriptionsParams
+// ForecastSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) ForecastSubscriptions(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ForecastSubscriptionsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "start_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_date", query, &params.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter start_date: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_date", query, &params.EndDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter end_date: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", query, &params.UserId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", query, &params.ServiceName)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service_name: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", query, &params.Category)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter category: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", query, &params.Tag)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter tag: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "cancel" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cancel", query, &params.Cancel)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter cancel: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "price_change" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "price_change", query, &params.PriceChange)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter price_change: %w", err).Error())
+	}
+
+	return siw.Handler.ForecastSubscriptions(c, params)
 }
 
-type StatsSubscriptionsResponseObject interface {
-	VisitStatsSubscriptionsResponse(ctx *fiber.Ctx) error
+// TopSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) TopSubscriptions(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TopSubscriptionsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "dimension" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dimension", query, &params.Dimension)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter dimension: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "metric" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "metric", query, &params.Metric)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter metric: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "start_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_date", query, &params.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter start_date: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_date", query, &params.EndDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter end_date: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", query, &params.UserId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", query, &params.ServiceName)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service_name: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", query, &params.Category)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter category: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", query, &params.Tag)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter tag: %w", err).Error())
+	}
+
+	return siw.Handler.TopSubscriptions(c, params)
+}
+
+// ListSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) ListSubscriptions(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListSubscriptionsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", query, &params.Offset)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter offset: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "start_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_date", query, &params.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter start_date: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_date", query, &params.EndDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter end_date: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", query, &params.UserId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", query, &params.ServiceName)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service_name: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", query, &params.Category)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter category: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", query, &params.Tag)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter tag: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "service_name_prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name_prefix", query, &params.ServiceNamePrefix)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter service_name_prefix: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "price_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "price_min", query, &params.PriceMin)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter price_min: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "price_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "price_max", query, &params.PriceMax)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter price_max: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", query, &params.Status)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter status: %w", err).Error())
+	}
+
+	return siw.Handler.ListSubscriptions(c, params)
+}
+
+// CreateSubscription operation middleware
+func (siw *ServerInterfaceWrapper) CreateSubscription(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateSubscriptionParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "reject_duplicates" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reject_duplicates", query, &params.RejectDuplicates)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter reject_duplicates: %w", err).Error())
+	}
+
+	headers := c.GetReqHeaders()
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Too many values for ParamName Idempotency-Key, 1 is required, but %d found", n))
+		}
+		value := valueList[0]
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", value, &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err).Error())
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	return siw.Handler.CreateSubscription(c, params)
+}
+
+// BatchSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) BatchSubscriptions(c *fiber.Ctx) error {
+
+	return siw.Handler.BatchSubscriptions(c)
+}
+
+// StreamSubscriptionEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamSubscriptionEvents(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamSubscriptionEventsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", query, &params.UserId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
+	}
+
+	headers := c.GetReqHeaders()
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID int64
+		n := len(valueList)
+		if n != 1 {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Too many values for ParamName Last-Event-ID, 1 is required, but %d found", n))
+		}
+		value := valueList[0]
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", value, &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter Last-Event-ID: %w", err).Error())
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	return siw.Handler.StreamSubscriptionEvents(c, params)
+}
+
+// DeleteSubscription operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSubscription(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "subscription_id" -------------
+	var subscriptionId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Params("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscription_id: %w", err).Error())
+	}
+
+	return siw.Handler.DeleteSubscription(c, subscriptionId)
+}
+
+// ReadSubscription operation middleware
+func (siw *ServerInterfaceWrapper) ReadSubscription(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "subscription_id" -------------
+	var subscriptionId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Params("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscription_id: %w", err).Error())
+	}
+
+	return siw.Handler.ReadSubscription(c, subscriptionId)
+}
+
+// UpdateSubscription operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSubscription(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "subscription_id" -------------
+	var subscriptionId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Params("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscription_id: %w", err).Error())
+	}
+
+	return siw.Handler.UpdateSubscription(c, subscriptionId)
+}
+
+// ReplaceSubscription operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceSubscription(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "subscription_id" -------------
+	var subscriptionId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Params("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscription_id: %w", err).Error())
+	}
+
+	return siw.Handler.ReplaceSubscription(c, subscriptionId)
+}
+
+// PauseSubscription operation middleware
+func (siw *ServerInterfaceWrapper) PauseSubscription(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "subscription_id" -------------
+	var subscriptionId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Params("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscription_id: %w", err).Error())
+	}
+
+	return siw.Handler.PauseSubscription(c, subscriptionId)
+}
+
+// ResumeSubscription operation middleware
+func (siw *ServerInterfaceWrapper) ResumeSubscription(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "subscription_id" -------------
+	var subscriptionId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscription_id", c.Params("subscription_id"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscription_id: %w", err).Error())
+	}
+
+	return siw.Handler.ResumeSubscription(c, subscriptionId)
+}
+
+// UserDuplicates operation middleware
+func (siw *ServerInterfaceWrapper) UserDuplicates(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Params("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
+	}
+
+	return siw.Handler.UserDuplicates(c, userId)
+}
+
+// UserReminders operation middleware
+func (siw *ServerInterfaceWrapper) UserReminders(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Params("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UserRemindersParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "month" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "month", query, &params.Month)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter month: %w", err).Error())
+	}
+
+	return siw.Handler.UserReminders(c, userId, params)
+}
+
+// UserSummary operation middleware
+func (siw *ServerInterfaceWrapper) UserSummary(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Params("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
+	}
+
+	return siw.Handler.UserSummary(c, userId)
+}
+
+// FiberServerOptions provides options for the Fiber server.
+type FiberServerOptions struct {
+	BaseURL     string
+	Middlewares []MiddlewareFunc
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router fiber.Router, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, FiberServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, options FiberServerOptions) {
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	for _, m := range options.Middlewares {
+		router.Use(fiber.Handler(m))
+	}
+
+	router.Get(options.BaseURL+"/insights/duplicates", wrapper.ListDuplicates)
+
+	router.Get(options.BaseURL+"/services", wrapper.ListServices)
+
+	router.Post(options.BaseURL+"/services", wrapper.CreateService)
+
+	router.Delete(options.BaseURL+"/services/:service_id", wrapper.DeleteService)
+
+	router.Get(options.BaseURL+"/services/:service_id", wrapper.ReadService)
+
+	router.Patch(options.BaseURL+"/services/:service_id", wrapper.UpdateService)
+
+	router.Get(options.BaseURL+"/stats", wrapper.StatsSubscriptions)
+
+	router.Get(options.BaseURL+"/stats/cache", wrapper.StatsCache)
+
+	router.Get(options.BaseURL+"/stats/churn", wrapper.ChurnSubscriptions)
+
+	router.Get(options.BaseURL+"/stats/cohorts", wrapper.CohortSubscriptions)
+
+	router.Get(options.BaseURL+"/stats/forecast", wrapper.ForecastSubscriptions)
+
+	router.Get(options.BaseURL+"/stats/top", wrapper.TopSubscriptions)
+
+	router.Get(options.BaseURL+"/subscriptions", wrapper.ListSubscriptions)
+
+	router.Post(options.BaseURL+"/subscriptions", wrapper.CreateSubscription)
+
+	router.Post(options.BaseURL+"/subscriptions/batch", wrapper.BatchSubscriptions)
+
+	router.Get(options.BaseURL+"/subscriptions/events", wrapper.StreamSubscriptionEvents)
+
+	router.Delete(options.BaseURL+"/subscriptions/:subscription_id", wrapper.DeleteSubscription)
+
+	router.Get(options.BaseURL+"/subscriptions/:subscription_id", wrapper.ReadSubscription)
+
+	router.Patch(options.BaseURL+"/subscriptions/:subscription_id", wrapper.UpdateSubscription)
+
+	router.Put(options.BaseURL+"/subscriptions/:subscription_id", wrapper.ReplaceSubscription)
+
+	router.Post(options.BaseURL+"/subscriptions/:subscription_id/pause", wrapper.PauseSubscription)
+
+	router.Post(options.BaseURL+"/subscriptions/:subscription_id/resume", wrapper.ResumeSubscription)
+
+	router.Get(options.BaseURL+"/users/:user_id/insights/duplicates", wrapper.UserDuplicates)
+
+	router.Get(options.BaseURL+"/users/:user_id/reminders", wrapper.UserReminders)
+
+	router.Get(options.BaseURL+"/users/:user_id/summary", wrapper.UserSummary)
+
+}
+
+type ListDuplicatesRequestObject struct {
+	Params ListDuplicatesParams
+}
+
+type ListDuplicatesResponseObject interface {
+	VisitListDuplicatesResponse(ctx *fiber.Ctx) error
+}
+
+type ListDuplicates200JSONResponse Duplicates
+
+func (response ListDuplicates200JSONResponse) VisitListDuplicatesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListDuplicatesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ListDuplicatesdefaultApplicationProblemPlusJSONResponse) VisitListDuplicatesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type ListServicesRequestObject struct {
+	Params ListServicesParams
+}
+
+type ListServicesResponseObject interface {
+	VisitListServicesResponse(ctx *fiber.Ctx) error
+}
+
+type ListServices200JSONResponse Services
+
+func (response ListServices200JSONResponse) VisitListServicesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListServicesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ListServicesdefaultApplicationProblemPlusJSONResponse) VisitListServicesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type CreateServiceRequestObject struct {
+	Body *CreateServiceJSONRequestBody
+}
+
+type CreateServiceResponseObject interface {
+	VisitCreateServiceResponse(ctx *fiber.Ctx) error
+}
+
+type CreateService200JSONResponse struct {
+	ServiceId *UUID `json:"service_id,omitempty"`
+}
+
+func (response CreateService200JSONResponse) VisitCreateServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CreateService409ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateService409ApplicationProblemPlusJSONResponse) VisitCreateServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(409)
+
+	return ctx.JSON(&response)
+}
+
+type CreateService422ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateService422ApplicationProblemPlusJSONResponse) VisitCreateServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(422)
+
+	return ctx.JSON(&response)
+}
+
+type CreateServicedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CreateServicedefaultApplicationProblemPlusJSONResponse) VisitCreateServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type DeleteServiceRequestObject struct {
+	ServiceId UUID `json:"service_id"`
+}
+
+type DeleteServiceResponseObject interface {
+	VisitDeleteServiceResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteService204Response struct {
+}
+
+func (response DeleteService204Response) VisitDeleteServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type DeleteService404ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteService404ApplicationProblemPlusJSONResponse) VisitDeleteServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteServicedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response DeleteServicedefaultApplicationProblemPlusJSONResponse) VisitDeleteServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type ReadServiceRequestObject struct {
+	ServiceId UUID `json:"service_id"`
+}
+
+type ReadServiceResponseObject interface {
+	VisitReadServiceResponse(ctx *fiber.Ctx) error
+}
+
+type ReadService200JSONResponse Service
+
+func (response ReadService200JSONResponse) VisitReadServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ReadService404ApplicationProblemPlusJSONResponse Problem
+
+func (response ReadService404ApplicationProblemPlusJSONResponse) VisitReadServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ReadServicedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ReadServicedefaultApplicationProblemPlusJSONResponse) VisitReadServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type UpdateServiceRequestObject struct {
+	ServiceId UUID `json:"service_id"`
+	Body      *UpdateServiceJSONRequestBody
+}
+
+type UpdateServiceResponseObject interface {
+	VisitUpdateServiceResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateService204Response struct {
+}
+
+func (response UpdateService204Response) VisitUpdateServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type UpdateService404ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateService404ApplicationProblemPlusJSONResponse) VisitUpdateServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateService409ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateService409ApplicationProblemPlusJSONResponse) VisitUpdateServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(409)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateService422ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateService422ApplicationProblemPlusJSONResponse) VisitUpdateServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(422)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateServicedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response UpdateServicedefaultApplicationProblemPlusJSONResponse) VisitUpdateServiceResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type StatsSubscriptionsRequestObject struct {
+	Params StatsSubscriptionsParams
+}
+
+type StatsSubscriptionsResponseObject interface {
+	VisitStatsSubscriptionsResponse(ctx *fiber.Ctx) error
+}
+
+type StatsSubscriptions200JSONResponse struct {
+	Groups     *[]StatsGroup `json:"groups,omitempty"`
+	TotalPrice *int          `json:"total_price,omitempty"`
+}
+
+func (response StatsSubscriptions200JSONResponse) VisitStatsSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type StatsSubscriptionsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response StatsSubscriptionsdefaultApplicationProblemPlusJSONResponse) VisitStatsSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type StatsCacheRequestObject struct {
+}
+
+type StatsCacheResponseObject interface {
+	VisitStatsCacheResponse(ctx *fiber.Ctx) error
+}
+
+type StatsCache200JSONResponse CacheStats
+
+func (response StatsCache200JSONResponse) VisitStatsCacheResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type StatsCachedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response StatsCachedefaultApplicationProblemPlusJSONResponse) VisitStatsCacheResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type ChurnSubscriptionsRequestObject struct {
+	Params ChurnSubscriptionsParams
+}
+
+type ChurnSubscriptionsResponseObject interface {
+	VisitChurnSubscriptionsResponse(ctx *fiber.Ctx) error
+}
+
+type ChurnSubscriptions200JSONResponse struct {
+	Services []ServiceChurn `json:"services"`
+}
+
+func (response ChurnSubscriptions200JSONResponse) VisitChurnSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ChurnSubscriptionsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ChurnSubscriptionsdefaultApplicationProblemPlusJSONResponse) VisitChurnSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type CohortSubscriptionsRequestObject struct {
+	Params CohortSubscriptionsParams
+}
+
+type CohortSubscriptionsResponseObject interface {
+	VisitCohortSubscriptionsResponse(ctx *fiber.Ctx) error
+}
+
+type CohortSubscriptions200JSONResponse struct {
+	Cohorts []Cohort `json:"cohorts"`
+}
+
+func (response CohortSubscriptions200JSONResponse) VisitCohortSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CohortSubscriptions200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response CohortSubscriptions200TextcsvResponse) VisitCohortSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		ctx.Response().Header.Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	ctx.Status(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(ctx.Response().BodyWriter(), response.Body)
+	return err
+}
+
+type CohortSubscriptionsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CohortSubscriptionsdefaultApplicationProblemPlusJSONResponse) VisitCohortSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type ForecastSubscriptionsRequestObject struct {
+	Params ForecastSubscriptionsParams
+}
+
+type ForecastSubscriptionsResponseObject interface {
+	VisitForecastSubscriptionsResponse(ctx *fiber.Ctx) error
+}
+
+type ForecastSubscriptions200JSONResponse Forecast
+
+func (response ForecastSubscriptions200JSONResponse) VisitForecastSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ForecastSubscriptionsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ForecastSubscriptionsdefaultApplicationProblemPlusJSONResponse) VisitForecastSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type TopSubscriptionsRequestObject struct {
+	Params TopSubscriptionsParams
+}
+
+type TopSubscriptionsResponseObject interface {
+	VisitTopSubscriptionsResponse(ctx *fiber.Ctx) error
+}
+
+type TopSubscriptions200JSONResponse struct {
+	Items []TopItem `json:"items"`
+}
+
+func (response TopSubscriptions200JSONResponse) VisitTopSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type TopSubscriptionsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response TopSubscriptionsdefaultApplicationProblemPlusJSONResponse) VisitTopSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type ListSubscriptionsRequestObject struct {
+	Params ListSubscriptionsParams
+}
+
+type ListSubscriptionsResponseObject interface {
+	VisitListSubscriptionsResponse(ctx *fiber.Ctx) error
+}
+
+type ListSubscriptions200JSONResponse Subscriptions
+
+func (response ListSubscriptions200JSONResponse) VisitListSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListSubscriptionsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ListSubscriptionsdefaultApplicationProblemPlusJSONResponse) VisitListSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type CreateSubscriptionRequestObject struct {
+	Params CreateSubscriptionParams
+	Body   *CreateSubscriptionJSONRequestBody
+}
+
+type CreateSubscriptionResponseObject interface {
+	VisitCreateSubscriptionResponse(ctx *fiber.Ctx) error
+}
+
+type CreateSubscription200JSONResponse struct {
+	// Duplicates Идентификаторы пересекающихся подписок пользователя на тот же сервис
+	Duplicates     *[]UUID `json:"duplicates,omitempty"`
+	SubscriptionId *UUID   `json:"subscription_id,omitempty"`
+}
+
+func (response CreateSubscription200JSONResponse) VisitCreateSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CreateSubscription409ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateSubscription409ApplicationProblemPlusJSONResponse) VisitCreateSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(409)
+
+	return ctx.JSON(&response)
+}
+
+type CreateSubscription422ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateSubscription422ApplicationProblemPlusJSONResponse) VisitCreateSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(422)
+
+	return ctx.JSON(&response)
+}
+
+type CreateSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CreateSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitCreateSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type BatchSubscriptionsRequestObject struct {
+	Body *BatchSubscriptionsJSONRequestBody
+}
+
+type BatchSubscriptionsResponseObject interface {
+	VisitBatchSubscriptionsResponse(ctx *fiber.Ctx) error
+}
+
+type BatchSubscriptions200JSONResponse BatchResults
+
+func (response BatchSubscriptions200JSONResponse) VisitBatchSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type BatchSubscriptions422ApplicationProblemPlusJSONResponse Problem
+
+func (response BatchSubscriptions422ApplicationProblemPlusJSONResponse) VisitBatchSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(422)
+
+	return ctx.JSON(&response)
+}
+
+type BatchSubscriptionsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response BatchSubscriptionsdefaultApplicationProblemPlusJSONResponse) VisitBatchSubscriptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type StreamSubscriptionEventsRequestObject struct {
+	Params StreamSubscriptionEventsParams
+}
+
+type StreamSubscriptionEventsResponseObject interface {
+	VisitStreamSubscriptionEventsResponse(ctx *fiber.Ctx) error
+}
+
+type StreamSubscriptionEvents200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response StreamSubscriptionEvents200TexteventStreamResponse) VisitStreamSubscriptionEventsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		ctx.Response().Header.Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	ctx.Status(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(ctx.Response().BodyWriter(), response.Body)
+	return err
+}
+
+type StreamSubscriptionEventsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response StreamSubscriptionEventsdefaultApplicationProblemPlusJSONResponse) VisitStreamSubscriptionEventsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type DeleteSubscriptionRequestObject struct {
+	SubscriptionId UUID `json:"subscription_id"`
+}
+
+type DeleteSubscriptionResponseObject interface {
+	VisitDeleteSubscriptionResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteSubscription204Response struct {
+}
+
+func (response DeleteSubscription204Response) VisitDeleteSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type DeleteSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteSubscription404ApplicationProblemPlusJSONResponse) VisitDeleteSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response DeleteSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitDeleteSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type ReadSubscriptionRequestObject struct {
+	SubscriptionId UUID `json:"subscription_id"`
+}
+
+type ReadSubscriptionResponseObject interface {
+	VisitReadSubscriptionResponse(ctx *fiber.Ctx) error
+}
+
+type ReadSubscription200JSONResponse Subscription
+
+func (response ReadSubscription200JSONResponse) VisitReadSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ReadSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response ReadSubscription404ApplicationProblemPlusJSONResponse) VisitReadSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ReadSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ReadSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitReadSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type UpdateSubscriptionRequestObject struct {
+	SubscriptionId                    UUID `json:"subscription_id"`
+	JSONBody                          *UpdateSubscriptionJSONRequestBody
+	ApplicationMergePatchPlusJSONBody *UpdateSubscriptionApplicationMergePatchPlusJSONRequestBody
+}
+
+type UpdateSubscriptionResponseObject interface {
+	VisitUpdateSubscriptionResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateSubscription204Response struct {
+}
+
+func (response UpdateSubscription204Response) VisitUpdateSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type UpdateSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateSubscription404ApplicationProblemPlusJSONResponse) VisitUpdateSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateSubscription422ApplicationProblemPlusJSONResponse Problem
+
+func (response UpdateSubscription422ApplicationProblemPlusJSONResponse) VisitUpdateSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(422)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response UpdateSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitUpdateSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type ReplaceSubscriptionRequestObject struct {
+	SubscriptionId UUID `json:"subscription_id"`
+	Body           *ReplaceSubscriptionJSONRequestBody
+}
+
+type ReplaceSubscriptionResponseObject interface {
+	VisitReplaceSubscriptionResponse(ctx *fiber.Ctx) error
+}
+
+type ReplaceSubscription204Response struct {
+}
+
+func (response ReplaceSubscription204Response) VisitReplaceSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type ReplaceSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceSubscription404ApplicationProblemPlusJSONResponse) VisitReplaceSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ReplaceSubscription422ApplicationProblemPlusJSONResponse Problem
+
+func (response ReplaceSubscription422ApplicationProblemPlusJSONResponse) VisitReplaceSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(422)
+
+	return ctx.JSON(&response)
+}
+
+type ReplaceSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ReplaceSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitReplaceSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type PauseSubscriptionRequestObject struct {
+	SubscriptionId UUID `json:"subscription_id"`
+	Body           *PauseSubscriptionJSONRequestBody
+}
+
+type PauseSubscriptionResponseObject interface {
+	VisitPauseSubscriptionResponse(ctx *fiber.Ctx) error
+}
+
+type PauseSubscription204Response struct {
+}
+
+func (response PauseSubscription204Response) VisitPauseSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type PauseSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response PauseSubscription404ApplicationProblemPlusJSONResponse) VisitPauseSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type PauseSubscription409ApplicationProblemPlusJSONResponse Problem
+
+func (response PauseSubscription409ApplicationProblemPlusJSONResponse) VisitPauseSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(409)
+
+	return ctx.JSON(&response)
+}
+
+type PauseSubscription422ApplicationProblemPlusJSONResponse Problem
+
+func (response PauseSubscription422ApplicationProblemPlusJSONResponse) VisitPauseSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(422)
+
+	return ctx.JSON(&response)
+}
+
+type PauseSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response PauseSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitPauseSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type ResumeSubscriptionRequestObject struct {
+	SubscriptionId UUID `json:"subscription_id"`
+	Body           *ResumeSubscriptionJSONRequestBody
+}
+
+type ResumeSubscriptionResponseObject interface {
+	VisitResumeSubscriptionResponse(ctx *fiber.Ctx) error
+}
+
+type ResumeSubscription204Response struct {
+}
+
+func (response ResumeSubscription204Response) VisitResumeSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type ResumeSubscription404ApplicationProblemPlusJSONResponse Problem
+
+func (response ResumeSubscription404ApplicationProblemPlusJSONResponse) VisitResumeSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ResumeSubscription409ApplicationProblemPlusJSONResponse Problem
+
+func (response ResumeSubscription409ApplicationProblemPlusJSONResponse) VisitResumeSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(409)
+
+	return ctx.JSON(&response)
+}
+
+type ResumeSubscription422ApplicationProblemPlusJSONResponse Problem
+
+func (response ResumeSubscription422ApplicationProblemPlusJSONResponse) VisitResumeSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(422)
+
+	return ctx.JSON(&response)
+}
+
+type ResumeSubscriptiondefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ResumeSubscriptiondefaultApplicationProblemPlusJSONResponse) VisitResumeSubscriptionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type UserDuplicatesRequestObject struct {
+	UserId UUID `json:"user_id"`
 }
 
-type StatsSubscriptions200JSONResponse struct {
-	TotalPrice *int `json:"total_price,omitempty"`
+type UserDuplicatesResponseObject interface {
+	VisitUserDuplicatesResponse(ctx *fiber.Ctx) error
 }
 
-func (response StatsSubscriptions200JSONResponse) VisitStatsSubscriptionsResponse(ctx *fiber.Ctx) error {
+type UserDuplicates200JSONResponse Duplicates
+
+func (response UserDuplicates200JSONResponse) VisitUserDuplicatesResponse(ctx *fiber.Ctx) error {
 	ctx.Response().Header.Set("Content-Type", "application/json")
 	ctx.Status(200)
 
 	return ctx.JSON(&response)
 }
 
-type StatsSubscriptionsdefaultJSONResponse struct {
-	Body       Error
+type UserDuplicatesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
 	StatusCode int
 }
 
-func (response StatsSubscriptionsdefaultJSONResponse) VisitStatsSubscriptionsResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
+func (response UserDuplicatesdefaultApplicationProblemPlusJSONResponse) VisitUserDuplicatesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
 	ctx.Status(response.StatusCode)
 
 	return ctx.JSON(&response.Body)
 }
 
-type ListSubscriptionsRequestObject struct {
-	Params ListSubscriptionsParams
+type UserRemindersRequestObject struct {
+	UserId UUID `json:"user_id"`
+	Params UserRemindersParams
 }
 
-type ListSubscriptionsResponseObject interface {
-	VisitListSubscriptionsResponse(ctx *fiber.Ctx) error
+type UserRemindersResponseObject interface {
+	VisitUserRemindersResponse(ctx *fiber.Ctx) error
 }
 
-type ListSubscriptions200JSONResponse Subscriptions
+type UserReminders200JSONResponse Reminders
 
-func (response ListSubscriptions200JSONResponse) VisitListSubscriptionsResponse(ctx *fiber.Ctx) error {
+func (response UserReminders200JSONResponse) VisitUserRemindersResponse(ctx *fiber.Ctx) error {
 	ctx.Response().Header.Set("Content-Type", "application/json")
 	ctx.Status(200)
 
 	return ctx.JSON(&response)
 }
 
-type ListSubscriptionsdefaultJSONResponse struct {
-	Body       Error
+type UserRemindersdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
 	StatusCode int
 }
 
-func (response ListSubscriptionsdefaultJSONResponse) VisitListSubscriptionsResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
+func (response UserRemindersdefaultApplicationProblemPlusJSONResponse) VisitUserRemindersResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
 	ctx.Status(response.StatusCode)
 
 	return ctx.JSON(&response.Body)
 }
 
-type CreateSubscriptionRequestObject struct {
-	Body *CreateSubscriptionJSONRequestBody
+type UserSummaryRequestObject struct {
+	UserId UUID `json:"user_id"`
 }
 
-type CreateSubscriptionResponseObject interface {
-	VisitCreateSubscriptionResponse(ctx *fiber.Ctx) error
+type UserSummaryResponseObject interface {
+	VisitUserSummaryResponse(ctx *fiber.Ctx) error
 }
 
-type CreateSubscription200JSONResponse struct {
-	SubscriptionId *UUID `json:"subscription_id,omitempty"`
-}
+type UserSummary200JSONResponse UserSummary
 
-func (response CreateSubscription200JSONResponse) VisitCreateSubscriptionResponse(ctx *fiber.Ctx) error {
+func (response UserSummary200JSONResponse) VisitUserSummaryResponse(ctx *fiber.Ctx) error {
 	ctx.Response().Header.Set("Content-Type", "application/json")
 	ctx.Status(200)
 
 	return ctx.JSON(&response)
 }
 
-type CreateSubscription422JSONResponse Error
-
-func (response CreateSubscription422JSONResponse) VisitCreateSubscriptionResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(422)
-
-	return ctx.JSON(&response)
-}
-
-type CreateSubscriptiondefaultJSONResponse struct {
-	Body       Error
+type UserSummarydefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
 	StatusCode int
 }
 
-func (response CreateSubscriptiondefaultJSONResponse) VisitCreateSubscriptionResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
+func (response UserSummarydefaultApplicationProblemPlusJSONResponse) VisitUserSummaryResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
 	ctx.Status(response.StatusCode)
 
 	return ctx.JSON(&response.Body)
 }
 
-type DeleteSubscriptionRequestObject struct {
-	SubscriptionId UUID `json:"subscription_id"`
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Пересекающиеся подписки на один сервис по всем пользователям
+	// (GET /insights/duplicates)
+	ListDuplicates(ctx context.Context, request ListDuplicatesRequestObject) (ListDuplicatesResponseObject, error)
+	// Получение каталога сервисов
+	// (GET /services)
+	ListServices(ctx context.Context, request ListServicesRequestObject) (ListServicesResponseObject, error)
+	// Добавление сервиса в каталог
+	// (POST /services)
+	CreateService(ctx context.Context, request CreateServiceRequestObject) (CreateServiceResponseObject, error)
+	// Удаление сервиса из каталога
+	// (DELETE /services/{service_id})
+	DeleteService(ctx context.Context, request DeleteServiceRequestObject) (DeleteServiceResponseObject, error)
+	// Получить сервис по идентификатору
+	// (GET /services/{service_id})
+	ReadService(ctx context.Context, request ReadServiceRequestObject) (ReadServiceResponseObject, error)
+	// Изменение сервиса
+	// (PATCH /services/{service_id})
+	UpdateService(ctx context.Context, request UpdateServiceRequestObject) (UpdateServiceResponseObject, error)
+	// Статистика по всем подпискам за период (суммарная стоимость)
+	// (GET /stats)
+	StatsSubscriptions(ctx context.Context, request StatsSubscriptionsRequestObject) (StatsSubscriptionsResponseObject, error)
+	// Счетчики попаданий и промахов кэша статистики
+	// (GET /stats/cache)
+	StatsCache(ctx context.Context, request StatsCacheRequestObject) (StatsCacheResponseObject, error)
+	// Показатели оттока и удержания по сервисам за период
+	// (GET /stats/churn)
+	ChurnSubscriptions(ctx context.Context, request ChurnSubscriptionsRequestObject) (ChurnSubscriptionsResponseObject, error)
+	// Когортный анализ удержания подписок по месяцу начала
+	// (GET /stats/cohorts)
+	CohortSubscriptions(ctx context.Context, request CohortSubscriptionsRequestObject) (CohortSubscriptionsResponseObject, error)
+	// Прогноз расходов на подписки по месяцам
+	// (GET /stats/forecast)
+	ForecastSubscriptions(ctx context.Context, request ForecastSubscriptionsRequestObject) (ForecastSubscriptionsResponseObject, error)
+	// Рейтинг групп подписок по выбранному показателю за период
+	// (GET /stats/top)
+	TopSubscriptions(ctx context.Context, request TopSubscriptionsRequestObject) (TopSubscriptionsResponseObject, error)
+	// Получение списка подписок
+	// (GET /subscriptions)
+	ListSubscriptions(ctx context.Context, request ListSubscriptionsRequestObject) (ListSubscriptionsResponseObject, error)
+	// Создание подписки
+	// (POST /subscriptions)
+	CreateSubscription(ctx context.Context, request CreateSubscriptionRequestObject) (CreateSubscriptionResponseObject, error)
+	// Пакетное создание, изменение и удаление подписок
+	// (POST /subscriptions/batch)
+	BatchSubscriptions(ctx context.Context, request BatchSubscriptionsRequestObject) (BatchSubscriptionsResponseObject, error)
+	// Поток изменений подписок
+	// (GET /subscriptions/events)
+	StreamSubscriptionEvents(ctx context.Context, request StreamSubscriptionEventsRequestObject) (StreamSubscriptionEventsResponseObject, error)
+	// Удаление подписки по идентификатору
+	// (DELETE /subscriptions/{subscription_id})
+	DeleteSubscription(ctx context.Context, request DeleteSubscriptionRequestObject) (DeleteSubscriptionResponseObject, error)
+	// Получить подписку по идентификатору
+	// (GET /subscriptions/{subscription_id})
+	ReadSubscription(ctx context.Context, request ReadSubscriptionRequestObject) (ReadSubscriptionResponseObject, error)
+	// Изменение подписки
+	// (PATCH /subscriptions/{subscription_id})
+	UpdateSubscription(ctx context.Context, request UpdateSubscriptionRequestObject) (UpdateSubscriptionResponseObject, error)
+	// Замена подписки
+	// (PUT /subscriptions/{subscription_id})
+	ReplaceSubscription(ctx context.Context, request ReplaceSubscriptionRequestObject) (ReplaceSubscriptionResponseObject, error)
+	// Приостановка оплаты подписки
+	// (POST /subscriptions/{subscription_id}/pause)
+	PauseSubscription(ctx context.Context, request PauseSubscriptionRequestObject) (PauseSubscriptionResponseObject, error)
+	// Возобновление оплаты подписки
+	// (POST /subscriptions/{subscription_id}/resume)
+	ResumeSubscription(ctx context.Context, request ResumeSubscriptionRequestObject) (ResumeSubscriptionResponseObject, error)
+	// Пересекающиеся подписки пользователя на один сервис
+	// (GET /users/{user_id}/insights/duplicates)
+	UserDuplicates(ctx context.Context, request UserDuplicatesRequestObject) (UserDuplicatesResponseObject, error)
+	// Напоминания о списаниях по подпискам пользователя за месяц
+	// (GET /users/{user_id}/reminders)
+	UserReminders(ctx context.Context, request UserRemindersRequestObject) (UserRemindersResponseObject, error)
+	// Сводка расходов пользователя
+	// (GET /users/{user_id}/summary)
+	UserSummary(ctx context.Context, request UserSummaryRequestObject) (UserSummaryResponseObject, error)
 }
 
-type DeleteSubscriptionResponseObject interface {
-	VisitDeleteSubscriptionResponse(ctx *fiber.Ctx) error
+type StrictHandlerFunc func(ctx *fiber.Ctx, args interface{}) (interface{}, error)
+
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
 }
 
-type DeleteSubscription204Response struct {
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
 }
 
-func (response DeleteSubscription204Response) VisitDeleteSubscriptionResponse(ctx *fiber.Ctx) error {
-	ctx.Status(204)
+// ListDuplicates operation middleware
+func (sh *strictHandler) ListDuplicates(ctx *fiber.Ctx, params ListDuplicatesParams) error {
+	var request ListDuplicatesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListDuplicates(ctx.UserContext(), request.(ListDuplicatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListDuplicates")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(ListDuplicatesResponseObject); ok {
+		if err := validResponse.VisitListDuplicatesResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
 	return nil
 }
 
-type DeleteSubscription404Response struct {
-}
+// ListServices operation middleware
+func (sh *strictHandler) ListServices(ctx *fiber.Ctx, params ListServicesParams) error {
+	var request ListServicesRequestObject
 
-func (response DeleteSubscription404Response) VisitDeleteSubscriptionResponse(ctx *fiber.Ctx) error {
-	ctx.Status(404)
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListServices(ctx.UserContext(), request.(ListServicesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListServices")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(ListServicesResponseObject); ok {
+		if err := validResponse.VisitListServicesResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
 	return nil
 }
 
-type DeleteSubscriptiondefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
-}
+// CreateService operation middleware
+func (sh *strictHandler) CreateService(ctx *fiber.Ctx) error {
+	var request CreateServiceRequestObject
 
-func (response DeleteSubscriptiondefaultJSONResponse) VisitDeleteSubscriptionResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(response.StatusCode)
+	var body CreateServiceJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
 
-	return ctx.JSON(&response.Body)
-}
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateService(ctx.UserContext(), request.(CreateServiceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateService")
+	}
 
-type ReadSubscriptionRequestObject struct {
-	SubscriptionId UUID `json:"subscription_id"`
-}
+	response, err := handler(ctx, request)
 
-type ReadSubscriptionResponseObject interface {
-	VisitReadSubscriptionResponse(ctx *fiber.Ctx) error
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(CreateServiceResponseObject); ok {
+		if err := validResponse.VisitCreateServiceResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type ReadSubscription200JSONResponse Subscription
+// DeleteService operation middleware
+func (sh *strictHandler) DeleteService(ctx *fiber.Ctx, serviceId UUID) error {
+	var request DeleteServiceRequestObject
 
-func (response ReadSubscription200JSONResponse) VisitReadSubscriptionResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	request.ServiceId = serviceId
 
-	return ctx.JSON(&response)
-}
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteService(ctx.UserContext(), request.(DeleteServiceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteService")
+	}
 
-type ReadSubscription404Response struct {
-}
+	response, err := handler(ctx, request)
 
-func (response ReadSubscription404Response) VisitReadSubscriptionResponse(ctx *fiber.Ctx) error {
-	ctx.Status(404)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(DeleteServiceResponseObject); ok {
+		if err := validResponse.VisitDeleteServiceResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
 	return nil
 }
 
-type ReadSubscriptiondefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
-}
+// ReadService operation middleware
+func (sh *strictHandler) ReadService(ctx *fiber.Ctx, serviceId UUID) error {
+	var request ReadServiceRequestObject
 
-func (response ReadSubscriptiondefaultJSONResponse) VisitReadSubscriptionResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(response.StatusCode)
+	request.ServiceId = serviceId
 
-	return ctx.JSON(&response.Body)
-}
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadService(ctx.UserContext(), request.(ReadServiceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadService")
+	}
 
-type UpdateSubscriptionRequestObject struct {
-	SubscriptionId UUID `json:"subscription_id"`
-	Body           *UpdateSubscriptionJSONRequestBody
-}
+	response, err := handler(ctx, request)
 
-type UpdateSubscriptionResponseObject interface {
-	VisitUpdateSubscriptionResponse(ctx *fiber.Ctx) error
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(ReadServiceResponseObject); ok {
+		if err := validResponse.VisitReadServiceResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type UpdateSubscription204Response struct {
-}
+// UpdateService operation middleware
+func (sh *strictHandler) UpdateService(ctx *fiber.Ctx, serviceId UUID) error {
+	var request UpdateServiceRequestObject
 
-func (response UpdateSubscription204Response) VisitUpdateSubscriptionResponse(ctx *fiber.Ctx) error {
-	ctx.Status(204)
+	request.ServiceId = serviceId
+
+	var body UpdateServiceJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateService(ctx.UserContext(), request.(UpdateServiceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateService")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(UpdateServiceResponseObject); ok {
+		if err := validResponse.VisitUpdateServiceResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
 	return nil
 }
 
-type UpdateSubscription404Response struct {
+// StatsSubscriptions operation middleware
+func (sh *strictHandler) StatsSubscriptions(ctx *fiber.Ctx, params StatsSubscriptionsParams) error {
+	var request StatsSubscriptionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.StatsSubscriptions(ctx.UserContext(), request.(StatsSubscriptionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StatsSubscriptions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(StatsSubscriptionsResponseObject); ok {
+		if err := validResponse.VisitStatsSubscriptionsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-func (response UpdateSubscription404Response) VisitUpdateSubscriptionResponse(ctx *fiber.Ctx) error {
-	ctx.Status(404)
+// StatsCache operation middleware
+func (sh *strictHandler) StatsCache(ctx *fiber.Ctx) error {
+	var request StatsCacheRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.StatsCache(ctx.UserContext(), request.(StatsCacheRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StatsCache")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(StatsCacheResponseObject); ok {
+		if err := validResponse.VisitStatsCacheResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
 	return nil
 }
 
-type UpdateSubscription422JSONResponse Error
+// ChurnSubscriptions operation middleware
+func (sh *strictHandler) ChurnSubscriptions(ctx *fiber.Ctx, params ChurnSubscriptionsParams) error {
+	var request ChurnSubscriptionsRequestObject
 
-func (response UpdateSubscription422JSONResponse) VisitUpdateSubscriptionResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(422)
+	request.Params = params
 
-	return ctx.JSON(&response)
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ChurnSubscriptions(ctx.UserContext(), request.(ChurnSubscriptionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ChurnSubscriptions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(ChurnSubscriptionsResponseObject); ok {
+		if err := validResponse.VisitChurnSubscriptionsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type UpdateSubscriptiondefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
-}
+// CohortSubscriptions operation middleware
+func (sh *strictHandler) CohortSubscriptions(ctx *fiber.Ctx, params CohortSubscriptionsParams) error {
+	var request CohortSubscriptionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.CohortSubscriptions(ctx.UserContext(), request.(CohortSubscriptionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CohortSubscriptions")
+	}
 
-func (response UpdateSubscriptiondefaultJSONResponse) VisitUpdateSubscriptionResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(response.StatusCode)
+	response, err := handler(ctx, request)
 
-	return ctx.JSON(&response.Body)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(CohortSubscriptionsResponseObject); ok {
+		if err := validResponse.VisitCohortSubscriptionsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Статистика по всем подпискам за период (суммарная стоимость)
-	// (GET /stats)
-	StatsSubscriptions(ctx context.Context, request StatsSubscriptionsRequestObject) (StatsSubscriptionsResponseObject, error)
-	// Получение списка подписок
-	// (GET /subscriptions)
-	ListSubscriptions(ctx context.Context, request ListSubscriptionsRequestObject) (ListSubscriptionsResponseObject, error)
-	// Создание подписки
-	// (POST /subscriptions)
-	CreateSubscription(ctx context.Context, request CreateSubscriptionRequestObject) (CreateSubscriptionResponseObject, error)
-	// Удаление подписки по идентификатору
-	// (DELETE /subscriptions/{subscription_id})
-	DeleteSubscription(ctx context.Context, request DeleteSubscriptionRequestObject) (DeleteSubscriptionResponseObject, error)
-	// Получить подписку по идентификатору
-	// (GET /subscriptions/{subscription_id})
-	ReadSubscription(ctx context.Context, request ReadSubscriptionRequestObject) (ReadSubscriptionResponseObject, error)
-	// Изменение подписки
-	// (PATCH /subscriptions/{subscription_id})
-	UpdateSubscription(ctx context.Context, request UpdateSubscriptionRequestObject) (UpdateSubscriptionResponseObject, error)
-}
+// ForecastSubscriptions operation middleware
+func (sh *strictHandler) ForecastSubscriptions(ctx *fiber.Ctx, params ForecastSubscriptionsParams) error {
+	var request ForecastSubscriptionsRequestObject
 
-type StrictHandlerFunc func(ctx *fiber.Ctx, args interface{}) (interface{}, error)
+	request.Params = params
 
-type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ForecastSubscriptions(ctx.UserContext(), request.(ForecastSubscriptionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ForecastSubscriptions")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
-}
+	response, err := handler(ctx, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(ForecastSubscriptionsResponseObject); ok {
+		if err := validResponse.VisitForecastSubscriptionsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-// StatsSubscriptions operation middleware
-func (sh *strictHandler) StatsSubscriptions(ctx *fiber.Ctx, params StatsSubscriptionsParams) error {
-	var request StatsSubscriptionsRequestObject
+// TopSubscriptions operation middleware
+func (sh *strictHandler) TopSubscriptions(ctx *fiber.Ctx, params TopSubscriptionsParams) error {
+	var request TopSubscriptionsRequestObject
 
 	request.Params = params
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.StatsSubscriptions(ctx.UserContext(), request.(StatsSubscriptionsRequestObject))
+		return sh.ssi.TopSubscriptions(ctx.UserContext(), request.(TopSubscriptionsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "StatsSubscriptions")
+		handler = middleware(handler, "TopSubscriptions")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(StatsSubscriptionsResponseObject); ok {
-		if err := validResponse.VisitStatsSubscriptionsResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(TopSubscriptionsResponseObject); ok {
+		if err := validResponse.VisitTopSubscriptionsResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -635,9 +3083,11 @@ func (sh *strictHandler) ListSubscriptions(ctx *fiber.Ctx, params ListSubscripti
 }
 
 // CreateSubscription operation middleware
-func (sh *strictHandler) CreateSubscription(ctx *fiber.Ctx) error {
+func (sh *strictHandler) CreateSubscription(ctx *fiber.Ctx, params CreateSubscriptionParams) error {
 	var request CreateSubscriptionRequestObject
 
+	request.Params = params
+
 	var body CreateSubscriptionJSONRequestBody
 	if err := ctx.BodyParser(&body); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
@@ -665,6 +3115,64 @@ func (sh *strictHandler) CreateSubscription(ctx *fiber.Ctx) error {
 	return nil
 }
 
+// BatchSubscriptions operation middleware
+func (sh *strictHandler) BatchSubscriptions(ctx *fiber.Ctx) error {
+	var request BatchSubscriptionsRequestObject
+
+	var body BatchSubscriptionsJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.BatchSubscriptions(ctx.UserContext(), request.(BatchSubscriptionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BatchSubscriptions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(BatchSubscriptionsResponseObject); ok {
+		if err := validResponse.VisitBatchSubscriptionsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// StreamSubscriptionEvents operation middleware
+func (sh *strictHandler) StreamSubscriptionEvents(ctx *fiber.Ctx, params StreamSubscriptionEventsParams) error {
+	var request StreamSubscriptionEventsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.StreamSubscriptionEvents(ctx.UserContext(), request.(StreamSubscriptionEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StreamSubscriptionEvents")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(StreamSubscriptionEventsResponseObject); ok {
+		if err := validResponse.VisitStreamSubscriptionEventsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // DeleteSubscription operation middleware
 func (sh *strictHandler) DeleteSubscription(ctx *fiber.Ctx, subscriptionId UUID) error {
 	var request DeleteSubscriptionRequestObject
@@ -724,12 +3232,22 @@ func (sh *strictHandler) UpdateSubscription(ctx *fiber.Ctx, subscriptionId UUID)
 	var request UpdateSubscriptionRequestObject
 
 	request.SubscriptionId = subscriptionId
+	if strings.HasPrefix(string(ctx.Request().Header.ContentType()), "application/json") {
 
-	var body UpdateSubscriptionJSONRequestBody
-	if err := ctx.BodyParser(&body); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		var body UpdateSubscriptionJSONRequestBody
+		if err := ctx.BodyParser(&body); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		request.JSONBody = &body
+	}
+	if strings.HasPrefix(string(ctx.Request().Header.ContentType()), "application/merge-patch+json") {
+
+		var body UpdateSubscriptionApplicationMergePatchPlusJSONRequestBody
+		if err := ctx.BodyParser(&body); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		request.ApplicationMergePatchPlusJSONBody = &body
 	}
-	request.Body = &body
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
 		return sh.ssi.UpdateSubscription(ctx.UserContext(), request.(UpdateSubscriptionRequestObject))
@@ -751,3 +3269,184 @@ func (sh *strictHandler) UpdateSubscription(ctx *fiber.Ctx, subscriptionId UUID)
 	}
 	return nil
 }
+
+// ReplaceSubscription operation middleware
+func (sh *strictHandler) ReplaceSubscription(ctx *fiber.Ctx, subscriptionId UUID) error {
+	var request ReplaceSubscriptionRequestObject
+
+	request.SubscriptionId = subscriptionId
+
+	var body ReplaceSubscriptionJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceSubscription(ctx.UserContext(), request.(ReplaceSubscriptionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceSubscription")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(ReplaceSubscriptionResponseObject); ok {
+		if err := validResponse.VisitReplaceSubscriptionResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PauseSubscription operation middleware
+func (sh *strictHandler) PauseSubscription(ctx *fiber.Ctx, subscriptionId UUID) error {
+	var request PauseSubscriptionRequestObject
+
+	request.SubscriptionId = subscriptionId
+
+	var body PauseSubscriptionJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.PauseSubscription(ctx.UserContext(), request.(PauseSubscriptionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PauseSubscription")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(PauseSubscriptionResponseObject); ok {
+		if err := validResponse.VisitPauseSubscriptionResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ResumeSubscription operation middleware
+func (sh *strictHandler) ResumeSubscription(ctx *fiber.Ctx, subscriptionId UUID) error {
+	var request ResumeSubscriptionRequestObject
+
+	request.SubscriptionId = subscriptionId
+
+	var body ResumeSubscriptionJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ResumeSubscription(ctx.UserContext(), request.(ResumeSubscriptionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResumeSubscription")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(ResumeSubscriptionResponseObject); ok {
+		if err := validResponse.VisitResumeSubscriptionResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UserDuplicates operation middleware
+func (sh *strictHandler) UserDuplicates(ctx *fiber.Ctx, userId UUID) error {
+	var request UserDuplicatesRequestObject
+
+	request.UserId = userId
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UserDuplicates(ctx.UserContext(), request.(UserDuplicatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UserDuplicates")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(UserDuplicatesResponseObject); ok {
+		if err := validResponse.VisitUserDuplicatesResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UserReminders operation middleware
+func (sh *strictHandler) UserReminders(ctx *fiber.Ctx, userId UUID, params UserRemindersParams) error {
+	var request UserRemindersRequestObject
+
+	request.UserId = userId
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UserReminders(ctx.UserContext(), request.(UserRemindersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UserReminders")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(UserRemindersResponseObject); ok {
+		if err := validResponse.VisitUserRemindersResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UserSummary operation middleware
+func (sh *strictHandler) UserSummary(ctx *fiber.Ctx, userId UUID) error {
+	var request UserSummaryRequestObject
+
+	request.UserId = userId
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UserSummary(ctx.UserContext(), request.(UserSummaryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UserSummary")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(UserSummaryResponseObject); ok {
+		if err := validResponse.VisitUserSummaryResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
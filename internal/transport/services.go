@@ -0,0 +1,219 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"ew/internal/database"
+	"ew/internal/logging"
+	"ew/internal/models/services"
+	"net/http"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+func registerServiceRules(validate *validator.Validate) {
+	listRules := map[string]string{
+		"Offset": "omitempty,min=0",
+		"Limit":  "omitempty,min=1",
+	}
+	validate.RegisterStructValidationMapRules(listRules, ListServicesParams{})
+
+	createRules := map[string]string{
+		"Name":         "required",
+		"Aliases":      "omitempty,dive,required",
+		"DefaultPrice": "omitempty,min=1",
+		"LogoUrl":      "omitempty,url",
+	}
+	validate.RegisterStructValidationMapRules(createRules, CreateServiceJSONRequestBody{})
+
+	updateRules := map[string]string{
+		"Name":         "omitempty,min=1",
+		"Aliases":      "omitempty,dive,required",
+		"DefaultPrice": "omitempty,min=1",
+		"LogoUrl":      "omitempty,url",
+	}
+	validate.RegisterStructValidationMapRules(updateRules, UpdateServiceJSONRequestBody{})
+}
+
+// findService looks up the catalog entry of a subscription by id, or by name and aliases.
+// An unknown name gives no service and no error, the storage adds it to the catalog along with the subscription.
+func (s Server) findService(ctx context.Context, id *uuid.UUID, name string) (*services.Service, error) {
+	if id != nil {
+		return s.Services.GetByID(ctx, *id)
+	}
+
+	service, err := s.Services.FindByName(ctx, name)
+	if errors.Is(err, services.NotFound) {
+		return nil, nil
+	}
+	return service, err
+}
+
+// serviceNames resolves the service_name filters like the writes resolve the names, by name and aliases,
+// to the names the subscriptions are stored with. Unknown names are kept as given.
+func (s Server) serviceNames(ctx context.Context, names []string) ([]string, error) {
+	res := make([]string, 0, len(names))
+	for _, name := range names {
+		service, err := s.findService(ctx, nil, name)
+		if err != nil {
+			return nil, err
+		}
+		if service != nil {
+			name = service.Name
+		}
+		if !slices.Contains(res, name) {
+			res = append(res, name)
+		}
+	}
+	return res, nil
+}
+
+func convertServiceToResponse(item *services.Service) Service {
+	aliases := []string(item.Aliases)
+
+	var price *int
+	if item.DefaultPrice != nil {
+		p := int(*item.DefaultPrice)
+		price = &p
+	}
+
+	return Service{
+		ServiceId:    &item.ID,
+		Name:         item.Name,
+		Aliases:      &aliases,
+		Category:     item.Category,
+		DefaultPrice: price,
+		LogoUrl:      item.LogoUrl,
+	}
+}
+
+func (s Server) ListServices(ctx context.Context, request ListServicesRequestObject) (ListServicesResponseObject, error) {
+	err := s.validate(ctx, request.Params)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("ListServices validation failed")
+		return ListServicesdefaultApplicationProblemPlusJSONResponse{Body: s.validationProblem(ctx, err), StatusCode: 422}, nil
+	}
+
+	items, err := s.Services.GetList(ctx, services.ServiceListParams{
+		Category: request.Params.Category,
+		Offset:   request.Params.Offset,
+		Limit:    request.Params.Limit,
+	})
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("ListServices failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("received services list")
+
+	res := make([]Service, 0, len(items))
+	for _, item := range items {
+		res = append(res, convertServiceToResponse(item))
+	}
+
+	return ListServices200JSONResponse(res), nil
+}
+
+func (s Server) ReadService(ctx context.Context, request ReadServiceRequestObject) (ReadServiceResponseObject, error) {
+	item, err := s.Services.GetByID(ctx, request.ServiceId)
+	if errors.Is(err, services.NotFound) {
+		return ReadService404ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusNotFound, problemGeneric, "serviceNotFound")), nil
+	}
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("ReadService failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("received service")
+
+	return ReadService200JSONResponse(convertServiceToResponse(item)), nil
+}
+
+func (s Server) CreateService(ctx context.Context, request CreateServiceRequestObject) (CreateServiceResponseObject, error) {
+	err := s.validate(ctx, request.Body)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("CreateService validation failed")
+		return CreateService422ApplicationProblemPlusJSONResponse(s.validationProblem(ctx, err)), nil
+	}
+
+	item := &services.Service{
+		Name:     request.Body.Name,
+		Category: request.Body.Category,
+		LogoUrl:  request.Body.LogoUrl,
+	}
+
+	if request.Body.Aliases != nil {
+		item.Aliases = database.TextArray(*request.Body.Aliases)
+	}
+
+	if request.Body.DefaultPrice != nil {
+		price := uint(*request.Body.DefaultPrice)
+		item.DefaultPrice = &price
+	}
+
+	id, err := s.Services.Add(ctx, item)
+	if errors.Is(err, services.NameTaken) {
+		return CreateService409ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusConflict, problemConflict, "serviceNameTaken")), nil
+	}
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("CreateService failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("created service")
+
+	return CreateService200JSONResponse{&id}, nil
+}
+
+func (s Server) UpdateService(ctx context.Context, request UpdateServiceRequestObject) (UpdateServiceResponseObject, error) {
+	err := s.validate(ctx, request.Body)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("UpdateService validation failed")
+		return UpdateService422ApplicationProblemPlusJSONResponse(s.validationProblem(ctx, err)), nil
+	}
+
+	item := &services.ServicePatch{
+		ID:       request.ServiceId,
+		Name:     request.Body.Name,
+		Category: request.Body.Category,
+		LogoUrl:  request.Body.LogoUrl,
+	}
+
+	if request.Body.Aliases != nil {
+		aliases := database.TextArray(*request.Body.Aliases)
+		item.Aliases = &aliases
+	}
+
+	if request.Body.DefaultPrice != nil {
+		price := uint(*request.Body.DefaultPrice)
+		item.DefaultPrice = &price
+	}
+
+	updated, err := s.Services.Update(ctx, item)
+	if errors.Is(err, services.NameTaken) {
+		return UpdateService409ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusConflict, problemConflict, "serviceNameTaken")), nil
+	}
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("UpdateService failed")
+		return nil, InternalError
+	}
+	if updated == 0 {
+		return UpdateService404ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusNotFound, problemGeneric, "serviceNotFound")), nil
+	}
+	logging.FromContext(ctx).Info("updated service")
+
+	return UpdateService204Response{}, nil
+}
+
+func (s Server) DeleteService(ctx context.Context, request DeleteServiceRequestObject) (DeleteServiceResponseObject, error) {
+	deleted, err := s.Services.Delete(ctx, request.ServiceId)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("DeleteService failed")
+		return nil, InternalError
+	}
+	if deleted == 0 {
+		return DeleteService404ApplicationProblemPlusJSONResponse(s.problemFor(ctx, http.StatusNotFound, problemGeneric, "serviceNotFound")), nil
+	}
+	logging.FromContext(ctx).Info("deleted service")
+
+	return DeleteService204Response{}, nil
+}
@@ -0,0 +1,286 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"ew/internal/models/subscriptions"
+	"io"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestImplServiceCreate(t *testing.T) {
+	webApp := prepareServ()
+
+	aliases := []string{"yandex plus", "Яндекс Плюс"}
+	category := "video"
+	item := ServiceCreate{Name: "Yandex Plus", Aliases: &aliases, Category: &category}
+
+	jsonStr, _ := json.Marshal(item)
+
+	req := httptest.NewRequest("POST", "/services", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 200)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	respJson := struct {
+		ServiceId string `json:"service_id"`
+	}{}
+	err := json.Unmarshal(body, &respJson)
+	if err != nil {
+		t.Errorf("invalid response body: %s", err.Error())
+	}
+
+	req = httptest.NewRequest("GET", "/services/"+respJson.ServiceId, nil)
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d", resp.StatusCode)
+	}
+	body, _ = io.ReadAll(resp.Body)
+
+	if !bytes.Contains(body, []byte("Яндекс Плюс")) {
+		t.Errorf("no aliases found, response body: %s", string(body))
+	}
+
+	jsonStr, _ = json.Marshal(ServiceCreate{Name: "YANDEX PLUS"})
+
+	req = httptest.NewRequest("POST", "/services", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 409 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 409)
+	}
+}
+
+func TestImplServiceNormalization(t *testing.T) {
+	webApp := prepareServ()
+
+	aliases := []string{"Яндекс Плюс"}
+	jsonStr, _ := json.Marshal(ServiceCreate{Name: "Yandex Plus", Aliases: &aliases})
+
+	req := httptest.NewRequest("POST", "/services", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 200)
+	}
+
+	start := time.Now().AddDate(0, -3, 0).Format("01-2006")
+	for _, name := range []string{"Yandex Plus", "yandex plus", "Яндекс Плюс", "Netflix"} {
+		jsonStr, _ = json.Marshal(Subscription{Price: 100, StartDate: start, UserId: uuid.New(), ServiceName: name})
+
+		req = httptest.NewRequest("POST", "/subscriptions", bytes.NewBuffer(jsonStr))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, _ = webApp.Test(req)
+		if resp.StatusCode != 200 {
+			t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 200)
+		}
+	}
+
+	canonical := slices.DeleteFunc(slices.Clone(repo.Items), func(item *subscriptions.Subscription) bool {
+		return item.ServiceName != "Yandex Plus"
+	})
+	if len(canonical) != 3 {
+		t.Errorf("names not normalized, got %d canonical items", len(canonical))
+	}
+
+	if len(serviceRepo.Items) != 2 {
+		t.Errorf("unknown service not added to catalog, got %d services", len(serviceRepo.Items))
+	}
+
+	req = httptest.NewRequest("GET", "/stats?group_by=service&start_date="+time.Now().Format("01-2006"), nil)
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d", resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+
+	stats := StatsSubscriptions200JSONResponse{}
+	err := json.Unmarshal(body, &stats)
+	if err != nil {
+		t.Errorf("invalid response body: %s", err.Error())
+	}
+
+	if stats.Groups == nil || len(*stats.Groups) != 5 {
+		t.Fatalf("incorrect groups, response body: %s", string(body))
+	}
+
+	group := (*stats.Groups)[1]
+	if group.Name != "Yandex Plus" || group.TotalPrice != 300 || *stats.TotalPrice != 1275 {
+		t.Errorf("incorrect grouping, response body: %s", string(body))
+	}
+
+	req = httptest.NewRequest("GET", "/stats?group_by=user", nil)
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 422 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 422)
+	}
+}
+
+func TestImplServiceUpdateDelete(t *testing.T) {
+	webApp := prepareServ()
+
+	jsonStr, _ := json.Marshal(Subscription{Price: 100, StartDate: "01-2025", UserId: uuid.New(), ServiceName: "Spotify"})
+
+	req := httptest.NewRequest("POST", "/subscriptions", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 200)
+	}
+
+	service := serviceRepo.Items[0]
+	subscription := repo.Items[len(repo.Items)-1]
+
+	name := "Spotify Premium"
+	jsonStr, _ = json.Marshal(ServicePatch{Name: &name})
+
+	req = httptest.NewRequest("PATCH", "/services/"+service.ID.String(), bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 204 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 204)
+	}
+
+	if subscription.ServiceName != name {
+		t.Errorf("subscription not renamed: %s", subscription.ServiceName)
+	}
+
+	req = httptest.NewRequest("DELETE", "/services/"+service.ID.String(), nil)
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 204 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 204)
+	}
+
+	if subscription.ServiceId != nil {
+		t.Errorf("subscription not unlinked")
+	}
+
+	req = httptest.NewRequest("GET", "/services/"+service.ID.String(), nil)
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 404 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 404)
+	}
+
+	unknown := uuid.New()
+	jsonStr, _ = json.Marshal(SubscriptionPatch{ServiceId: &unknown})
+
+	req = httptest.NewRequest("PATCH", "/subscriptions/"+subscription.ID.String(), bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 422 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 422)
+	}
+}
+
+func TestImplServiceCatalogWrites(t *testing.T) {
+	webApp := prepareServ()
+
+	// a rejected subscription adds nothing to the catalog
+	end := "01-2024"
+	jsonStr, _ := json.Marshal(Subscription{Price: 100, StartDate: "01-2025", EndDate: &end, UserId: uuid.New(), ServiceName: "Kinopoisk"})
+
+	req := httptest.NewRequest("POST", "/subscriptions", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 422 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 422)
+	}
+
+	created := map[string]any{"service_name": "Kinopoisk", "price": 100, "user_id": uuid.New(), "start_date": "01-2025"}
+	status, res := sendBatch(t, webApp, map[string]any{"operations": []map[string]any{
+		{"op": "create", "subscription": created},
+		{"op": "delete", "subscription_id": uuid.New()},
+	}})
+	if status != 200 || !slices.Equal(statuses(res), []BatchResultStatus{RolledBack, Failed}) {
+		t.Errorf("invalid atomic results: %d %v", status, statuses(res))
+	}
+	if len(serviceRepo.Items) != 0 {
+		t.Errorf("service added by a rejected write: %v", serviceRepo.Items[0])
+	}
+
+	// a name without a price leaves the default price unknown
+	name := "Kinopoisk"
+	jsonStr, _ = json.Marshal(SubscriptionPatch{ServiceName: &name})
+
+	req = httptest.NewRequest("PATCH", "/subscriptions/"+id1.String(), bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 204 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 204)
+	}
+	if len(serviceRepo.Items) != 1 || serviceRepo.Items[0].DefaultPrice != nil {
+		t.Errorf("unexpected catalog: %v", serviceRepo.Items)
+	}
+
+	aliases := []string{" kinopoisk"}
+	jsonStr, _ = json.Marshal(ServiceCreate{Name: "Yandex Plus", Aliases: &aliases})
+
+	req = httptest.NewRequest("POST", "/services", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _ = webApp.Test(req)
+	if resp.StatusCode != 409 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 409)
+	}
+}
+
+func TestImplServiceNameFilters(t *testing.T) {
+	webApp := prepareServ()
+
+	aliases := []string{"item 2"}
+	jsonStr, _ := json.Marshal(ServiceCreate{Name: "Item Two", Aliases: &aliases})
+	req := httptest.NewRequest("POST", "/services", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+	if resp, _ := webApp.Test(req); resp.StatusCode != 200 {
+		t.Fatalf("invalid status code: %d, expected %d", resp.StatusCode, 200)
+	}
+
+	// an alias filters the subscriptions renamed to the name of the service
+	resp, _ := webApp.Test(httptest.NewRequest("GET", "/subscriptions?service_name=ITEM%202", nil))
+	var items []Subscription
+	_ = json.NewDecoder(resp.Body).Decode(&items)
+	if len(items) != 1 || *items[0].SubscriptionId != id2 || items[0].ServiceName != "Item Two" {
+		t.Errorf("unexpected subscriptions of an alias: %v", items)
+	}
+
+	var byAlias, byName StatsSubscriptions200JSONResponse
+	resp, _ = webApp.Test(httptest.NewRequest("GET", "/stats?service_name=item%202", nil))
+	_ = json.NewDecoder(resp.Body).Decode(&byAlias)
+	resp, _ = webApp.Test(httptest.NewRequest("GET", "/stats?service_name=Item%20Two", nil))
+	_ = json.NewDecoder(resp.Body).Decode(&byName)
+	if byAlias.TotalPrice == nil || *byAlias.TotalPrice == 0 || *byAlias.TotalPrice != *byName.TotalPrice {
+		t.Errorf("unexpected stats of an alias: %v, expected %v", byAlias.TotalPrice, byName.TotalPrice)
+	}
+
+	// a name is not cleared by a patch
+	for _, name := range []string{"", "  "} {
+		jsonStr, _ = json.Marshal(SubscriptionPatch{ServiceName: &name})
+		req = httptest.NewRequest("PATCH", "/subscriptions/"+id1.String(), bytes.NewBuffer(jsonStr))
+		req.Header.Set("Content-Type", "application/json")
+		if resp, _ := webApp.Test(req); resp.StatusCode != 422 {
+			t.Errorf("%q: invalid status code: %d, expected %d", name, resp.StatusCode, 422)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package transport
+
+import (
+	"context"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func registerTopRules(validate *validator.Validate) {
+	topRules := map[string]string{
+		"Dimension": "omitempty,oneof=service_name user_id category",
+		"Metric":    "omitempty,oneof=total run_rate count",
+		"Limit":     "omitempty,min=1,max=100",
+		"StartDate": "omitempty,dateFormat",
+		"EndDate":   "omitempty,dateFormat",
+	}
+	validate.RegisterStructValidationMapRules(topRules, TopSubscriptionsParams{})
+}
+
+func (s Server) TopSubscriptions(ctx context.Context, request TopSubscriptionsRequestObject) (TopSubscriptionsResponseObject, error) {
+	err := s.validate(ctx, request.Params)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("TopSubscriptions validation failed")
+		return TopSubscriptionsdefaultApplicationProblemPlusJSONResponse{Body: s.validationProblem(ctx, err), StatusCode: 422}, nil
+	}
+
+	params := subscriptions.TopParams{
+		Dimension: subscriptions.DimensionServiceName,
+		Metric:    subscriptions.MetricTotal,
+		Limit:     10,
+	}
+
+	if request.Params.Dimension != nil {
+		params.Dimension = subscriptions.Dimension(*request.Params.Dimension)
+	}
+
+	if request.Params.Metric != nil {
+		params.Metric = subscriptions.Metric(*request.Params.Metric)
+	}
+
+	if request.Params.Limit != nil {
+		params.Limit = *request.Params.Limit
+	}
+
+	if request.Params.StartDate != nil {
+		st, _ := time.Parse("01-2006", *request.Params.StartDate)
+		params.Filter.StartDate = &st
+	}
+
+	if request.Params.EndDate != nil {
+		en, _ := time.Parse("01-2006", *request.Params.EndDate)
+		params.Filter.EndDate = &en
+	}
+
+	if request.Params.UserId != nil {
+		params.Filter.UserIds = *request.Params.UserId
+	}
+
+	if request.Params.ServiceName != nil {
+		params.Filter.ServiceNames = *request.Params.ServiceName
+	}
+
+	if request.Params.Category != nil {
+		params.Filter.Categories = *request.Params.Category
+	}
+
+	if request.Params.Tag != nil {
+		params.Filter.Tags = normalizeTags(*request.Params.Tag)
+	}
+
+	top, err := s.Repo.GetTop(ctx, params)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("TopSubscriptions failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("received top")
+
+	res := TopSubscriptions200JSONResponse{Items: make([]TopItem, 0, len(top))}
+	for _, item := range top {
+		res.Items = append(res.Items, TopItem{Rank: item.Rank, Key: item.Key, Name: item.Name, Value: item.Value})
+	}
+
+	return res, nil
+}
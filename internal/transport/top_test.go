@@ -0,0 +1,41 @@
+package transport
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestImplTop(t *testing.T) {
+	webApp := prepareServ()
+
+	cases := []struct {
+		query    string
+		expected string
+	}{
+		{"", `{"items":[{"key":"some item","name":"some item","rank":1,"value":2250},{"key":"nothing","name":"nothing","rank":2,"value":1000},{"key":"item 2","name":"item 2","rank":3,"value":750}]}`},
+		{"?metric=run_rate&limit=2", `{"items":[{"key":"nothing","name":"nothing","rank":1,"value":500},{"key":"item 2","name":"item 2","rank":2,"value":250}]}`},
+		{"?dimension=category&metric=count", `{"items":[{"key":"","name":"","rank":1,"value":3}]}`},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest("GET", "/stats/top"+c.query, nil)
+
+		resp, _ := webApp.Test(req)
+		body, _ := io.ReadAll(resp.Body)
+
+		if !bytes.Equal(bytes.TrimSpace(body), []byte(c.expected)) {
+			t.Errorf("%s: incorrect top, response body: %s", c.query, string(body))
+		}
+	}
+
+	for _, query := range []string{"?limit=0", "?limit=101", "?metric=avg", "?dimension=price"} {
+		req := httptest.NewRequest("GET", "/stats/top"+query, nil)
+
+		resp, _ := webApp.Test(req)
+		if resp.StatusCode != 422 {
+			t.Errorf("%s: invalid status code: %d, expected %d", query, resp.StatusCode, 422)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const tracerName = "ew/internal/transport"
+
+// Tracing is a strict middleware wrapping each operation, from the parsed request to the response object, in a span.
+func Tracing(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+	return func(c *fiber.Ctx, args interface{}) (interface{}, error) {
+		ctx, span := otel.Tracer(tracerName).Start(c.UserContext(), operationID)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+
+		res, err := f(c, args)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return res, err
+	}
+}
+
+// validate runs the registered validation rules in a span of its own.
+func (s Server) validate(ctx context.Context, v any) error {
+	_, span := otel.Tracer(tracerName).Start(ctx, "validate")
+	defer span.End()
+
+	err := s.Validator.Struct(v)
+	if err != nil {
+		span.SetStatus(codes.Error, "validation failed")
+	}
+	return err
+}
@@ -0,0 +1,48 @@
+package transport
+
+import (
+	"ew/internal/tracing"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestImplTracing(t *testing.T) {
+	prepareServ()
+
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(tracing.NewProvider(sdktrace.WithSyncer(exporter)))
+
+	webApp := fiber.New()
+	webApp.Use(tracing.Middleware)
+	RegisterHandlers(webApp, NewStrictHandler(
+		NewServer(repo, serviceRepo, validator.New()),
+		[]StrictMiddlewareFunc{Tracing},
+	))
+
+	req := httptest.NewRequest("GET", "/stats?start_date=13-2025", nil)
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 422 {
+		t.Errorf("invalid status code: %d", resp.StatusCode)
+	}
+
+	spans := exporter.GetSpans()
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	if !slices.Equal(names, []string{"validate", "StatsSubscriptions", "GET /stats"}) {
+		t.Fatalf("not equal %v", names)
+	}
+
+	if spans[0].Parent.SpanID() != spans[1].SpanContext.SpanID() || spans[1].Parent.SpanID() != spans[2].SpanContext.SpanID() {
+		t.Errorf("spans are not nested")
+	}
+}
@@ -0,0 +1,221 @@
+package transport
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	ruTranslations "github.com/go-playground/validator/v10/translations/ru"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const DefaultLocale = "en"
+
+type locale struct {
+	translator  locales.Translator
+	validations func(*validator.Validate, ut.Translator) error
+	// rules holds the messages of the rules NewServer registers itself,
+	// the built-in rules come with the validator translations
+	rules map[string]string
+	// problems holds the titles and the details of the problems, they are no validation rules
+	problems map[string]string
+}
+
+var supportedLocales = []locale{
+	{
+		translator:  en.New(),
+		validations: enTranslations.RegisterDefaultTranslations,
+		rules: map[string]string{
+			"dateFormat":    "{0} must be a date in the MM-YYYY format",
+			"serviceExists": "{0} does not match any service in the catalog",
+			"maxPeriod":     "the period up to {0} must be shorter than {1} months",
+			"pauseDates":    "{0} overlaps the pause history of the subscription",
+			"notBlank":      "{0} must not be blank",
+		},
+		problems: map[string]string{
+			"status-404": "Not Found",
+			"status-409": "Conflict",
+			"status-422": "Unprocessable Entity",
+
+			"validationFailed":     "request validation failed",
+			"subscriptionNotFound": "subscription not found",
+			"serviceNotFound":      "service not found",
+			"subscriptionOverlaps": "subscription overlaps an existing subscription to the same service",
+			"alreadyPaused":        "subscription is already paused",
+			"notPaused":            "subscription is not paused",
+			"serviceNameTaken":     "service name already taken",
+
+			"idempotencyMismatch":   "Idempotency-Key was already used with a different request",
+			"idempotencyInProgress": "request with the same Idempotency-Key is still in progress, retry later",
+		},
+	},
+	{
+		translator:  ru.New(),
+		validations: ruTranslations.RegisterDefaultTranslations,
+		rules: map[string]string{
+			"dateFormat":    "{0} должен быть датой в формате ММ-ГГГГ",
+			"serviceExists": "{0} не соответствует ни одному сервису из каталога",
+			"maxPeriod":     "период до {0} должен быть короче {1} месяцев",
+			"pauseDates":    "{0} пересекается с историей приостановок подписки",
+			"notBlank":      "{0} не должен быть пустым",
+		},
+		problems: map[string]string{
+			"status-404": "Не найдено",
+			"status-409": "Конфликт",
+			"status-422": "Некорректный запрос",
+
+			"validationFailed":     "запрос не прошел проверку",
+			"subscriptionNotFound": "подписка не найдена",
+			"serviceNotFound":      "сервис не найден",
+			"subscriptionOverlaps": "подписка пересекается с существующей подпиской на тот же сервис",
+			"alreadyPaused":        "подписка уже приостановлена",
+			"notPaused":            "подписка не приостановлена",
+			"serviceNameTaken":     "название сервиса уже занято",
+
+			"idempotencyMismatch":   "Idempotency-Key уже использован с другим запросом",
+			"idempotencyInProgress": "запрос с тем же Idempotency-Key еще выполняется, повторите позже",
+		},
+	},
+}
+
+// newTranslations sets up the translator registry for every supported locale
+// and makes validation errors report the json names of the fields.
+func newTranslations(validate *validator.Validate) *ut.UniversalTranslator {
+	validate.RegisterTagNameFunc(jsonFieldName)
+
+	translators := make([]locales.Translator, 0, len(supportedLocales))
+	for _, loc := range supportedLocales {
+		translators = append(translators, loc.translator)
+	}
+	translations := ut.New(translators[0], translators...)
+
+	for _, loc := range supportedLocales {
+		trans, _ := translations.GetTranslator(loc.translator.Locale())
+		if err := loc.validations(validate, trans); err != nil {
+			logrus.WithError(err).Error("Failed to register validator translations")
+		}
+		registerCustomTranslations(validate, trans, loc.rules)
+	}
+
+	return translations
+}
+
+func registerCustomTranslations(validate *validator.Validate, trans ut.Translator, messages map[string]string) {
+	for key, message := range messages {
+		err := validate.RegisterTranslation(key, trans,
+			func(trans ut.Translator) error {
+				return trans.Add(key, message, true)
+			},
+			func(trans ut.Translator, fe validator.FieldError) string {
+				text, _ := trans.T(fe.Tag(), fe.Field(), fe.Param())
+				return text
+			},
+		)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to register validator translation")
+		}
+	}
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+type localeKey struct{}
+
+// Locale picks the best supported language of the Accept-Language header for the messages of the request,
+// regional variants such as ru-RU fall back to their base language.
+func Locale(c *fiber.Ctx) error {
+	c.SetUserContext(WithLocale(c.UserContext(), c.Get(fiber.HeaderAcceptLanguage)))
+	return c.Next()
+}
+
+// WithLocale is Locale for the requests that do not come through fiber, it takes the Accept-Language value.
+func WithLocale(ctx context.Context, acceptLanguage string) context.Context {
+	if lang := acceptedLocale(acceptLanguage); lang != "" {
+		return context.WithValue(ctx, localeKey{}, lang)
+	}
+	return ctx
+}
+
+func acceptedLocale(header string) string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+
+	var accepted []language
+	for _, item := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(item), ";")
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if q, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = q
+			}
+		}
+		if tag != "" && quality > 0 {
+			accepted = append(accepted, language{tag: strings.ToLower(strings.TrimSpace(tag)), quality: quality})
+		}
+	}
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+
+	for _, lang := range accepted {
+		base, _, _ := strings.Cut(lang.tag, "-")
+		for _, loc := range supportedLocales {
+			if base == loc.translator.Locale() {
+				return base
+			}
+		}
+	}
+	return ""
+}
+
+// locale returns the request locale, or the default one.
+func (s Server) locale(ctx context.Context) string {
+	if lang, ok := ctx.Value(localeKey{}).(string); ok {
+		if _, found := s.Translations.GetTranslator(lang); found {
+			return lang
+		}
+	}
+	return s.DefaultLocale
+}
+
+// translator returns the translator of the request locale, or of the default one.
+func (s Server) translator(ctx context.Context) ut.Translator {
+	trans, _ := s.Translations.GetTranslator(s.locale(ctx))
+	return trans
+}
+
+// message translates a problem text, falling back to the key.
+func (s Server) message(ctx context.Context, key string) string {
+	lang := s.locale(ctx)
+	for _, loc := range supportedLocales {
+		if loc.translator.Locale() != lang {
+			continue
+		}
+		if text, found := loc.problems[key]; found {
+			return text
+		}
+	}
+	return key
+}
@@ -0,0 +1,98 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+func TestImplTranslations(t *testing.T) {
+	webApp := prepareServ()
+
+	body, _ := json.Marshal(map[string]any{
+		"service_name": "Some item",
+		"price":        -1,
+		"user_id":      uuid.New(),
+		"start_date":   "13-2025",
+	})
+
+	cases := []struct {
+		method   string
+		path     string
+		language string
+		body     []byte
+		status   int
+		expected string
+	}{
+		{"POST", "/subscriptions", "ru-RU,ru;q=0.9,en;q=0.8", body, 422, `{"errors":[{"field":"price","message":"price должен быть больше или равно 1","rule":"min"},{"field":"start_date","message":"start_date должен быть датой в формате ММ-ГГГГ","rule":"dateFormat"}],"detail":"запрос не прошел проверку","status":422,"title":"Некорректный запрос","type":"/problems/validation-error"}`},
+		{"POST", "/subscriptions", "de, en;q=0.5", body, 422, `{"errors":[{"field":"price","message":"price must be 1 or greater","rule":"min"},{"field":"start_date","message":"start_date must be a date in the MM-YYYY format","rule":"dateFormat"}],"detail":"request validation failed","status":422,"title":"Unprocessable Entity","type":"/problems/validation-error"}`},
+		{"GET", "/subscriptions/" + uuid.NewString(), "ru", nil, 404, `{"detail":"подписка не найдена","status":404,"title":"Не найдено","type":"about:blank"}`},
+		{"GET", "/subscriptions/" + uuid.NewString(), "", nil, 404, `{"detail":"subscription not found","status":404,"title":"Not Found","type":"about:blank"}`},
+		{"GET", "/subscriptions/" + uuid.NewString(), "en;q=0.3, ru;q=0.7", nil, 404, `{"detail":"подписка не найдена","status":404,"title":"Не найдено","type":"about:blank"}`},
+		{"GET", "/services/" + uuid.NewString(), "ru", nil, 404, `{"detail":"сервис не найден","status":404,"title":"Не найдено","type":"about:blank"}`},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(c.method, c.path, bytes.NewBuffer(c.body))
+		req.Header.Set("Content-Type", "application/json")
+		if c.language != "" {
+			req.Header.Set("Accept-Language", c.language)
+		}
+
+		resp, _ := webApp.Test(req)
+		respBody, _ := io.ReadAll(resp.Body)
+
+		if resp.StatusCode != c.status {
+			t.Errorf("%s %s: invalid status code: %d", c.path, c.language, resp.StatusCode)
+		}
+		if !jsonEqual(respBody, []byte(c.expected)) {
+			t.Errorf("%s %s: incorrect problem: %s", c.path, c.language, string(respBody))
+		}
+	}
+}
+
+func TestProblemMessages(t *testing.T) {
+	server := NewServer(nil, nil, validator.New())
+
+	for _, loc := range supportedLocales {
+		trans, _ := server.Translations.GetTranslator(loc.translator.Locale())
+		for key := range loc.problems {
+			if _, err := trans.T(key); err == nil {
+				t.Errorf("%s: problem text %q registered as a validation rule", loc.translator.Locale(), key)
+			}
+		}
+	}
+
+	ctx := WithLocale(context.Background(), "ru")
+	if text := server.message(ctx, "serviceNotFound"); text != "сервис не найден" {
+		t.Errorf("unexpected message %q", text)
+	}
+	if text := server.message(ctx, "unknown"); text != "unknown" {
+		t.Errorf("expected the key of an unknown message, got %q", text)
+	}
+}
+
+func TestAcceptedLocale(t *testing.T) {
+	cases := map[string]string{
+		"":                   "",
+		"ru":                 "ru",
+		"RU-ru":              "ru",
+		"fr, en-GB;q=0.8":    "en",
+		"en;q=0, ru;q=0.1":   "ru",
+		"de":                 "",
+		"en;q=0.5, ru":       "ru",
+		"en-US,en;q=0.9,ru;": "en",
+	}
+
+	for header, expected := range cases {
+		if res := acceptedLocale(header); res != expected {
+			t.Errorf("%q: expected %q, got %q", header, expected, res)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package transport
+
+import (
+	"context"
+	"ew/internal/logging"
+	"ew/internal/models/subscriptions"
+)
+
+func (s Server) UserSummary(ctx context.Context, request UserSummaryRequestObject) (UserSummaryResponseObject, error) {
+	summary, err := s.Repo.GetUserSummary(ctx, request.UserId)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("UserSummary failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).Info("received user summary")
+
+	return UserSummary200JSONResponse(convertSummaryToResponse(summary)), nil
+}
+
+// UserSummaries reads the summaries of many users with one repository call, for the GraphQL users query.
+func (s Server) UserSummaries(ctx context.Context, userIds []UUID) (map[UUID]UserSummary, error) {
+	summaries, err := s.Repo.GetUserSummaries(ctx, userIds)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("UserSummaries failed")
+		return nil, InternalError
+	}
+	logging.FromContext(ctx).WithField("users", len(userIds)).Info("received user summaries")
+
+	res := make(map[UUID]UserSummary, len(summaries))
+	for userId, summary := range summaries {
+		res[userId] = convertSummaryToResponse(summary)
+	}
+	return res, nil
+}
+
+func convertSummaryToResponse(summary *subscriptions.UserSummary) UserSummary {
+	res := UserSummary{
+		ActiveCount:       summary.ActiveCount,
+		MonthlyRunRate:    summary.MonthlyRunRate,
+		LifetimeSpend:     summary.LifetimeSpend,
+		Last12MonthsSpend: summary.LastYearSpend,
+	}
+
+	if summary.TopServiceName != nil && summary.TopServicePrice != nil {
+		res.MostExpensiveService = &ServicePrice{ServiceName: *summary.TopServiceName, Price: int(*summary.TopServicePrice)}
+	}
+
+	if summary.NextEndDate != nil {
+		date := summary.NextEndDate.Format("01-2006")
+		res.NextEndDate = &date
+	}
+
+	return res
+}
@@ -0,0 +1,36 @@
+package transport
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestImplUserSummary(t *testing.T) {
+	webApp := prepareServ()
+
+	req := httptest.NewRequest("GET", "/users/"+repo.Items[0].UserId.String()+"/summary", nil)
+
+	resp, _ := webApp.Test(req)
+	if resp.StatusCode != 200 {
+		t.Errorf("invalid status code: %d, expected %d", resp.StatusCode, 200)
+	}
+	body, _ := io.ReadAll(resp.Body)
+
+	expected := `{"active_count":1,"last_12_months_spend":1500,"lifetime_spend":2250,"monthly_run_rate":125,"most_expensive_service":{"price":125,"service_name":"some item"}}`
+	if !bytes.Equal(bytes.TrimSpace(body), []byte(expected)) {
+		t.Errorf("incorrect summary, response body: %s", string(body))
+	}
+
+	req = httptest.NewRequest("GET", "/users/"+uuid.New().String()+"/summary", nil)
+
+	resp, _ = webApp.Test(req)
+	body, _ = io.ReadAll(resp.Body)
+
+	if !bytes.Contains(body, []byte(`"active_count":0`)) || bytes.Contains(body, []byte("most_expensive_service")) {
+		t.Errorf("incorrect summary, response body: %s", string(body))
+	}
+}
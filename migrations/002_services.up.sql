@@ -0,0 +1,23 @@
+CREATE TABLE services (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    category VARCHAR(255),
+    default_price INT,
+    logo_url TEXT
+);
+CREATE UNIQUE INDEX services_name ON services (lower(name));
+CREATE INDEX services_category ON services (category);
+
+ALTER TABLE subscriptions ADD COLUMN service_id UUID REFERENCES services (id) ON DELETE SET NULL;
+CREATE INDEX service_id ON subscriptions (service_id);
+
+INSERT INTO services (name, default_price)
+SELECT DISTINCT ON (lower(trim(service_name))) trim(service_name), price
+FROM subscriptions
+ORDER BY lower(trim(service_name)), start_date DESC;
+
+UPDATE subscriptions
+SET service_id = services.id, service_name = services.name
+FROM services
+WHERE lower(trim(subscriptions.service_name)) = lower(services.name);
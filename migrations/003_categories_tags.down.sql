@@ -0,0 +1,2 @@
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS tags;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS category;
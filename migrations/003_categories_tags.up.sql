@@ -0,0 +1,9 @@
+ALTER TABLE subscriptions ADD COLUMN category VARCHAR(255);
+ALTER TABLE subscriptions ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+CREATE INDEX category ON subscriptions (category);
+CREATE INDEX tags ON subscriptions USING GIN (tags);
+
+UPDATE subscriptions
+SET category = services.category
+FROM services
+WHERE subscriptions.service_id = services.id AND services.category IS NOT NULL;
@@ -0,0 +1,9 @@
+CREATE TABLE subscription_pauses (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    resume_date DATE,
+    CHECK (resume_date >= start_date)
+);
+CREATE INDEX subscription_pauses_subscription_id ON subscription_pauses (subscription_id);
+CREATE UNIQUE INDEX subscription_pauses_open ON subscription_pauses (subscription_id) WHERE resume_date IS NULL;
@@ -0,0 +1,25 @@
+-- spend per user, service and month, rebuilt from subscriptions by the rollup command and kept in sync on writes;
+-- start_month lets /stats apply its filter on the subscription start without reading subscriptions
+CREATE TABLE monthly_spend (
+    user_id UUID NOT NULL,
+    service_name VARCHAR(255) NOT NULL,
+    start_month DATE NOT NULL,
+    month DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    PRIMARY KEY (user_id, service_name, start_month, month)
+);
+CREATE INDEX monthly_spend_month ON monthly_spend (month);
+CREATE INDEX monthly_spend_service_name ON monthly_spend (service_name);
+
+INSERT INTO monthly_spend (user_id, service_name, start_month, month, amount)
+SELECT subscriptions.user_id, subscriptions.service_name, date_trunc('month', subscriptions.start_date)::date, months.month::date, SUM(subscriptions.price)
+FROM subscriptions
+CROSS JOIN generate_series(subscriptions.start_date, COALESCE(subscriptions.end_date, date_trunc('month', now())::date), INTERVAL '1 month') AS months(month)
+WHERE months.month >= GREATEST(subscriptions.start_date, (subscriptions.trial_end_date + INTERVAL '1 month')::date)
+    AND NOT EXISTS (
+        SELECT 1 FROM subscription_pauses AS pause
+        WHERE pause.subscription_id = subscriptions.id
+            AND pause.start_date <= months.month
+            AND (pause.resume_date IS NULL OR pause.resume_date > months.month)
+    )
+GROUP BY 1, 2, 3, 4;
@@ -0,0 +1,18 @@
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_period_check;
+-- the constraint holds for the new rows right away, the existing ones are checked without changing them
+ALTER TABLE subscriptions ADD CONSTRAINT subscriptions_period_check CHECK (end_date IS NULL OR end_date >= start_date) NOT VALID;
+
+DO $$
+DECLARE
+    invalid text;
+BEGIN
+    SELECT string_agg(id::text, ', ' ORDER BY id) INTO invalid FROM subscriptions WHERE end_date < start_date;
+
+    IF invalid IS NULL THEN
+        ALTER TABLE subscriptions VALIDATE CONSTRAINT subscriptions_period_check;
+    ELSE
+        RAISE WARNING 'subscriptions ending before they start: %', invalid
+            USING HINT = 'fix their periods and run ALTER TABLE subscriptions VALIDATE CONSTRAINT subscriptions_period_check';
+    END IF;
+END
+$$;
@@ -0,0 +1,8 @@
+CREATE TABLE subscription_events (
+    id BIGSERIAL PRIMARY KEY,
+    type TEXT NOT NULL,
+    subscription_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS services_name;
+CREATE UNIQUE INDEX services_name ON services (lower(name));
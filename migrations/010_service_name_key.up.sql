@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS services_name;
+
+UPDATE services SET name = btrim(name) WHERE name <> btrim(name);
+
+CREATE UNIQUE INDEX services_name ON services (lower(btrim(name)));